@@ -8,7 +8,7 @@ import (
 // @version 1.0
 // @description API for auth service
 // @host localhost:3100
-// @BasePath /
+// @BasePath /api/v1
 // @schemes http
 func main() {
 	app.RunAuthServer()
@@ -6,7 +6,7 @@ import "github.com/Van-programan/Forum_GO/internal/app"
 // @version 1.0
 // @description API for forum service
 // @host localhost:3101
-// @BasePath /
+// @BasePath /api/v1
 // @schemes http
 func main() {
 	app.RunForumServer()
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -11,19 +12,30 @@ type (
 	ConfigAuth struct {
 		App      App
 		AuthInfo AuthInfo
+		GRPC     GRPC
+		MTLS     MTLS
 		Log      Log
 		JWT      JWT
 		PGAuth   PGAuth
 		Swagger  Swagger
+		OIDC     OIDC
+		Password Password
 	}
 
 	ConfigForum struct {
-		App       App
-		ForumInfo ForumInfo
-		Log       Log
-		PGForum   PGForum
-		Swagger   Swagger
-		JWT       JWT
+		App         App
+		ForumInfo   ForumInfo
+		GRPC        GRPC
+		Log         Log
+		PGForum     PGForum
+		Swagger     Swagger
+		JWT         JWT
+		UserCache   UserCache
+		UserClient  UserClient
+		UserSync    UserSync
+		MTLS        MTLS
+		Idempotency Idempotency
+		ReadCache   ReadCache
 	}
 
 	App struct {
@@ -37,9 +49,80 @@ type (
 		GRPCPort string `env:"GRPC_PORT" envDefault:"50051"`
 	}
 
+	// GRPC configures the gRPC servers of the user service and the forum. A zero MaxDeadline leaves
+	// deadlines to the caller.
+	GRPC struct {
+		Reflection     bool          `env:"GRPC_REFLECTION" envDefault:"false"`
+		MaxDeadline    time.Duration `env:"GRPC_MAX_DEADLINE" envDefault:"10s"`
+		HealthInterval time.Duration `env:"GRPC_HEALTH_INTERVAL" envDefault:"5s"`
+		// KeepaliveMinTime is the shortest ping interval clients may use without being disconnected.
+		KeepaliveMinTime time.Duration `env:"GRPC_KEEPALIVE_MIN_TIME" envDefault:"20s"`
+		// AllowedIdentities are the URI or DNS SANs of client certificates allowed to call the server.
+		// Empty lets in any client whose certificate the CA signed. Only checked with mTLS on.
+		AllowedIdentities []string `env:"GRPC_ALLOWED_IDENTITIES" envSeparator:","`
+	}
+
+	// MTLS secures the gRPC links between services. The forum reads ServerName as the name the user
+	// service's certificate must be issued for, and also serves ForumService with its own certificate.
+	MTLS struct {
+		Enabled        bool          `env:"MTLS_ENABLED" envDefault:"false"`
+		CertFile       string        `env:"MTLS_CERT_FILE"`
+		KeyFile        string        `env:"MTLS_KEY_FILE"`
+		CAFile         string        `env:"MTLS_CA_FILE"`
+		ServerName     string        `env:"MTLS_SERVER_NAME" envDefault:"user-service"`
+		ReloadInterval time.Duration `env:"MTLS_RELOAD_INTERVAL" envDefault:"1m"`
+	}
+
 	ForumInfo struct {
-		Server   string `env:"FORUM_SERVICE" envDefault:":3101"`
-		GRPCPort string `env:"GRPC_PORT" envDefault:"50051"`
+		Server string `env:"FORUM_SERVICE" envDefault:":3101"`
+		// GRPCPort serves the internal ForumService API; empty, the default, turns it off. Its callers post
+		// as any user, so the forum refuses to serve it without mTLS and GRPC_ALLOWED_IDENTITIES.
+		GRPCPort string `env:"FORUM_GRPC_PORT"`
+	}
+
+	// UserCache bounds the forum's cache of user profiles. A zero size turns the cache off.
+	UserCache struct {
+		Size        int           `env:"USER_CACHE_SIZE" envDefault:"10000"`
+		TTL         time.Duration `env:"USER_CACHE_TTL" envDefault:"10m"`
+		NegativeTTL time.Duration `env:"USER_CACHE_NEGATIVE_TTL" envDefault:"1m"`
+	}
+
+	// UserClient tunes the forum's calls to the user service.
+	UserClient struct {
+		// Addr is a host:port resolved through DNS, a comma-separated list of host:port endpoints
+		// or a gRPC target such as dns:///user-service:50051.
+		Addr             string        `env:"USER_SERVICE_ADDR" envDefault:"localhost:50051"`
+		KeepaliveTime    time.Duration `env:"USER_CLIENT_KEEPALIVE_TIME" envDefault:"30s"`
+		KeepaliveTimeout time.Duration `env:"USER_CLIENT_KEEPALIVE_TIMEOUT" envDefault:"10s"`
+		Timeout          time.Duration `env:"USER_CLIENT_TIMEOUT" envDefault:"5s"`
+		MaxAttempts      int           `env:"USER_CLIENT_MAX_ATTEMPTS" envDefault:"3"`
+		InitialBackoff   time.Duration `env:"USER_CLIENT_INITIAL_BACKOFF" envDefault:"100ms"`
+		MaxBackoff       time.Duration `env:"USER_CLIENT_MAX_BACKOFF" envDefault:"1s"`
+		BreakerThreshold int           `env:"USER_CLIENT_BREAKER_THRESHOLD" envDefault:"5"`
+		BreakerCooldown  time.Duration `env:"USER_CLIENT_BREAKER_COOLDOWN" envDefault:"30s"`
+		// BatchWait is how long username and profile lookups wait to be sent together; zero turns batching off.
+		BatchWait time.Duration `env:"USER_CLIENT_BATCH_WAIT" envDefault:"2ms"`
+		MaxBatch  int           `env:"USER_CLIENT_MAX_BATCH" envDefault:"500"`
+	}
+
+	// UserSync controls the mirror of user accounts in the forum database. Changes are synced as the user
+	// service announces them; Interval is the fallback for missed announcements, zero turns it off.
+	UserSync struct {
+		Interval time.Duration `env:"USER_SYNC_INTERVAL" envDefault:"1m"`
+	}
+
+	// Idempotency controls how long the replies to POST requests sent with an Idempotency-Key are kept
+	// for retries. LockTTL bounds how long a request that never finished holds its key.
+	Idempotency struct {
+		TTL     time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+		LockTTL time.Duration `env:"IDEMPOTENCY_LOCK_TTL" envDefault:"1m"`
+	}
+
+	// ReadCache bounds each of the forum's in-process caches of categories and topics. A zero size turns
+	// them off.
+	ReadCache struct {
+		Size int           `env:"READ_CACHE_SIZE" envDefault:"1000"`
+		TTL  time.Duration `env:"READ_CACHE_TTL" envDefault:"5m"`
 	}
 
 	Log struct {
@@ -73,6 +156,31 @@ type (
 	Swagger struct {
 		Enabled bool `env:"SWAGGER_ENABLED" envDefault:"true"`
 	}
+
+	OIDC struct {
+		Providers []string `env:"OIDC_PROVIDERS" envSeparator:","`
+		// RedirectBaseURL is the public origin of the auth service. Providers redirect back to
+		// RedirectBaseURL/api/v1/oidc/<provider>/callback.
+		RedirectBaseURL string `env:"OIDC_REDIRECT_BASE_URL" envDefault:"http://localhost:3100"`
+	}
+
+	// Password configures Argon2id hashing and the policy new passwords must pass.
+	Password struct {
+		MinLength         int    `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+		MaxLength         int    `env:"PASSWORD_MAX_LENGTH" envDefault:"128"`
+		CommonListPath    string `env:"PASSWORD_COMMON_LIST"`
+		Argon2Memory      uint32 `env:"ARGON2_MEMORY_KB" envDefault:"65536"`
+		Argon2Iterations  uint32 `env:"ARGON2_ITERATIONS" envDefault:"3"`
+		Argon2Parallelism uint8  `env:"ARGON2_PARALLELISM" envDefault:"2"`
+	}
+
+	// OIDCProvider is read from OIDC_<NAME>_* variables for each name listed in OIDC_PROVIDERS.
+	OIDCProvider struct {
+		Issuer       string   `env:"ISSUER,required"`
+		ClientID     string   `env:"CLIENT_ID,required"`
+		ClientSecret string   `env:"CLIENT_SECRET"`
+		Scopes       []string `env:"SCOPES" envSeparator:"," envDefault:"openid,profile,email"`
+	}
 )
 
 func NewConfigAuth() (*ConfigAuth, error) {
@@ -92,3 +200,13 @@ func NewConfigForum() (*ConfigForum, error) {
 
 	return cfg, nil
 }
+
+func (o OIDC) Provider(name string) (*OIDCProvider, error) {
+	cfg := &OIDCProvider{}
+	opts := env.Options{Prefix: "OIDC_" + strings.ToUpper(name) + "_"}
+	if err := env.ParseWithOptions(cfg, opts); err != nil {
+		return nil, fmt.Errorf("config error: oidc provider %s: %w", name, err)
+	}
+
+	return cfg, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 
 	"syscall"
 	"time"
@@ -18,6 +19,8 @@ import (
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
 	"github.com/Van-programan/Forum_GO/pkg/logger"
 	"github.com/Van-programan/Forum_GO/pkg/migrator"
+	"github.com/Van-programan/Forum_GO/pkg/oidc"
+	"github.com/Van-programan/Forum_GO/pkg/password"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
 )
 
@@ -56,13 +59,59 @@ func RunAuthServer() {
 	migrator.Up()
 	userRepo := repo.NewUserRepository(pg, logger)
 	tokenRepo := repo.NewRefreshTokenRepository(pg, logger)
+	mfaRepo := repo.NewMFARepository(pg, logger)
+	roleRepo := repo.NewRoleRepository(pg, logger)
+	banRepo := repo.NewBanRepository(pg, logger)
 
 	jwt := jwt.New(cfg.JWT.Secret, cfg.JWT.Access_TTL, cfg.JWT.Refresh_TTL)
 
-	authUC := usecase.NewAuthUsecase(userRepo, tokenRepo, jwt, logger)
+	commonPasswords, err := password.LoadCommonPasswords(cfg.Password.CommonListPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	policy := password.NewPolicy(cfg.Password.MinLength, cfg.Password.MaxLength, commonPasswords)
+	hasher := password.NewArgon2id(password.Params{
+		Memory:      cfg.Password.Argon2Memory,
+		Iterations:  cfg.Password.Argon2Iterations,
+		Parallelism: cfg.Password.Argon2Parallelism,
+		SaltLength:  password.DefaultParams.SaltLength,
+		KeyLength:   password.DefaultParams.KeyLength,
+	})
+
+	authUC := usecase.NewAuthUsecase(userRepo, tokenRepo, mfaRepo, roleRepo, banRepo, hasher, policy, jwt, cfg.App.AppName, logger)
+
+	providers := make(map[string]*oidc.Provider, len(cfg.OIDC.Providers))
+	for _, name := range cfg.OIDC.Providers {
+		providerCfg, err := cfg.OIDC.Provider(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		provider, err := oidc.NewProvider(ctx, oidc.Config{
+			Issuer:       providerCfg.Issuer,
+			ClientID:     providerCfg.ClientID,
+			ClientSecret: providerCfg.ClientSecret,
+			RedirectURL:  strings.TrimSuffix(cfg.OIDC.RedirectBaseURL, "/") + route.APIv1 + "/oidc/" + name + "/callback",
+			Scopes:       providerCfg.Scopes,
+		})
+		if err != nil {
+			logger.Error().Err(err).Str("provider", name).Msg("Failed to set up oidc provider, skipping it")
+			continue
+		}
+		providers[name] = provider
+	}
+	identityRepo := repo.NewIdentityRepository(pg, logger)
+	oidcUC := usecase.NewOIDCUsecase(providers, identityRepo, userRepo, tokenRepo, mfaRepo, roleRepo, banRepo, jwt, logger)
+	moderatorUC := usecase.NewModeratorUsecase(roleRepo, userRepo, logger)
+	adminRepo := repo.NewAdminRepository(pg, logger)
+	adminUC := usecase.NewAdminUsecase(adminRepo, logger)
+	personalTokenRepo := repo.NewPersonalTokenRepository(pg, logger)
+	personalTokenUC := usecase.NewPersonalTokenUsecase(personalTokenRepo, logger)
+	profileRepo := repo.NewProfileRepository(pg, logger)
+	profileUC := usecase.NewProfileUsecase(profileRepo, logger)
 	fmt.Println("04")
 	httpServer := httpserver.New(cfg.AuthInfo.Server)
-	route.NewAuthRouter(httpServer.Engine, authUC, jwt, logger)
+	route.NewAuthRouter(httpServer.Engine, authUC, oidcUC, moderatorUC, adminUC, personalTokenUC, profileUC, jwt, logger)
 
 	httpServer.Run()
 	interrupt := make(chan os.Signal, 1)
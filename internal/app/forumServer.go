@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,7 +12,11 @@ import (
 
 	"github.com/Van-programan/Forum_GO/config"
 	"github.com/Van-programan/Forum_GO/internal/client"
+	"github.com/Van-programan/Forum_GO/internal/controller"
+	"github.com/Van-programan/Forum_GO/internal/controller/grpc"
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
 	"github.com/Van-programan/Forum_GO/internal/controller/route"
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/repo"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/internal/ws"
@@ -20,6 +25,11 @@ import (
 	"github.com/Van-programan/Forum_GO/pkg/logger"
 	"github.com/Van-programan/Forum_GO/pkg/migrator"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	forumpb "github.com/Van-programan/Forum_GO/pkg/proto/forum"
+	Grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
 func RunForumServer() {
@@ -60,27 +70,113 @@ func RunForumServer() {
 	topicRepo := repo.NewTopicRepository(pg, logger)
 	postRepo := repo.NewPostRepository(pg, logger)
 	chatRepo := repo.NewChatRepository(pg, logger)
+	userMirrorRepo := repo.NewUserMirrorRepository(pg, logger)
+	idempotencyRepo := repo.NewIdempotencyRepository(pg, logger)
+
+	certCtx, stopCerts := context.WithCancel(context.Background())
+	defer stopCerts()
+
+	clientCfg := client.Config{
+		Timeout:          cfg.UserClient.Timeout,
+		MaxAttempts:      cfg.UserClient.MaxAttempts,
+		InitialBackoff:   cfg.UserClient.InitialBackoff,
+		MaxBackoff:       cfg.UserClient.MaxBackoff,
+		BreakerThreshold: cfg.UserClient.BreakerThreshold,
+		BreakerCooldown:  cfg.UserClient.BreakerCooldown,
+		KeepaliveTime:    cfg.UserClient.KeepaliveTime,
+		KeepaliveTimeout: cfg.UserClient.KeepaliveTimeout,
+	}
+	reloader := startCertReloader(certCtx, cfg.MTLS, logger)
+	if reloader != nil {
+		clientCfg.TLS = reloader.ClientTLSConfig(cfg.MTLS.ServerName)
+	}
 
-	userClient, err := client.New(cfg.ForumInfo.GRPCPort, logger)
+	userClient, err := client.New(cfg.UserClient.Addr, clientCfg, logger)
 	if err != nil {
 		log.Fatalf("app - Run - client.New: %v", err)
 	}
+	if cfg.UserClient.BatchWait > 0 {
+		userClient = client.NewBatched(userClient, client.BatchConfig{
+			Wait:     cfg.UserClient.BatchWait,
+			MaxBatch: cfg.UserClient.MaxBatch,
+		})
+	}
+	if cfg.UserCache.Size > 0 {
+		userClient = client.NewCached(userClient, client.CacheConfig{
+			Size:        cfg.UserCache.Size,
+			TTL:         cfg.UserCache.TTL,
+			NegativeTTL: cfg.UserCache.NegativeTTL,
+		}, logger)
+	}
 	defer userClient.Close()
 
+	syncCtx, stopSync := context.WithCancel(context.Background())
+	defer stopSync()
+	go usecase.NewUserSync(userMirrorRepo, userClient, cfg.UserSync.Interval, logger).Run(syncCtx)
+
+	var caches []controller.CacheStatsReporter
+	if cfg.ReadCache.Size > 0 {
+		cacheCfg := repo.CacheConfig{Size: cfg.ReadCache.Size, TTL: cfg.ReadCache.TTL}
+		cachedCategories := repo.NewCachedCategoryRepository(categoryRepo, cacheCfg)
+		cachedTopics := repo.NewCachedTopicRepository(topicRepo, cacheCfg)
+		categoryRepo, topicRepo = cachedCategories, cachedTopics
+		caches = append(caches, cachedCategories, cachedTopics)
+
+		go repo.NewForumChangeListener(dbURL, logger).Listen(syncCtx, func(change entity.ForumChange) {
+			cachedCategories.Invalidate(change)
+			cachedTopics.Invalidate(change)
+		})
+	}
+
 	categoryUC := usecase.NewCategoryUsecase(categoryRepo, logger)
 	topicUC := usecase.NewTopicUsecase(topicRepo, categoryRepo, userClient, logger)
 	postUC := usecase.NewPostUsecase(postRepo, topicRepo, userClient, logger)
 
 	jwt := jwt.New(cfg.JWT.Secret, cfg.JWT.Access_TTL, cfg.JWT.Refresh_TTL)
 
-	hub := ws.NewHub(logger)
+	hub := ws.NewHub(logger, userClient)
 	go hub.Run()
-	chatUC := usecase.NewChatUsecase(chatRepo, logger)
+	chatUC := usecase.NewChatUsecase(chatRepo, userClient, logger)
 
 	httpServer := httpserver.New(cfg.ForumInfo.Server)
-	route.NewForumRouter(httpServer.Engine, categoryUC, topicUC, postUC, jwt, logger, hub, chatUC, userClient)
+	idempotencyCfg := middleware.IdempotencyConfig{TTL: cfg.Idempotency.TTL, LockTTL: cfg.Idempotency.LockTTL}
+	route.NewForumRouter(httpServer.Engine, categoryUC, topicUC, postUC, jwt, logger, hub, chatUC, userClient, idempotencyRepo, idempotencyCfg, caches)
 	httpServer.Run()
 
+	if cfg.ForumInfo.GRPCPort != "" {
+		// Callers of ForumService post as any user, so only allowlisted services may reach it.
+		if reloader == nil || len(cfg.GRPC.AllowedIdentities) == 0 {
+			log.Fatalf("app - Run - FORUM_GRPC_PORT requires MTLS_ENABLED and GRPC_ALLOWED_IDENTITIES")
+		}
+
+		serverOpts := []Grpc.ServerOption{
+			Grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             cfg.GRPC.KeepaliveMinTime,
+				PermitWithoutStream: true,
+			}),
+		}
+		serverOpts = append(serverOpts, Grpc.Creds(credentials.NewTLS(reloader.ServerTLSConfig())))
+		serverOpts = append(serverOpts, grpc.ServerOptions(cfg.GRPC.MaxDeadline, cfg.GRPC.AllowedIdentities, logger)...)
+
+		grpcServer := Grpc.NewServer(serverOpts...)
+		grpc.RegisterForum(grpcServer, categoryUC, topicUC, postUC, logger)
+		grpc.RegisterHealth(certCtx, grpcServer, pg.Pool, cfg.GRPC.HealthInterval, logger, forumpb.ForumService_ServiceDesc.ServiceName)
+		if cfg.GRPC.Reflection {
+			reflection.Register(grpcServer)
+		}
+
+		l, err := net.Listen("tcp", ":"+cfg.ForumInfo.GRPCPort)
+		if err != nil {
+			log.Fatalf("app - Run - net.Listen: %v", err)
+		}
+		go func() {
+			if err := grpcServer.Serve(l); err != nil {
+				log.Fatalf("app - Run - grpcServer.Serve: %v", err)
+			}
+		}()
+		defer grpcServer.Stop()
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
 	<-interrupt
@@ -16,7 +16,11 @@ import (
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/pkg/logger"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
 	Grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
 func RunGrpcServer() {
@@ -47,10 +51,37 @@ func RunGrpcServer() {
 
 	userRepo := repo.New(pg, logger)
 
-	userUsecase := usecase.New(userRepo, logger)
+	banRepo := repo.NewBanRepository(pg, logger)
+	tokenRepo := repo.NewPersonalTokenRepository(pg, logger)
+	profileRepo := repo.NewProfileRepository(pg, logger)
 
-	grpcServer := Grpc.NewServer()
+	changes := usecase.NewUserChangeBroker(logger)
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	go repo.NewUserChangeListener(dbURL, logger).Listen(listenCtx, changes.Publish)
+
+	replicationRepo := repo.NewUserReplicationRepository(pg, logger)
+	userUsecase := usecase.New(userRepo, banRepo, tokenRepo, profileRepo, replicationRepo, changes, logger)
+
+	serverOpts := []Grpc.ServerOption{
+		Grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.GRPC.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+	}
+	allowedIdentities := []string(nil)
+	if reloader := startCertReloader(listenCtx, cfg.MTLS, logger); reloader != nil {
+		serverOpts = append(serverOpts, Grpc.Creds(credentials.NewTLS(reloader.ServerTLSConfig())))
+		allowedIdentities = cfg.GRPC.AllowedIdentities
+	}
+	serverOpts = append(serverOpts, grpc.ServerOptions(cfg.GRPC.MaxDeadline, allowedIdentities, logger)...)
+
+	grpcServer := Grpc.NewServer(serverOpts...)
 	grpc.Register(grpcServer, userUsecase, logger)
+	grpc.RegisterHealth(listenCtx, grpcServer, pg.Pool, cfg.GRPC.HealthInterval, logger, userpb.UserService_ServiceDesc.ServiceName)
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
 
 	l, err := net.Listen("tcp", ":"+cfg.AuthInfo.GRPCPort)
 	if err != nil {
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"log"
+
+	"github.com/Van-programan/Forum_GO/config"
+	"github.com/Van-programan/Forum_GO/pkg/mtls"
+	"github.com/rs/zerolog"
+)
+
+// startCertReloader loads the mTLS certificates and keeps them fresh until ctx is done. It returns nil
+// when mTLS is off.
+func startCertReloader(ctx context.Context, cfg config.MTLS, logger *zerolog.Logger) *mtls.Reloader {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	reloader, err := mtls.NewReloader(mtls.Config{
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		CAFile:         cfg.CAFile,
+		ReloadInterval: cfg.ReloadInterval,
+	}, logger)
+	if err != nil {
+		log.Fatalf("app - Run - mtls.NewReloader: %v", err)
+	}
+	go reloader.Watch(ctx)
+	return reloader
+}
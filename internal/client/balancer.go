@@ -0,0 +1,56 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/health" // enables the client-side health checks named in serviceConfig
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// staticScheme names the resolver used for a fixed list of user service endpoints.
+const staticScheme = "user-static"
+
+// serviceConfig spreads calls over every user service replica and stops sending calls to a replica whose
+// health service reports it is not serving, e.g. because it lost its database.
+var serviceConfig = fmt.Sprintf(`{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": %q}
+}`, userpb.UserService_ServiceDesc.ServiceName)
+
+// dialTarget turns USER_SERVICE_ADDR into a gRPC target. It accepts a target with a scheme such as
+// "dns:///user-service:50051", a comma-separated list of host:port endpoints, one host:port, whose name
+// is resolved through DNS so every A record becomes an endpoint, or a bare port on localhost.
+func dialTarget(addr string) (string, []grpc.DialOption, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return "", nil, fmt.Errorf("clients.user - dialTarget: empty user service address")
+	}
+	if strings.Contains(addr, "://") {
+		return addr, nil, nil
+	}
+
+	endpoints := strings.Split(addr, ",")
+	if len(endpoints) == 1 {
+		if !strings.Contains(addr, ":") {
+			addr = "localhost:" + addr
+		}
+		return "dns:///" + addr, nil, nil
+	}
+
+	state := resolver.State{}
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		state.Endpoints = append(state.Endpoints, resolver.Endpoint{Addresses: []resolver.Address{{Addr: endpoint}}})
+	}
+
+	static := manual.NewBuilderWithScheme(staticScheme)
+	static.InitialState(state)
+	return staticScheme + ":///user-service", []grpc.DialOption{grpc.WithResolvers(static)}, nil
+}
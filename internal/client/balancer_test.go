@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestDialTarget(t *testing.T) {
+	tests := []struct {
+		addr       string
+		wantTarget string
+		wantOpts   int
+	}{
+		{"50051", "dns:///localhost:50051", 0},
+		{"user-service:50051", "dns:///user-service:50051", 0},
+		{"dns:///user-service:50051", "dns:///user-service:50051", 0},
+		{"10.0.0.1:50051, 10.0.0.2:50051", staticScheme + ":///user-service", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			target, opts, err := dialTarget(tt.addr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTarget, target)
+			assert.Len(t, opts, tt.wantOpts)
+		})
+	}
+
+	_, _, err := dialTarget(" ")
+	assert.Error(t, err)
+}
+
+// countingUserServer answers GetUsername and counts the calls it served.
+type countingUserServer struct {
+	userpb.UnimplementedUserServiceServer
+	calls  atomic.Int64
+	health *health.Server
+}
+
+func (s *countingUserServer) GetUsername(ctx context.Context, req *userpb.GetUsernameRequest) (*userpb.GetUsernameResponse, error) {
+	s.calls.Add(1)
+	return &userpb.GetUsernameResponse{UserId: req.UserId, Username: "alice"}, nil
+}
+
+func startUserServer(t *testing.T) (*countingUserServer, string) {
+	srv := &countingUserServer{health: health.NewServer()}
+	srv.health.SetServingStatus(userpb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	server := grpc.NewServer()
+	userpb.RegisterUserServiceServer(server, srv)
+	healthpb.RegisterHealthServer(server, srv.health)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(l)
+	t.Cleanup(server.Stop)
+	return srv, l.Addr().String()
+}
+
+func TestNew_BalancesOverHealthyEndpoints(t *testing.T) {
+	first, firstAddr := startUserServer(t)
+	second, secondAddr := startUserServer(t)
+
+	logger := zerolog.Nop()
+	c, err := New(firstAddr+","+secondAddr, Config{Timeout: 2 * time.Second, MaxAttempts: 1}, &logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	// Round robin only starts once both subchannels are ready, so keep calling until both served some.
+	assert.Eventually(t, func() bool {
+		_, err := c.GetUsername(context.Background(), 1)
+		return err == nil && first.calls.Load() > 0 && second.calls.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	first.health.SetServingStatus(userpb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	assert.Eventually(t, func() bool {
+		before := first.calls.Load()
+		for range 4 {
+			if _, err := c.GetUsername(context.Background(), 1); err != nil {
+				return false
+			}
+		}
+		return first.calls.Load() == before
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// withOutgoingRequestID forwards the request ID of the HTTP request being served, if any, to the user service.
+func withOutgoingRequestID(ctx context.Context) context.Context {
+	if id := requestid.FromContext(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+	}
+	return ctx
+}
+
+func requestIDUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withOutgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+func requestIDStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withOutgoingRequestID(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIDUnaryInterceptor(t *testing.T) {
+	var got []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get(requestid.MetadataKey)
+		return nil
+	}
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	assert.NoError(t, requestIDUnaryInterceptor(ctx, "/user.UserService/GetUsername", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"req-1"}, got)
+
+	assert.NoError(t, requestIDUnaryInterceptor(context.Background(), "/user.UserService/GetUsername", nil, nil, nil, invoker))
+	assert.Empty(t, got)
+}
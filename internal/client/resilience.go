@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrCircuitOpen = errors.New("user service circuit breaker is open")
+
+// idempotentMethods may be retried: they only read, apart from ValidateToken touching last_used_at.
+var idempotentMethods = map[string]bool{
+	userpb.UserService_GetUsernames_FullMethodName:    true,
+	userpb.UserService_GetUsername_FullMethodName:     true,
+	userpb.UserService_GetUserStatus_FullMethodName:   true,
+	userpb.UserService_GetUserStatuses_FullMethodName: true,
+	userpb.UserService_ValidateToken_FullMethodName:   true,
+	userpb.UserService_GetUserProfiles_FullMethodName: true,
+	userpb.UserService_GetUserProfile_FullMethodName:  true,
+}
+
+// retryPolicy retries idempotent calls that failed with Unavailable, with jittered exponential backoff.
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func (p retryPolicy) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !idempotentMethods[method] {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	backoff := p.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || attempt >= p.maxAttempts || status.Code(err) != codes.Unavailable {
+			return err
+		}
+
+		wait := backoff/2 + rand.N(backoff/2+1)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, p.maxBackoff)
+	}
+}
+
+// circuitBreaker stops calling the user service after threshold consecutive failures. Once cooldown
+// has passed a single trial call is let through; its success closes the circuit, its failure opens it again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+	now       func() time.Time
+	log       *zerolog.Logger
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, log *zerolog.Logger) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now, log: log}
+}
+
+func (b *circuitBreaker) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !b.allow() {
+		return ErrCircuitOpen
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.record(err)
+	return err
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch {
+	case isServiceFailure(err):
+		b.failures++
+		if b.failures >= b.threshold {
+			if b.failures == b.threshold {
+				b.log.Warn().Str("op", "UserClient.circuitBreaker").Err(err).Dur("cooldown", b.cooldown).Msg("Circuit opened")
+			}
+			b.openUntil = b.now().Add(b.cooldown)
+		}
+	case status.Code(err) == codes.Canceled:
+		// The caller gave up; that says nothing about the service.
+	default:
+		if b.failures >= b.threshold {
+			b.log.Info().Str("op", "UserClient.circuitBreaker").Msg("Circuit closed")
+		}
+		b.failures = 0
+	}
+}
+
+// isServiceFailure reports whether err means the user service is unhealthy rather than that the request was rejected.
+func isServiceFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedInvoker returns the given errors in order, then nil, and counts the calls.
+func scriptedInvoker(calls *int, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func testRetryPolicy() retryPolicy {
+	return retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: 2 * time.Millisecond}
+}
+
+func TestRetryPolicy_RetriesUnavailable(t *testing.T) {
+	calls := 0
+	unavailable := status.Error(codes.Unavailable, "down")
+	invoker := scriptedInvoker(&calls, unavailable, unavailable)
+
+	err := testRetryPolicy().unaryInterceptor(context.Background(), userpb.UserService_GetUserProfiles_FullMethodName, nil, nil, nil, invoker)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryPolicy_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	unavailable := status.Error(codes.Unavailable, "down")
+	invoker := scriptedInvoker(&calls, unavailable, unavailable, unavailable, unavailable)
+
+	err := testRetryPolicy().unaryInterceptor(context.Background(), userpb.UserService_GetUsername_FullMethodName, nil, nil, nil, invoker)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryPolicy_DoesNotRetryOtherCodes(t *testing.T) {
+	calls := 0
+	invoker := scriptedInvoker(&calls, status.Error(codes.NotFound, "no such user"))
+
+	err := testRetryPolicy().unaryInterceptor(context.Background(), userpb.UserService_GetUserProfile_FullMethodName, nil, nil, nil, invoker)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_DoesNotRetryNonIdempotent(t *testing.T) {
+	calls := 0
+	invoker := scriptedInvoker(&calls, status.Error(codes.Unavailable, "down"))
+
+	err := testRetryPolicy().unaryInterceptor(context.Background(), "/user.UserService/Unknown", nil, nil, nil, invoker)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	logger := zerolog.Nop()
+	b := newCircuitBreaker(2, time.Minute, &logger)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	calls := 0
+	unavailable := status.Error(codes.Unavailable, "down")
+	failing := scriptedInvoker(&calls, unavailable, unavailable, unavailable)
+	call := func() error {
+		return b.unaryInterceptor(context.Background(), userpb.UserService_GetUsername_FullMethodName, nil, nil, nil, failing)
+	}
+
+	assert.Error(t, call())
+	assert.Error(t, call())
+	assert.ErrorIs(t, call(), ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	// After the cooldown one probe goes through; it fails and the circuit opens again.
+	now = now.Add(time.Minute)
+	assert.Equal(t, codes.Unavailable, status.Code(call()))
+	assert.ErrorIs(t, call(), ErrCircuitOpen)
+	assert.Equal(t, 3, calls)
+
+	// The next probe succeeds and closes the circuit.
+	now = now.Add(time.Minute)
+	assert.NoError(t, call())
+	assert.NoError(t, call())
+	assert.Equal(t, 5, calls)
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	logger := zerolog.Nop()
+	b := newCircuitBreaker(1, time.Minute, &logger)
+
+	for _, err := range []error{
+		status.Error(codes.NotFound, "no such user"),
+		status.Error(codes.Canceled, "caller left"),
+		status.Error(codes.InvalidArgument, "bad id"),
+	} {
+		b.record(err)
+	}
+	assert.True(t, b.allow())
+
+	b.record(errors.New("plain error"))
+	assert.False(t, b.allow())
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/cache"
+)
+
+const (
+	tokenCacheTTL        = 30 * time.Second
+	invalidTokenCacheTTL = 10 * time.Second
+	// tokenCacheSize bounds the cache, so a flood of made-up tokens cannot grow it without limit.
+	tokenCacheSize = 10000
+)
+
+// tokenCache remembers personal token validation results for a short time, so a bot does not cost
+// a gRPC call per request. A nil identity records an invalid token.
+type tokenCache struct {
+	entries *cache.LRU[[sha256.Size]byte, *entity.TokenIdentity]
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{entries: cache.NewLRU[[sha256.Size]byte, *entity.TokenIdentity](tokenCacheSize)}
+}
+
+func (c *tokenCache) get(token string) (*entity.TokenIdentity, bool) {
+	return c.entries.Get(sha256.Sum256([]byte(token)))
+}
+
+func (c *tokenCache) set(token string, identity *entity.TokenIdentity) {
+	ttl := tokenCacheTTL
+	if identity == nil {
+		ttl = invalidTokenCacheTTL
+	}
+	c.entries.Put(sha256.Sum256([]byte(token)), identity, ttl)
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+)
+
+// maxUserIDsPerCall matches the largest batch the user service accepts.
+const maxUserIDsPerCall = 1000
+
+// BatchConfig controls request coalescing. Lookups arriving within Wait of the first one share a batch,
+// which is sent early once it holds MaxBatch IDs.
+type BatchConfig struct {
+	Wait     time.Duration
+	MaxBatch int
+}
+
+// batchedUserClient coalesces username and profile lookups from concurrent callers into one
+// GetUsernames or GetUserProfiles call per window, asking for each ID once. Other calls go straight
+// to the wrapped client.
+type batchedUserClient struct {
+	UserClient
+	usernames *loader[string]
+	profiles  *loader[entity.UserProfile]
+}
+
+// NewBatched wraps next with request coalescing. Put it below the profile cache so only misses are batched.
+func NewBatched(next UserClient, cfg BatchConfig) UserClient {
+	return &batchedUserClient{
+		UserClient: next,
+		usernames:  newLoader(next.GetUsernames, cfg),
+		profiles:   newLoader(next.GetUserProfiles, cfg),
+	}
+}
+
+func (c *batchedUserClient) GetUsernames(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	return c.usernames.load(ctx, userIDs)
+}
+
+// GetUsername returns ErrUserNotFound for a deleted user.
+func (c *batchedUserClient) GetUsername(ctx context.Context, userID int64) (string, error) {
+	usernames, err := c.usernames.load(ctx, []int64{userID})
+	if err != nil {
+		return "", err
+	}
+	username, ok := usernames[userID]
+	if !ok {
+		return "", ErrUserNotFound
+	}
+	return username, nil
+}
+
+func (c *batchedUserClient) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]entity.UserProfile, error) {
+	return c.profiles.load(ctx, userIDs)
+}
+
+// GetUserProfile returns ErrUserNotFound for a deleted user.
+func (c *batchedUserClient) GetUserProfile(ctx context.Context, userID int64) (*entity.UserProfile, error) {
+	profiles, err := c.profiles.load(ctx, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	profile, ok := profiles[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &profile, nil
+}
+
+// loader is a dataloader keyed by user ID: it gathers IDs from concurrent load calls into batches and
+// fetches each batch once.
+type loader[V any] struct {
+	fetch    func(ctx context.Context, ids []int64) (map[int64]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending *batch[V]
+}
+
+// batch is one fetch in the making. result and err are set before done is closed.
+type batch[V any] struct {
+	ctx        context.Context
+	ids        []int64
+	index      map[int64]struct{}
+	timer      *time.Timer
+	dispatched bool
+	done       chan struct{}
+	result     map[int64]V
+	err        error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, ids []int64) (map[int64]V, error), cfg BatchConfig) *loader[V] {
+	maxBatch := cfg.MaxBatch
+	if maxBatch <= 0 || maxBatch > maxUserIDsPerCall {
+		maxBatch = maxUserIDsPerCall
+	}
+	return &loader[V]{fetch: fetch, wait: cfg.Wait, maxBatch: maxBatch}
+}
+
+// load returns the values found for ids; IDs the user service does not know are missing from the map.
+// It gives up with ctx's error when ctx is done first, leaving the batch to finish for the other callers.
+func (l *loader[V]) load(ctx context.Context, ids []int64) (map[int64]V, error) {
+	if len(ids) == 0 {
+		return make(map[int64]V), nil
+	}
+
+	batches := l.enqueue(ctx, ids)
+
+	result := make(map[int64]V, len(ids))
+	for _, b := range batches {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-b.done:
+		}
+		if b.err != nil {
+			return nil, b.err
+		}
+		for _, id := range ids {
+			if v, ok := b.result[id]; ok {
+				result[id] = v
+			}
+		}
+	}
+	return result, nil
+}
+
+// enqueue adds ids to the pending batch, sending batches that fill up, and returns the batches holding them.
+func (l *loader[V]) enqueue(ctx context.Context, ids []int64) []*batch[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var batches []*batch[V]
+	for _, id := range ids {
+		if l.pending == nil {
+			l.pending = l.newBatch(ctx)
+		}
+		b := l.pending
+		if len(batches) == 0 || batches[len(batches)-1] != b {
+			batches = append(batches, b)
+		}
+		if _, ok := b.index[id]; ok {
+			continue
+		}
+		b.index[id] = struct{}{}
+		b.ids = append(b.ids, id)
+		if len(b.ids) >= l.maxBatch {
+			l.dispatchLocked(b)
+		}
+	}
+	if l.wait <= 0 && l.pending != nil {
+		l.dispatchLocked(l.pending)
+	}
+	return batches
+}
+
+// newBatch starts a batch whose fetch carries the values, such as the request ID, of the caller that
+// opened it, but not its cancellation: other callers wait on the same fetch.
+func (l *loader[V]) newBatch(ctx context.Context) *batch[V] {
+	b := &batch[V]{
+		ctx:   context.WithoutCancel(ctx),
+		index: make(map[int64]struct{}),
+		done:  make(chan struct{}),
+	}
+	if l.wait > 0 {
+		b.timer = time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.dispatchLocked(b)
+		})
+	}
+	return b
+}
+
+func (l *loader[V]) dispatchLocked(b *batch[V]) {
+	if b.dispatched {
+		return
+	}
+	b.dispatched = true
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	if l.pending == b {
+		l.pending = nil
+	}
+
+	go func() {
+		b.result, b.err = l.fetch(b.ctx, b.ids)
+		close(b.done)
+	}()
+}
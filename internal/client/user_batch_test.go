@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sameIDs matches an ID slice regardless of order.
+func sameIDs(want ...int64) any {
+	return mock.MatchedBy(func(got []int64) bool {
+		got = slices.Clone(got)
+		slices.Sort(got)
+		return slices.Equal(got, want)
+	})
+}
+
+func TestBatched_CoalescesConcurrentLookups(t *testing.T) {
+	next := mocks.NewUserClient(t)
+	next.On("GetUsernames", mock.Anything, sameIDs(1, 2, 3)).
+		Return(map[int64]string{1: "alice", 2: "bob"}, nil).Once()
+	c := NewBatched(next, BatchConfig{Wait: 20 * time.Millisecond, MaxBatch: 100})
+
+	var wg sync.WaitGroup
+	results := make([]map[int64]string, 3)
+	errs := make([]error, 3)
+	for i, ids := range [][]int64{{1, 2}, {2, 3}, {1}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.GetUsernames(context.Background(), ids)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[int64]string{1: "alice", 2: "bob"}, results[0])
+	assert.Equal(t, map[int64]string{2: "bob"}, results[1])
+	assert.Equal(t, map[int64]string{1: "alice"}, results[2])
+}
+
+func TestBatched_GetUsernameNotFound(t *testing.T) {
+	next := mocks.NewUserClient(t)
+	next.On("GetUsernames", mock.Anything, []int64{7}).Return(map[int64]string{}, nil).Once()
+	c := NewBatched(next, BatchConfig{Wait: time.Millisecond})
+
+	_, err := c.GetUsername(context.Background(), 7)
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestBatched_SplitsAtMaxBatch(t *testing.T) {
+	next := mocks.NewUserClient(t)
+	next.On("GetUserProfiles", mock.Anything, []int64{1, 2}).
+		Return(map[int64]entity.UserProfile{1: {UserID: 1}, 2: {UserID: 2}}, nil).Once()
+	next.On("GetUserProfiles", mock.Anything, []int64{3}).
+		Return(map[int64]entity.UserProfile{3: {UserID: 3}}, nil).Once()
+	c := NewBatched(next, BatchConfig{Wait: time.Millisecond, MaxBatch: 2})
+
+	profiles, err := c.GetUserProfiles(context.Background(), []int64{1, 2, 3})
+
+	require.NoError(t, err)
+	assert.Len(t, profiles, 3)
+}
+
+func TestBatched_SharesErrors(t *testing.T) {
+	next := mocks.NewUserClient(t)
+	unavailable := errors.New("user service unavailable")
+	next.On("GetUserProfiles", mock.Anything, []int64{1}).Return(nil, unavailable).Once()
+	c := NewBatched(next, BatchConfig{Wait: time.Millisecond})
+
+	_, err := c.GetUserProfile(context.Background(), 1)
+
+	assert.ErrorIs(t, err, unavailable)
+}
+
+func TestBatched_CallerCancellation(t *testing.T) {
+	next := mocks.NewUserClient(t)
+	release := make(chan struct{})
+	next.On("GetUsernames", mock.Anything, sameIDs(1, 2)).
+		Run(func(mock.Arguments) { <-release }).
+		Return(map[int64]string{1: "alice", 2: "bob"}, nil).Once()
+	c := NewBatched(next, BatchConfig{Wait: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := c.GetUsername(ctx, 1)
+		cancelled <- err
+	}()
+
+	patient := make(chan string, 1)
+	go func() {
+		username, _ := c.GetUsername(context.Background(), 2)
+		patient <- username
+	}()
+
+	time.Sleep(80 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+
+	close(release)
+	assert.Equal(t, "bob", <-patient)
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/cache"
+	"github.com/rs/zerolog"
+)
+
+const (
+	watchBackoffMin = time.Second
+	watchBackoffMax = 30 * time.Second
+)
+
+// CacheConfig bounds the profile cache. Deleted users are remembered for NegativeTTL.
+type CacheConfig struct {
+	Size        int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+// cachedUserClient serves profile and username lookups from a bounded LRU cache and keeps it fresh
+// with the user service's change stream. Other calls go straight to the wrapped client.
+// A nil profile in the cache records a user that does not exist.
+type cachedUserClient struct {
+	next        UserClient
+	profiles    *cache.LRU[int64, *entity.UserProfile]
+	ttl         time.Duration
+	negativeTTL time.Duration
+	stop        context.CancelFunc
+	done        chan struct{}
+	log         *zerolog.Logger
+}
+
+// NewCached wraps next with a profile cache and starts watching for user changes until Close.
+func NewCached(next UserClient, cfg CacheConfig, log *zerolog.Logger) UserClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &cachedUserClient{
+		next:        next,
+		profiles:    cache.NewLRU[int64, *entity.UserProfile](cfg.Size),
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+		stop:        cancel,
+		done:        make(chan struct{}),
+		log:         log,
+	}
+	go c.watch(ctx)
+	return c
+}
+
+func (c *cachedUserClient) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]entity.UserProfile, error) {
+	profiles := make(map[int64]entity.UserProfile, len(userIDs))
+	var missing []int64
+	for _, id := range userIDs {
+		profile, found := c.profiles.Get(id)
+		switch {
+		case !found:
+			missing = append(missing, id)
+		case profile != nil:
+			profiles[id] = *profile
+		}
+	}
+	if len(missing) == 0 {
+		return profiles, nil
+	}
+
+	epoch := c.profiles.Epoch()
+	fetched, err := c.next.GetUserProfiles(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range missing {
+		if profile, ok := fetched[id]; ok {
+			profiles[id] = profile
+			c.profiles.PutAt(epoch, id, &profile, c.ttl)
+		} else {
+			c.profiles.PutAt(epoch, id, nil, c.negativeTTL)
+		}
+	}
+	return profiles, nil
+}
+
+// GetUserProfile returns ErrUserNotFound for a deleted user.
+func (c *cachedUserClient) GetUserProfile(ctx context.Context, userID int64) (*entity.UserProfile, error) {
+	profiles, err := c.GetUserProfiles(ctx, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	profile, ok := profiles[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &profile, nil
+}
+
+func (c *cachedUserClient) GetUsernames(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	profiles, err := c.GetUserProfiles(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[int64]string, len(profiles))
+	for id, profile := range profiles {
+		usernames[id] = profile.Username
+	}
+	return usernames, nil
+}
+
+// GetUsername returns ErrUserNotFound for a deleted user.
+func (c *cachedUserClient) GetUsername(ctx context.Context, userID int64) (string, error) {
+	profile, err := c.GetUserProfile(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return profile.Username, nil
+}
+
+func (c *cachedUserClient) GetUserStatus(ctx context.Context, userID int64) (*entity.UserStatus, error) {
+	return c.next.GetUserStatus(ctx, userID)
+}
+
+func (c *cachedUserClient) GetUserStatuses(ctx context.Context, userIDs []int64) (map[int64]entity.UserStatus, error) {
+	return c.next.GetUserStatuses(ctx, userIDs)
+}
+
+func (c *cachedUserClient) ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error) {
+	return c.next.ValidateToken(ctx, token)
+}
+
+func (c *cachedUserClient) WatchUserChanges(ctx context.Context) (<-chan entity.UserChange, error) {
+	return c.next.WatchUserChanges(ctx)
+}
+
+func (c *cachedUserClient) StreamUsers(ctx context.Context, since int64, apply func(records []entity.UserRecord) error) (int64, error) {
+	return c.next.StreamUsers(ctx, since, apply)
+}
+
+func (c *cachedUserClient) Close() error {
+	c.stop()
+	<-c.done
+	return c.next.Close()
+}
+
+// watch applies user changes to the cache, resubscribing with backoff whenever the stream ends.
+// While it is down, entries can be stale for at most their TTL; the stream's opening reset clears them.
+func (c *cachedUserClient) watch(ctx context.Context) {
+	defer close(c.done)
+	log := c.log.With().Str("op", "CachedUserClient.watch").Logger()
+	backoff := watchBackoffMin
+
+	for {
+		changes, err := c.next.WatchUserChanges(ctx)
+		if err == nil {
+			for change := range changes {
+				backoff = watchBackoffMin
+				c.apply(change)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn().Err(err).Dur("retry_in", backoff).Msg("User change stream ended")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, watchBackoffMax)
+	}
+}
+
+func (c *cachedUserClient) apply(change entity.UserChange) {
+	if change.Type == entity.UserChangeReset {
+		c.profiles.Purge()
+		return
+	}
+	c.profiles.Invalidate(change.UserID)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedClient(t *testing.T, cfg CacheConfig) (*cachedUserClient, *mocks.UserClient, chan entity.UserChange) {
+	next := mocks.NewUserClient(t)
+	changes := make(chan entity.UserChange)
+	next.On("WatchUserChanges", mock.Anything).Return((<-chan entity.UserChange)(changes), nil).Once()
+	next.On("Close").Return(nil).Once()
+
+	logger := zerolog.Nop()
+	c := NewCached(next, cfg, &logger).(*cachedUserClient)
+	t.Cleanup(func() {
+		c.stop()
+		close(changes)
+		require.NoError(t, c.Close())
+	})
+	return c, next, changes
+}
+
+func TestCachedUserClient_CachesProfilesAndMisses(t *testing.T) {
+	ctx := context.Background()
+	c, next, _ := newCachedClient(t, CacheConfig{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute})
+
+	next.On("GetUserProfiles", ctx, []int64{1, 2}).
+		Return(map[int64]entity.UserProfile{1: {UserID: 1, Username: "alice"}}, nil).Once()
+
+	usernames, err := c.GetUsernames(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{1: "alice"}, usernames)
+
+	profile, err := c.GetUserProfile(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", profile.Username)
+
+	_, err = c.GetUsername(ctx, 2)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestCachedUserClient_ChangeInvalidates(t *testing.T) {
+	ctx := context.Background()
+	c, next, changes := newCachedClient(t, CacheConfig{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute})
+
+	next.On("GetUserProfiles", ctx, []int64{1}).
+		Return(map[int64]entity.UserProfile{1: {UserID: 1, Username: "alice"}}, nil).Once()
+	name, err := c.GetUsername(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", name)
+
+	changes <- entity.UserChange{Type: entity.UserChangeRenamed, UserID: 1}
+	// A second send only completes once the first change has been applied.
+	changes <- entity.UserChange{Type: entity.UserChangeUpdated, UserID: 99}
+
+	next.On("GetUserProfiles", ctx, []int64{1}).
+		Return(map[int64]entity.UserProfile{1: {UserID: 1, Username: "alicia"}}, nil).Once()
+	name, err = c.GetUsername(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "alicia", name)
+}
+
+func TestCachedUserClient_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c, next, _ := newCachedClient(t, CacheConfig{Size: 2, TTL: time.Minute, NegativeTTL: time.Minute})
+
+	for _, id := range []int64{1, 2} {
+		next.On("GetUserProfiles", ctx, []int64{id}).Return(map[int64]entity.UserProfile{id: {UserID: id}}, nil).Once()
+		_, err := c.GetUserProfile(ctx, id)
+		require.NoError(t, err)
+	}
+	_, err := c.GetUserProfile(ctx, 1)
+	require.NoError(t, err)
+
+	next.On("GetUserProfiles", ctx, []int64{3}).Return(map[int64]entity.UserProfile{3: {UserID: 3}}, nil).Once()
+	_, err = c.GetUserProfile(ctx, 3)
+	require.NoError(t, err)
+
+	next.On("GetUserProfiles", ctx, []int64{2}).Return(map[int64]entity.UserProfile{2: {UserID: 2}}, nil).Once()
+	_, err = c.GetUserProfile(ctx, 2)
+	require.NoError(t, err)
+}
+
+func TestCachedUserClient_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	c, next, _ := newCachedClient(t, CacheConfig{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute})
+
+	next.On("GetUserProfiles", ctx, []int64{1}).Return(nil, errors.New("unavailable")).Once()
+	_, err := c.GetUserProfile(ctx, 1)
+	assert.Error(t, err)
+
+	next.On("GetUserProfiles", ctx, []int64{1}).Return(map[int64]entity.UserProfile{1: {UserID: 1}}, nil).Once()
+	_, err = c.GetUserProfile(ctx, 1)
+	assert.NoError(t, err)
+}
@@ -2,58 +2,131 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"strings"
+	"io"
 	"time"
 
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 type UserClient interface {
 	GetUsernames(ctx context.Context, userIDs []int64) (map[int64]string, error)
 	GetUsername(ctx context.Context, userID int64) (string, error)
+	GetUserStatus(ctx context.Context, userID int64) (*entity.UserStatus, error)
+	GetUserStatuses(ctx context.Context, userIDs []int64) (map[int64]entity.UserStatus, error)
+	ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error)
+	GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]entity.UserProfile, error)
+	GetUserProfile(ctx context.Context, userID int64) (*entity.UserProfile, error)
+	WatchUserChanges(ctx context.Context) (<-chan entity.UserChange, error)
+	StreamUsers(ctx context.Context, since int64, apply func(records []entity.UserRecord) error) (int64, error)
 	Close() error
 }
 
 type userClient struct {
-	client userpb.UserServiceClient
-	conn   *grpc.ClientConn
-	log    *zerolog.Logger
+	client  userpb.UserServiceClient
+	conn    *grpc.ClientConn
+	tokens  *tokenCache
+	timeout time.Duration
+	log     *zerolog.Logger
 }
 
-func New(address string, log *zerolog.Logger) (UserClient, error) {
-	if !strings.Contains(address, ":") {
-		address = ":" + address
+// Config tunes calls to the user service. MaxAttempts includes the first try, and a zero
+// BreakerThreshold turns the circuit breaker off. A nil TLS connects without transport security.
+// KeepaliveTime is how long a connection may sit idle before it is pinged; zero turns pings off.
+type Config struct {
+	TLS              *tls.Config
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	Timeout          time.Duration
+	MaxAttempts      int
+	InitialBackoff   time.Duration
+	MaxBackoff       time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Errors returned by UserClient methods wrap one of these, so callers can tell a missing user from a user
+// service that cannot answer. ErrUnavailable covers an unreachable service or database, a timeout and
+// an open circuit breaker.
+var (
+	ErrInvalidToken    = errors.New("invalid personal access token")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidArgument = errors.New("invalid request to user service")
+	ErrUnavailable     = errors.New("user service unavailable")
+)
+
+// fromStatus wraps a failed call's error with the matching typed error; the gRPC status stays in the chain.
+func fromStatus(err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %w", ErrUserNotFound, err)
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
+	return err
+}
 
-	conn, err := grpc.Dial(
-		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-		grpc.WithTimeout(5*time.Second),
-	)
+// New creates a client without connecting: connections are made on the first call and re-established
+// in the background, so the forum starts and serves degraded pages while the user service is down.
+// address is parsed by dialTarget; calls are balanced round-robin over the healthy endpoints.
+func New(address string, cfg Config, log *zerolog.Logger) (UserClient, error) {
+	target, opts, err := dialTarget(address)
 	if err != nil {
-		return nil, fmt.Errorf("grpc.Dial failed: %w", err)
+		return nil, err
 	}
 
-	client := userpb.NewUserServiceClient(conn)
+	interceptors := []grpc.UnaryClientInterceptor{requestIDUnaryInterceptor}
+	if cfg.BreakerThreshold > 0 {
+		interceptors = append(interceptors, newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown, log).unaryInterceptor)
+	}
+	retry := retryPolicy{maxAttempts: cfg.MaxAttempts, initialBackoff: cfg.InitialBackoff, maxBackoff: cfg.MaxBackoff}
+	interceptors = append(interceptors, retry.unaryInterceptor)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+	creds := insecure.NewCredentials()
+	if cfg.TLS != nil {
+		creds = credentials.NewTLS(cfg.TLS)
+	}
 
-	_, err = client.GetUsernames(ctx, &userpb.GetUsernamesRequest{UserIds: []int64{0}})
+	opts = append(opts,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithChainStreamInterceptor(requestIDStreamInterceptor),
+	)
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             cfg.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("health check failed: %w", err)
+		return nil, fmt.Errorf("grpc.NewClient failed: %w", err)
 	}
 
 	return &userClient{
-		client: client,
-		conn:   conn,
-		log:    log,
+		client:  userpb.NewUserServiceClient(conn),
+		conn:    conn,
+		tokens:  newTokenCache(),
+		timeout: cfg.Timeout,
+		log:     log,
 	}, nil
 }
 
@@ -74,13 +147,13 @@ func (c *userClient) GetUsernames(ctx context.Context, userIDs []int64) (map[int
 		UserIds: userIDs,
 	}
 
-	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	res, err := c.client.GetUsernames(callCtx, req)
 	if err != nil {
 		c.log.Error().Err(err).Str("op", "UserClient.GetUsernames").Any("userIDs", userIDs).Msg("Failed to get usernames")
-		return nil, fmt.Errorf("clients.user - GetUsernames - c.client.GetUsernames: %w", err)
+		return nil, fmt.Errorf("clients.user - GetUsernames - c.client.GetUsernames: %w", fromStatus(err))
 	}
 
 	c.log.Info().Str("op", "UserClient.GetUsernames").Msg("Successfully got usernames")
@@ -92,15 +165,221 @@ func (c *userClient) GetUsername(ctx context.Context, userID int64) (string, err
 		UserId: userID,
 	}
 
-	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	res, err := c.client.GetUsername(callCtx, req)
 	if err != nil {
-		c.log.Error().Err(err).Str("op", "UserClient.GetUsername").Any("userID", userID).Msg("Failed to get username")
-		return "", fmt.Errorf("clients.user - GetUsername - c.client.GetUsername: %w", err)
+		if status.Code(err) != codes.NotFound {
+			c.log.Error().Err(err).Str("op", "UserClient.GetUsername").Any("userID", userID).Msg("Failed to get username")
+		}
+		return "", fmt.Errorf("clients.user - GetUsername - c.client.GetUsername: %w", fromStatus(err))
 	}
 
 	c.log.Info().Str("op", "UserClient.GetUsername").Msg("Successfully got username")
 	return res.GetUsername(), nil
 }
+
+func (c *userClient) GetUserStatus(ctx context.Context, userID int64) (*entity.UserStatus, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.GetUserStatus(callCtx, &userpb.GetUserStatusRequest{UserId: userID})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.GetUserStatus").Int64("userID", userID).Msg("Failed to get user status")
+		return nil, fmt.Errorf("clients.user - GetUserStatus - c.client.GetUserStatus: %w", fromStatus(err))
+	}
+
+	status := fromProtoStatus(res)
+	return &status, nil
+}
+
+// GetUserStatuses returns the status of every user in userIDs in one call.
+func (c *userClient) GetUserStatuses(ctx context.Context, userIDs []int64) (map[int64]entity.UserStatus, error) {
+	if len(userIDs) == 0 {
+		return make(map[int64]entity.UserStatus), nil
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.GetUserStatuses(callCtx, &userpb.GetUserStatusesRequest{UserIds: userIDs})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.GetUserStatuses").Int("count", len(userIDs)).Msg("Failed to get user statuses")
+		return nil, fmt.Errorf("clients.user - GetUserStatuses - c.client.GetUserStatuses: %w", fromStatus(err))
+	}
+
+	statuses := make(map[int64]entity.UserStatus, len(res.GetStatuses()))
+	for id, status := range res.GetStatuses() {
+		statuses[id] = fromProtoStatus(status)
+	}
+	return statuses, nil
+}
+
+func fromProtoStatus(res *userpb.GetUserStatusResponse) entity.UserStatus {
+	status := entity.UserStatus{UserID: res.GetUserId(), Banned: res.GetBanned(), Reason: res.GetBanReason()}
+	if res.GetBannedUntil() != 0 {
+		until := time.Unix(res.GetBannedUntil(), 0)
+		status.BannedUntil = &until
+	}
+	return status
+}
+
+// ValidateToken resolves a personal access token, answering from a short-lived local cache when it can.
+// It returns ErrInvalidToken for unknown, expired and revoked tokens.
+func (c *userClient) ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error) {
+	if identity, ok := c.tokens.get(token); ok {
+		if identity == nil {
+			return nil, ErrInvalidToken
+		}
+		return identity, nil
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.ValidateToken(callCtx, &userpb.ValidateTokenRequest{Token: token})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.ValidateToken").Msg("Failed to validate token")
+		return nil, fmt.Errorf("clients.user - ValidateToken - c.client.ValidateToken: %w", fromStatus(err))
+	}
+
+	if !res.GetValid() {
+		c.tokens.set(token, nil)
+		return nil, ErrInvalidToken
+	}
+
+	identity := &entity.TokenIdentity{TokenID: res.GetTokenId(), UserID: res.GetUserId(), Role: res.GetRole(), Scopes: res.GetScopes()}
+	c.tokens.set(token, identity)
+	return identity, nil
+}
+
+// GetUserProfiles returns the profiles of the users that still exist; deleted users are missing from the map.
+func (c *userClient) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]entity.UserProfile, error) {
+	if len(userIDs) == 0 {
+		return make(map[int64]entity.UserProfile), nil
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.GetUserProfiles(callCtx, &userpb.GetUserProfilesRequest{UserIds: userIDs})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.GetUserProfiles").Any("userIDs", userIDs).Msg("Failed to get user profiles")
+		return nil, fmt.Errorf("clients.user - GetUserProfiles - c.client.GetUserProfiles: %w", fromStatus(err))
+	}
+
+	profiles := make(map[int64]entity.UserProfile, len(res.GetProfiles()))
+	for id, profile := range res.GetProfiles() {
+		profiles[id] = fromProtoProfile(profile)
+	}
+	return profiles, nil
+}
+
+func (c *userClient) GetUserProfile(ctx context.Context, userID int64) (*entity.UserProfile, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.GetUserProfile(callCtx, &userpb.GetUserProfileRequest{UserId: userID})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			c.log.Error().Err(err).Str("op", "UserClient.GetUserProfile").Int64("userID", userID).Msg("Failed to get user profile")
+		}
+		return nil, fmt.Errorf("clients.user - GetUserProfile - c.client.GetUserProfile: %w", fromStatus(err))
+	}
+
+	profile := fromProtoProfile(res.GetProfile())
+	return &profile, nil
+}
+
+func fromProtoProfile(p *userpb.UserProfile) entity.UserProfile {
+	return entity.UserProfile{
+		UserID:      p.GetUserId(),
+		Username:    p.GetUsername(),
+		DisplayName: p.GetDisplayName(),
+		Bio:         p.GetBio(),
+		AvatarURL:   p.GetAvatarUrl(),
+		Role:        p.GetRole(),
+		Signature:   p.GetSignature(),
+		JoinedAt:    time.Unix(p.GetJoinedAt(), 0),
+	}
+}
+
+var userChangeTypes = map[userpb.UserChangeEvent_Type]string{
+	userpb.UserChangeEvent_CREATED: entity.UserChangeCreated,
+	userpb.UserChangeEvent_RENAMED: entity.UserChangeRenamed,
+	userpb.UserChangeEvent_UPDATED: entity.UserChangeUpdated,
+	userpb.UserChangeEvent_DELETED: entity.UserChangeDeleted,
+	userpb.UserChangeEvent_RESET:   entity.UserChangeReset,
+}
+
+// WatchUserChanges streams account changes until ctx is done or the stream breaks, then closes the channel.
+func (c *userClient) WatchUserChanges(ctx context.Context) (<-chan entity.UserChange, error) {
+	stream, err := c.client.WatchUserChanges(ctx, &userpb.WatchUserChangesRequest{})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.WatchUserChanges").Msg("Failed to watch user changes")
+		return nil, fmt.Errorf("clients.user - WatchUserChanges - c.client.WatchUserChanges: %w", fromStatus(err))
+	}
+
+	changes := make(chan entity.UserChange)
+	go func() {
+		defer close(changes)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					c.log.Warn().Err(err).Str("op", "UserClient.WatchUserChanges").Msg("User change stream broke")
+				}
+				return
+			}
+
+			changeType, ok := userChangeTypes[event.GetType()]
+			if !ok {
+				continue
+			}
+			select {
+			case changes <- entity.UserChange{Type: changeType, UserID: event.GetUserId()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// StreamUsers hands apply, a page at a time, every account changed since the checkpoint since and returns
+// the checkpoint to pass next time. The checkpoint is only valid once every page has been applied.
+func (c *userClient) StreamUsers(ctx context.Context, since int64, apply func(records []entity.UserRecord) error) (int64, error) {
+	stream, err := c.client.StreamUsers(ctx, &userpb.StreamUsersRequest{Since: since})
+	if err != nil {
+		c.log.Error().Err(err).Str("op", "UserClient.StreamUsers").Msg("Failed to stream users")
+		return 0, fmt.Errorf("clients.user - StreamUsers - c.client.StreamUsers: %w", fromStatus(err))
+	}
+
+	var checkpoint int64
+	for {
+		page, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return checkpoint, nil
+		}
+		if err != nil {
+			c.log.Error().Err(err).Str("op", "UserClient.StreamUsers").Int64("since", since).Msg("User stream broke")
+			return 0, fmt.Errorf("clients.user - StreamUsers - stream.Recv: %w", fromStatus(err))
+		}
+
+		records := make([]entity.UserRecord, 0, len(page.GetUsers()))
+		for _, user := range page.GetUsers() {
+			records = append(records, entity.UserRecord{
+				ID:       user.GetUserId(),
+				Username: user.GetUsername(),
+				Role:     user.GetRole(),
+				Deleted:  user.GetDeleted(),
+			})
+		}
+		if err := apply(records); err != nil {
+			return 0, err
+		}
+		checkpoint = page.GetCheckpoint()
+	}
+}
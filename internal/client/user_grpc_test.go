@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found", status.Error(codes.NotFound, "user not found"), ErrUserNotFound},
+		{"invalid argument", status.Error(codes.InvalidArgument, "invalid user ids"), ErrInvalidArgument},
+		{"unavailable", status.Error(codes.Unavailable, "database unavailable"), ErrUnavailable},
+		{"deadline", status.Error(codes.DeadlineExceeded, "too slow"), ErrUnavailable},
+		{"circuit open", ErrCircuitOpen, ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fromStatus(tt.err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestFromStatus_LeavesOtherErrors(t *testing.T) {
+	err := status.Error(codes.Internal, "internal error")
+
+	got := fromStatus(err)
+
+	assert.Equal(t, err, got)
+	for _, typed := range []error{ErrUserNotFound, ErrInvalidArgument, ErrUnavailable} {
+		assert.False(t, errors.Is(got, typed))
+	}
+}
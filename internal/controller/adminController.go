@@ -0,0 +1,329 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type AdminHandler struct {
+	Usecase usecase.AdminUsecase
+	Log     *zerolog.Logger
+}
+
+const (
+	listUsersOp   = "AdminHandler.ListUsers"
+	getUserOp     = "AdminHandler.GetUser"
+	changeRoleOp  = "AdminHandler.ChangeRole"
+	forceLogoutOp = "AdminHandler.ForceLogout"
+	banOp         = "AdminHandler.Ban"
+	unbanOp       = "AdminHandler.Unban"
+	auditLogOp    = "AdminHandler.AuditLog"
+)
+
+// ListUsers godoc
+// @Summary List users
+// @Description Lists users page by page. Requires the user.manage permission.
+// @Tags admin
+// @Produce json
+// @Param q query string false "Substring of the username"
+// @Param role query string false "Exact role"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} response.UsersPageResponse "Users and the total number of matches"
+// @Failure 400 {object} response.ErrorResponse "Invalid pagination parameters"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", listUsersOp).Logger()
+
+	limit, offset, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.Usecase.ListUsers(c.Request.Context(), entity.UserFilter{
+		Query:  c.Query("q"),
+		Role:   c.Query("role"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users, "total": total})
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Returns a user with the number of active sessions and the last login time. Requires the user.manage permission. The lookup is recorded in the audit log.
+// @Tags admin
+// @Produce json
+// @Param id path int true "User ID" Format(int64)
+// @Success 200 {object} response.UserDetailsResponse "User details"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", getUserOp).Logger()
+
+	actorID, userID, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.Usecase.GetUser(c.Request.Context(), actorID, userID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to get user")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// ChangeRole godoc
+// @Summary Change a user's role
+// @Description Changes the role and revokes the user's refresh tokens, so their next login issues tokens with the new role. Requires the user.manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID" Format(int64)
+// @Param request body request.ChangeRoleRequest true "New role"
+// @Success 200 {object} response.SuccessMessageResponse "Role changed"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID, unknown role or own account"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/role [patch]
+func (h *AdminHandler) ChangeRole(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", changeRoleOp).Logger()
+
+	actorID, userID, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req request.ChangeRoleRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.Usecase.ChangeRole(c.Request.Context(), actorID, userID, req.Role); err != nil {
+		log.Warn().Err(err).Int64("user_id", userID).Msg("Failed to change role")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role changed"})
+}
+
+// ForceLogout godoc
+// @Summary End all sessions of a user
+// @Description Revokes every refresh token of the user. Access tokens already issued stay valid until they expire. Requires the user.manage permission.
+// @Tags admin
+// @Produce json
+// @Param id path int true "User ID" Format(int64)
+// @Success 200 {object} response.SuccessMessageResponse "Sessions revoked"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/logout [post]
+func (h *AdminHandler) ForceLogout(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", forceLogoutOp).Logger()
+
+	actorID, userID, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Usecase.ForceLogout(c.Request.Context(), actorID, userID); err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to force logout")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sessions revoked"})
+}
+
+// Ban godoc
+// @Summary Ban a user
+// @Description Bans the user until expires_at, or permanently when it is omitted. The user cannot log in, refresh tokens, post or chat while banned, and their refresh tokens are revoked. Requires the user.manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID" Format(int64)
+// @Param request body request.BanRequest true "Reason and optional expiry"
+// @Success 200 {object} response.BanResponse "User banned"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID, missing reason, expiry in the past or own account"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/ban [post]
+func (h *AdminHandler) Ban(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", banOp).Logger()
+
+	actorID, userID, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req request.BanRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	ban, err := h.Usecase.Ban(c.Request.Context(), actorID, userID, req.Reason, req.ExpiresAt)
+	if err != nil {
+		log.Warn().Err(err).Int64("user_id", userID).Msg("Failed to ban user")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ban": ban})
+}
+
+// Unban godoc
+// @Summary Lift a ban
+// @Description Lifts every active ban of the user. Requires the user.manage permission.
+// @Tags admin
+// @Produce json
+// @Param id path int true "User ID" Format(int64)
+// @Success 200 {object} response.SuccessMessageResponse "Ban lifted"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User is not banned"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/users/{id}/ban [delete]
+func (h *AdminHandler) Unban(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", unbanOp).Logger()
+
+	actorID, userID, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Usecase.Unban(c.Request.Context(), actorID, userID); err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to unban user")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ban lifted"})
+}
+
+// AuditLog godoc
+// @Summary Admin audit log
+// @Description Lists recorded admin actions, newest first. Requires the user.manage permission.
+// @Tags admin
+// @Produce json
+// @Param actor_id query int false "Only actions performed by this user"
+// @Param target_id query int false "Only actions on this user"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} response.AuditLogResponse "Audit entries"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing user.manage permission"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/audit [get]
+func (h *AdminHandler) AuditLog(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", auditLogOp).Logger()
+
+	limit, offset, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	filter := entity.AuditFilter{Limit: limit, Offset: offset}
+	for key, dst := range map[string]*int64{"actor_id": &filter.ActorID, "target_id": &filter.TargetID} {
+		if v := c.Query(key); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				middleware.Abort(c, middleware.InvalidParameter(key))
+				return
+			}
+			*dst = id
+		}
+	}
+
+	entries, err := h.Usecase.AuditLog(c.Request.Context(), filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get audit log")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+func (h *AdminHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
+	reqLog := h.Log.With().
+		Str("method", c.Request.Method).
+		Str("path", c.Request.URL.Path).
+		Str("remote_addr", c.ClientIP())
+
+	logger := reqLog.Logger()
+	return &logger
+}
+
+// adminTarget reads the acting admin from the context and the target user from the :id parameter, writing the error response itself.
+func adminTarget(c *gin.Context) (actorID, userID int64, ok bool) {
+	actorID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return 0, 0, false
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("user id"))
+		return 0, 0, false
+	}
+
+	return actorID, userID, true
+}
+
+// pagination parses the limit and offset query parameters, writing a 400 response when they are not numbers.
+func pagination(c *gin.Context) (limit, offset int, ok bool) {
+	var err error
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			middleware.Abort(c, middleware.InvalidParameter("limit"))
+			return 0, 0, false
+		}
+	}
+	if v := c.Query("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			middleware.Abort(c, middleware.InvalidParameter("offset"))
+			return 0, 0, false
+		}
+	}
+	return limit, offset, true
+}
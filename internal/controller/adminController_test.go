@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAdminRouter(t *testing.T, actorID int64) (*gin.Engine, *mocks.AdminUsecase) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAdminUsecase(t)
+	log := zerolog.Nop()
+	handler := &AdminHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, actorID)
+		c.Next()
+	})
+	router.GET("/admin/users", handler.ListUsers)
+	router.GET("/admin/users/:id", handler.GetUser)
+	router.PATCH("/admin/users/:id/role", handler.ChangeRole)
+	router.POST("/admin/users/:id/logout", handler.ForceLogout)
+	router.POST("/admin/users/:id/ban", handler.Ban)
+	router.DELETE("/admin/users/:id/ban", handler.Unban)
+	return router, mockUsecase
+}
+
+func TestAdminHandler_ListUsers_Success(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	filter := entity.UserFilter{Query: "bo", Limit: 10, Offset: 20}
+	mockUsecase.On("ListUsers", mock.Anything, filter).Return([]entity.User{{ID: 7, Username: "bob"}}, int64(21), nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users?q=bo&limit=10&offset=20", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"username":"bob"`)
+	assert.Contains(t, rr.Body.String(), `"total":21`)
+}
+
+func TestAdminHandler_ListUsers_InvalidLimit(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users?limit=ten", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockUsecase.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+}
+
+func TestAdminHandler_GetUser_NotFound(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	mockUsecase.On("GetUser", mock.Anything, int64(1), int64(7)).Return(nil, usecase.ErrUserNotFound).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users/7", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAdminHandler_ChangeRole_Success(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	mockUsecase.On("ChangeRole", mock.Anything, int64(1), int64(7), "moderator").Return(nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/admin/users/7/role", strings.NewReader(`{"role":"moderator"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestAdminHandler_ChangeRole_UnknownRole(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	mockUsecase.On("ChangeRole", mock.Anything, int64(1), int64(7), "wizard").Return(usecase.ErrUnknownRole).Once()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/admin/users/7/role", strings.NewReader(`{"role":"wizard"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAdminHandler_ForceLogout_Success(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	mockUsecase.On("ForceLogout", mock.Anything, int64(1), int64(7)).Return(nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/users/7/logout", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestAdminHandler_Ban_Success(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	ban := &entity.Ban{ID: 3, UserID: 7, BannedBy: 1, Reason: "spam", ExpiresAt: &expiresAt}
+	mockUsecase.On("Ban", mock.Anything, int64(1), int64(7), "spam", mock.MatchedBy(func(t *time.Time) bool {
+		return t != nil && t.Equal(expiresAt)
+	})).Return(ban, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/users/7/ban", strings.NewReader(`{"reason":"spam","expires_at":"2030-01-02T03:04:05Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"reason":"spam"`)
+}
+
+func TestAdminHandler_Ban_MissingReason(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/users/7/ban", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockUsecase.AssertNotCalled(t, "Ban", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminHandler_Unban_NotBanned(t *testing.T) {
+	router, mockUsecase := newAdminRouter(t, 1)
+
+	mockUsecase.On("Unban", mock.Anything, int64(1), int64(7)).Return(usecase.ErrNotBanned).Once()
+
+	req, _ := http.NewRequest(http.MethodDelete, "/admin/users/7/ban", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -1,13 +1,16 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
 	"github.com/Van-programan/Forum_GO/internal/controller/request"
 	_ "github.com/Van-programan/Forum_GO/internal/controller/response"
-	_ "github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/password"
 	"github.com/gin-gonic/gin"
 
 	"github.com/rs/zerolog"
@@ -24,33 +27,57 @@ const (
 	refreshOp      = "AuthHandler.Refresh"
 	logoutOp       = "AuthHandler.Logout"
 	checkSessionOp = "AuthHandler.CheckSession"
+	loginMFAOp     = "AuthHandler.LoginMFA"
+	enrollTOTPOp   = "AuthHandler.EnrollTOTP"
+	confirmTOTPOp  = "AuthHandler.ConfirmTOTP"
+	disableTOTPOp  = "AuthHandler.DisableTOTP"
 )
 
+// Login and refresh failures are reported without saying which check failed.
+var (
+	errLoginFailed          = entity.NewError(entity.KindUnauthorized, "invalid_credentials", "invalid credentials")
+	errMFALoginFailed       = entity.NewError(entity.KindUnauthorized, "invalid_mfa_login", "invalid mfa token or code")
+	errRefreshTokenRequired = entity.NewError(entity.KindUnauthorized, "refresh_token_required", "refresh token required")
+	errRefreshFailed        = entity.NewError(entity.KindUnauthorized, "invalid_refresh_token", "invalid or expired refresh token")
+	errInvalidPassword      = entity.NewError(entity.KindForbidden, "invalid_password", "invalid password")
+)
+
+var errPasswordPolicy = entity.NewError(entity.KindUnprocessable, "password_policy", "password does not meet policy")
+
 // Register godoc
 // @Summary Register a new user
-// @Description Creates a new user account and returns user information along with an access token. A refresh token is set as an HTTP-only cookie.
+// @Description Creates a new user account with the user role and returns user information along with an access token. A refresh token is set as an HTTP-only cookie. The password must satisfy the password policy: length limits, not a common password and not containing the username.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param user body request.RegisterRequest true "User Credentials"
 // @Success 200 {object} response.RegisterSuccessResponse "Successfully registered"
-// @Failure 400 {object} response.ErrorResponseAuth "Invalid request payload"
-// @Failure 500 {object} response.ErrorResponseAuth "Internal server error"
-// @Router /register [post]
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 409 {object} response.ErrorResponse "Username is already taken"
+// @Failure 422 {object} response.ErrorResponse "Password does not meet the policy"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/register [post]
 func (ah *AuthHandler) Register(c *gin.Context) {
 	log := ah.getRequestLogger(c).With().Str("op", registerOp).Logger()
 
 	var req request.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn().Err(err).Msg("Failed to bind request")
-		fmt.Println("ошибка")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
-	res, err := ah.Usecase.Register(c.Request.Context(), req.Username, req.Role, req.Password)
+	res, err := ah.Usecase.Register(c.Request.Context(), req.Username, req.Password)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to register user")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			middleware.Abort(c, errPasswordPolicy.WithDetails(map[string]any{"violations": policyErr.Violations}))
+			return
+		}
+		var domainErr *entity.Error
+		if errors.As(err, &domainErr) {
+			log.Warn().Err(err).Msg("Failed to register user")
+		} else {
+			log.Error().Err(err).Msg("Failed to register user")
+		}
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -61,23 +88,23 @@ func (ah *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Log in an existing user
-// @Description Authenticates a user and returns user information along with a new access token. A new refresh token is set as an HTTP-only cookie, and any existing refresh token in the cookie is invalidated.
+// @Description Authenticates a user and returns user information along with a new access token. A new refresh token is set as an HTTP-only cookie, and any existing refresh token in the cookie is invalidated. If the user has 2FA enabled, a short-lived mfa_token is returned instead and the login must be completed with /login/mfa.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body request.LoginRequest true "User Login Credentials"
 // @Success 200 {object} response.LoginSuccessResponse "Successfully logged in"
-// @Failure 400 {object} response.ErrorResponseAuth "Invalid request payload"
-// @Failure 401 {object} response.ErrorResponseAuth "Invalid credentials"
-// @Router /login [post]
+// @Success 202 {object} response.MFAChallengeResponse "Second factor required"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid credentials"
+// @Failure 403 {object} response.ErrorResponse "User is banned"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/login [post]
 func (ah *AuthHandler) Login(c *gin.Context) {
 	log := ah.getRequestLogger(c).With().Str("op", loginOp).Logger()
 
 	var req request.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn().Err(err).Msg("Failed to bind request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		fmt.Println("ошибка")
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
@@ -93,8 +120,56 @@ func (ah *AuthHandler) Login(c *gin.Context) {
 
 	res, err := ah.Usecase.Login(c.Request.Context(), req.Username, req.Password, oldRefreshToken)
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to login user")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		// Only an unknown user or a wrong password is a 401; a failing database must not look like one.
+		var domainErr *entity.Error
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCredentials):
+			log.Warn().Err(err).Msg("Failed to login user")
+			err = errLoginFailed
+		case errors.As(err, &domainErr):
+			log.Warn().Err(err).Msg("Failed to login user")
+		default:
+			log.Error().Err(err).Msg("Failed to login user")
+		}
+		middleware.Abort(c, err)
+		return
+	}
+
+	if res.MFARequired {
+		c.SetCookie("refresh_token", "", -1, "/", "", false, true)
+		c.JSON(http.StatusAccepted, gin.H{"mfa_required": true, "mfa_token": res.MFAToken})
+		return
+	}
+
+	c.SetCookie("refresh_token", res.Tokens.RefreshToken, 3600*24*30, "/", "", false, true)
+
+	c.JSON(http.StatusOK, gin.H{"user": res.User, "access_token": res.Tokens.AccessToken})
+}
+
+// LoginMFA godoc
+// @Summary Complete a two-step login
+// @Description Exchanges the mfa_token returned by /login and a TOTP or recovery code for an access token. A refresh token is set as an HTTP-only cookie. An mfa_token allows 5 attempts and one successful login; after that the user has to log in again. Each TOTP code is accepted once, so a code already used to log in or to confirm 2FA is rejected.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body request.LoginMFARequest true "MFA token and code"
+// @Success 200 {object} response.LoginSuccessResponse "Successfully logged in"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid, expired or used up mfa token, or invalid code"
+// @Failure 403 {object} response.ErrorResponse "User is banned"
+// @Router /auth/login/mfa [post]
+func (ah *AuthHandler) LoginMFA(c *gin.Context) {
+	log := ah.getRequestLogger(c).With().Str("op", loginMFAOp).Logger()
+
+	var req request.LoginMFARequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	res, err := ah.Usecase.LoginMFA(c.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to complete mfa login")
+		middleware.Abort(c, unlessBanned(err, errMFALoginFailed))
 		return
 	}
 
@@ -103,21 +178,131 @@ func (ah *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"user": res.User, "access_token": res.Tokens.AccessToken})
 }
 
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generates a new TOTP secret for the current user. 2FA is not active until it is confirmed with a code.
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} response.TOTPEnrollResponse "Secret and otpauth URI"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/mfa/totp/enroll [post]
+func (ah *AuthHandler) EnrollTOTP(c *gin.Context) {
+	log := ah.getRequestLogger(c).With().Str("op", enrollTOTPOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	res, err := ah.Usecase.EnrollTOTP(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to enroll totp")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enables 2FA after checking the first code from the authenticator app. Returns one-time recovery codes that are shown only once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param code body request.TOTPConfirmRequest true "Code from the authenticator app"
+// @Success 200 {object} response.TOTPConfirmResponse "Recovery codes"
+// @Failure 400 {object} response.ErrorResponse "Invalid code or enrollment not started"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/mfa/totp/confirm [post]
+func (ah *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	log := ah.getRequestLogger(c).With().Str("op", confirmTOTPOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	var req request.TOTPConfirmRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	res, err := ah.Usecase.ConfirmTOTP(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to confirm totp")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DisableTOTP godoc
+// @Summary Disable TOTP
+// @Description Turns off 2FA for the current user and deletes the recovery codes. Requires the account password.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param password body request.TOTPDisableRequest true "Current password"
+// @Success 200 {object} response.SuccessMessageResponse "TOTP disabled"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Invalid password"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/mfa/totp/disable [post]
+func (ah *AuthHandler) DisableTOTP(c *gin.Context) {
+	log := ah.getRequestLogger(c).With().Str("op", disableTOTPOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	var req request.TOTPDisableRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	if err := ah.Usecase.DisableTOTP(c.Request.Context(), userID, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			err = errInvalidPassword
+		} else {
+			log.Error().Err(err).Msg("Failed to disable totp")
+		}
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "totp disabled"})
+}
+
 // Refresh godoc
 // @Summary Refresh access token
 // @Description Uses a refresh token to generate a new access token and a new refresh token. Refresh token is set as an HTTP-only cookie.
 // @Tags auth
 // @Produce json
 // @Success 200 {object} response.RefreshSuccessResponse "Successfully refreshed tokens"
-// @Failure 401 {object} response.ErrorResponseAuth "Refresh token required or invalid/expired refresh token"
-// @Router /refresh [post]
+// @Failure 401 {object} response.ErrorResponse "Refresh token required or invalid/expired refresh token"
+// @Failure 403 {object} response.ErrorResponse "User is banned"
+// @Router /auth/refresh [post]
 func (ah *AuthHandler) Refresh(c *gin.Context) {
 	log := ah.getRequestLogger(c).With().Str("op", refreshOp).Logger()
 
 	refreshToken, err := c.Cookie("refresh_token")
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get refresh token from cookie")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token required"})
+		middleware.Abort(c, errRefreshTokenRequired)
 		return
 	}
 
@@ -125,7 +310,7 @@ func (ah *AuthHandler) Refresh(c *gin.Context) {
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to refresh token")
 		c.SetCookie("refresh_token", "", -1, "/", "", false, true)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		middleware.Abort(c, unlessBanned(err, errRefreshFailed))
 		return
 	}
 
@@ -139,7 +324,7 @@ func (ah *AuthHandler) Refresh(c *gin.Context) {
 // @Tags auth
 // @Produce json
 // @Success 200 {object} response.LogoutSuccessResponse "Successfully logged out"
-// @Router /logout [post]
+// @Router /auth/logout [post]
 func (ah *AuthHandler) Logout(c *gin.Context) {
 	log := ah.getRequestLogger(c).With().Str("op", logoutOp).Logger()
 	refreshToken, err := c.Cookie("refresh_token")
@@ -154,6 +339,15 @@ func (ah *AuthHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
 }
 
+// unlessBanned replaces err with fallback unless it carries an active ban, whose reason and expiry
+// reach the client as error details.
+func unlessBanned(err, fallback error) error {
+	if errors.Is(err, usecase.ErrUserBanned) {
+		return err
+	}
+	return fallback
+}
+
 func (ah *AuthHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
 	reqLog := ah.Log.With().
 		Str("method", c.Request.Method).
@@ -164,6 +358,14 @@ func (ah *AuthHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
 	return &logger
 }
 
+// CheckSession godoc
+// @Summary Check the current session
+// @Description Reports whether the refresh token cookie belongs to an active session, and whose it is.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.IsSessionActiveResponse "Session is active"
+// @Failure 401 {object} response.IsSessionActiveResponse "No active session"
+// @Router /auth/session [get]
 func (ah *AuthHandler) CheckSession(c *gin.Context) {
 	log := ah.getRequestLogger(c).With().Str("op", checkSessionOp).Logger()
 
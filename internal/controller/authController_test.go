@@ -8,19 +8,31 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
 	authrequest "github.com/Van-programan/Forum_GO/internal/controller/request"
 	authresponse "github.com/Van-programan/Forum_GO/internal/controller/response"
 	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
 	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/password"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// newTestRouter returns an engine with the error middleware the services install, so handlers' aborts
+// are rendered the way clients see them.
+func newTestRouter() *gin.Engine {
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(middleware.Locale(), middleware.Errors(&log))
+	return router
+}
+
 func TestAuthHandler_Register_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -32,7 +44,6 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	reqBody := authrequest.RegisterRequest{
 		Username: "user",
 		Password: "password",
-		Role:     "user",
 	}
 	expectedUser := entity.User{ID: 1, Username: "user", Role: "user"}
 	expectedTokens := authresponse.Tokens{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"}
@@ -41,7 +52,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 		Tokens: expectedTokens,
 	}
 
-	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Role, reqBody.Password).
+	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Password).
 		Return(expectedResponse, nil).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
@@ -73,7 +84,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 
 func TestAuthHandler_Register_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -94,7 +105,7 @@ func TestAuthHandler_Register_InvalidJSON(t *testing.T) {
 
 func TestAuthHandler_Register_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -106,11 +117,10 @@ func TestAuthHandler_Register_UsecaseError(t *testing.T) {
 	reqBody := authrequest.RegisterRequest{
 		Username: "testuser",
 		Password: "password123",
-		Role:     "user",
 	}
 	usecaseError := errors.New("usecase failed to register")
 
-	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Role, reqBody.Password).
+	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Password).
 		Return(nil, usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
@@ -124,9 +134,72 @@ func TestAuthHandler_Register_UsecaseError(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestAuthHandler_Register_UsernameTaken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/register", handler.Register)
+
+	reqBody := authrequest.RegisterRequest{
+		Username: "testuser",
+		Password: "password123",
+	}
+
+	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Password).
+		Return(nil, usecase.ErrUsernameTaken).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"username_taken"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_Register_PasswordPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/register", handler.Register)
+
+	reqBody := authrequest.RegisterRequest{
+		Username: "testuser",
+		Password: "short",
+	}
+	policyErr := &password.PolicyError{Violations: []password.Violation{{Code: password.ViolationTooShort, Message: "password must be at least 8 characters long"}}}
+
+	mockUsecase.On("Register", mock.Anything, reqBody.Username, reqBody.Password).
+		Return(nil, policyErr).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"too_short"`)
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestAuthHandler_Login_SuccessWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -171,7 +244,7 @@ func TestAuthHandler_Login_SuccessWithoutToken(t *testing.T) {
 
 func TestAuthHandler_Login_SuccessWithToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -218,7 +291,7 @@ func TestAuthHandler_Login_SuccessWithToken(t *testing.T) {
 
 func TestAuthHandler_Login_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -239,7 +312,7 @@ func TestAuthHandler_Login_InvalidJSON(t *testing.T) {
 
 func TestAuthHandler_Login_Unauthorized(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -252,10 +325,9 @@ func TestAuthHandler_Login_Unauthorized(t *testing.T) {
 		Username: "user",
 		Password: "wrongpassword",
 	}
-	usecaseError := errors.New("invalid credentials from usecase")
 
 	mockUsecase.On("Login", mock.Anything, reqBody.Username, reqBody.Password, "").
-		Return(nil, usecaseError).Once()
+		Return(nil, usecase.ErrInvalidCredentials).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(jsonBody))
@@ -272,9 +344,191 @@ func TestAuthHandler_Login_Unauthorized(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestAuthHandler_Login_InternalError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/login", handler.Login)
+
+	reqBody := authrequest.LoginRequest{
+		Username: "user",
+		Password: "password",
+	}
+
+	mockUsecase.On("Login", mock.Anything, reqBody.Username, reqBody.Password, "").
+		Return(nil, errors.New("failed to get user: connection refused")).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "invalid_credentials")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_Login_Banned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/login", handler.Login)
+
+	reqBody := authrequest.LoginRequest{
+		Username: "user",
+		Password: "password",
+	}
+	banErr := &usecase.BanError{Ban: &entity.Ban{ID: 3, UserID: 1, Reason: "spam"}}
+
+	mockUsecase.On("Login", mock.Anything, reqBody.Username, reqBody.Password, "").
+		Return(nil, banErr).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	var respBody map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "user_banned", respBody["code"])
+	assert.Equal(t, usecase.ErrUserBanned.Error(), respBody["error"])
+	details, _ := respBody["details"].(map[string]interface{})
+	assert.Equal(t, "spam", details["reason"])
+	assert.Nil(t, details["expires_at"])
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_Login_MFARequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/login", handler.Login)
+
+	reqBody := authrequest.LoginRequest{
+		Username: "user",
+		Password: "password",
+	}
+	expectedResponse := &authresponse.LoginResponse{
+		User:        entity.User{ID: 1, Username: "user", Role: "user"},
+		MFARequired: true,
+		MFAToken:    "mfa_token",
+	}
+
+	mockUsecase.On("Login", mock.Anything, reqBody.Username, reqBody.Password, "").
+		Return(expectedResponse, nil).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	var respBody map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, true, respBody["mfa_required"])
+	assert.Equal(t, "mfa_token", respBody["mfa_token"])
+	assert.Nil(t, respBody["access_token"])
+	cookies := rr.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "", cookies[0].Value)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginMFA_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/login/mfa", handler.LoginMFA)
+
+	reqBody := authrequest.LoginMFARequest{MFAToken: "mfa_token", Code: "123456"}
+	expectedTokens := authresponse.Tokens{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"}
+	expectedResponse := &authresponse.LoginResponse{
+		User:   entity.User{ID: 1, Username: "user", Role: "user"},
+		Tokens: expectedTokens,
+	}
+
+	mockUsecase.On("LoginMFA", mock.Anything, reqBody.MFAToken, reqBody.Code).Return(expectedResponse, nil).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/login/mfa", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var respBody map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTokens.AccessToken, respBody["access_token"])
+	cookies := rr.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, expectedTokens.RefreshToken, cookies[0].Value)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginMFA_InvalidCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewAuthUsecase(t)
+	log := zerolog.Nop()
+	handler := &AuthHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/login/mfa", handler.LoginMFA)
+
+	reqBody := authrequest.LoginMFARequest{MFAToken: "mfa_token", Code: "000000"}
+	mockUsecase.On("LoginMFA", mock.Anything, reqBody.MFAToken, reqBody.Code).Return(nil, usecase.ErrInvalidMFACode).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/login/mfa", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Empty(t, rr.Result().Cookies())
+
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestAuthHandler_Refresh_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -313,7 +567,7 @@ func TestAuthHandler_Refresh_Success(t *testing.T) {
 
 func TestAuthHandler_Refresh_NoToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -336,7 +590,7 @@ func TestAuthHandler_Refresh_NoToken(t *testing.T) {
 
 func TestAuthHandler_Refresh_InvalidToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -372,7 +626,7 @@ func TestAuthHandler_Refresh_InvalidToken(t *testing.T) {
 
 func TestAuthHandler_Logout_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -407,7 +661,7 @@ func TestAuthHandler_Logout_Success(t *testing.T) {
 
 func TestAuthHandler_Logout_SuccesWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -433,7 +687,7 @@ func TestAuthHandler_Logout_SuccesWithoutToken(t *testing.T) {
 
 func TestAuthHandler_Logout_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -464,7 +718,7 @@ func TestAuthHandler_Logout_UsecaseError(t *testing.T) {
 
 func TestAuthHandler_CheckSession_SessionActive(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -502,7 +756,7 @@ func TestAuthHandler_CheckSession_SessionActive(t *testing.T) {
 
 func TestAuthHandler_CheckSession_SessionInactive(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -537,7 +791,7 @@ func TestAuthHandler_CheckSession_SessionInactive(t *testing.T) {
 
 func TestAuthHandler_CheckSession_NoToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
@@ -563,7 +817,7 @@ func TestAuthHandler_CheckSession_NoToken(t *testing.T) {
 
 func TestAuthHandler_CheckSession_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewAuthUsecase(t)
 	log := zerolog.Nop()
 	handler := &AuthHandler{
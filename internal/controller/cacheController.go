@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStatsReporter reports the hit and miss counts of a read cache. The caching repositories implement it.
+type CacheStatsReporter interface {
+	Stats() entity.CacheStats
+}
+
+type CacheHandler struct {
+	Caches []CacheStatsReporter
+}
+
+// Stats godoc
+// @Summary Get read cache statistics
+// @Description Returns the hit and miss counts of the in-process caches of categories and topics on the node that answers. Requires admin privileges.
+// @Tags cache
+// @Produce json
+// @Success 200 {object} response.CacheStatsResponse "Statistics of each cache; empty when caching is off"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (missing category.manage permission)"
+// @Security ApiKeyAuth
+// @Router /cache/stats [get]
+func (h *CacheHandler) Stats(c *gin.Context) {
+	stats := make([]entity.CacheStats, 0, len(h.Caches))
+	for _, cache := range h.Caches {
+		stats = append(stats, cache.Stats())
+	}
+	c.JSON(http.StatusOK, gin.H{"caches": stats})
+}
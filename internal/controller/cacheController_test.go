@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticCacheStats entity.CacheStats
+
+func (s staticCacheStats) Stats() entity.CacheStats {
+	return entity.CacheStats(s)
+}
+
+func TestCacheHandler_Stats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	handler := &CacheHandler{Caches: []CacheStatsReporter{
+		staticCacheStats{Name: "categories", Hits: 9, Misses: 1, Entries: 3},
+		staticCacheStats{Name: "topics", Hits: 2, Misses: 2, Entries: 2},
+	}}
+	router.GET("/cache/stats", handler.Stats)
+
+	req, _ := http.NewRequest(http.MethodGet, "/cache/stats", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var respBody response.CacheStatsResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &respBody))
+	assert.Equal(t, []entity.CacheStats{
+		{Name: "categories", Hits: 9, Misses: 1, Entries: 3},
+		{Name: "topics", Hits: 2, Misses: 2, Entries: 2},
+	}, respBody.Caches)
+}
+
+func TestCacheHandler_Stats_CachingOff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	handler := &CacheHandler{}
+	router.GET("/cache/stats", handler.Stats)
+
+	req, _ := http.NewRequest(http.MethodGet, "/cache/stats", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"caches":[]}`, rr.Body.String())
+}
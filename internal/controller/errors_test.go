@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// The controllers import every package that declares domain errors, so by the time the test runs
+// the registry holds all of their codes.
+func TestErrorCodes_Translated(t *testing.T) {
+	codes := entity.ErrorCodes()
+	assert.Contains(t, codes, "topic_not_found")
+	assert.Contains(t, codes, "password_policy")
+	assert.Contains(t, codes, "request_too_large")
+
+	for _, locale := range i18n.Locales() {
+		if locale == i18n.English {
+			continue
+		}
+		for _, code := range codes {
+			assert.True(t, i18n.Has(locale, i18n.ErrorCode(code)), "%s catalog has no message for %s", locale, code)
+		}
+	}
+}
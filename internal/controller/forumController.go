@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/internal/ws"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
@@ -60,6 +60,8 @@ const (
 	deleteTopicOp   = "TopicHandler.Delete"
 	updateTopicOp   = "TopicHandler.Update"
 	getByIDTopicOP  = "TopicHandler.GetByID"
+	lockTopicOp     = "TopicHandler.Lock"
+	unlockTopicOp   = "TopicHandler.Unlock"
 )
 
 func NewChatHandler(hub *ws.Hub, chatUsecase usecase.ChatUsecase, userClient client.UserClient, log *zerolog.Logger) *ChatHandler {
@@ -68,32 +70,30 @@ func NewChatHandler(hub *ws.Hub, chatUsecase usecase.ChatUsecase, userClient cli
 
 // Create godoc
 // @Summary Create a new category
-// @Description Creates a new category. Requires admin role.
+// @Description Creates a new category. Requires the category.manage permission.
 // @Tags categories
 // @Accept json
 // @Produce json
-// @Param category body entity.Category true "Category data to create. ID, CreatedAt, UpdatedAt will be ignored."
+// @Param category body request.CreateRequestCategory true "Category data to create"
 // @Success 201 {object} response.IDResponse "Category created successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid request payload"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an admin)"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (missing category.manage permission)"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", createOp).Logger()
 
-	var category entity.Category
-	if err := c.ShouldBindJSON(&category); err != nil {
-		log.Warn().Err(err).Msg("Failed to bind request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req request.CreateRequestCategory
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	id, err := h.Usecase.Create(c.Request.Context(), category)
+	id, err := h.Usecase.Create(c.Request.Context(), entity.Category{Title: req.Title, Description: req.Description})
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create category")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -107,8 +107,14 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID" Format(int64)
 // @Success 200 {object} response.CategoryResponse "Successfully retrieved category"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid category ID"
-// @Failure 500 {object} response.ErrorResponseForum "Failed to get category"
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of the cached copy"
+// @Success 304 "The cached copy is current"
+// @Header 200 {string} ETag "Version of the category, for If-Match and If-None-Match"
+// @Header 200 {string} Last-Modified "Time of the last change to the category"
+// @Header 200 {string} Cache-Control "public, max-age=60"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID"
+// @Failure 500 {object} response.ErrorResponse "Failed to get category"
 // @Router /categories/{id} [get]
 func (h *CategoryHandler) GetByID(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", getTitleOp).Logger()
@@ -116,17 +122,20 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to parse category id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
 		return
 	}
 
 	category, err := h.Usecase.GetByID(c.Request.Context(), categoryID)
 	if err != nil {
 		log.Error().Err(err).Int64("category_id", categoryID).Msg("Failed to get category")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get category"})
+		middleware.Abort(c, err)
 		return
 	}
 
+	if middleware.NotModified(c, middleware.ETag(category.Version), category.UpdatedAt) {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"category": category})
 
 }
@@ -136,16 +145,33 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 // @Description Retrieves a list of all categories.
 // @Tags categories
 // @Produce json
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of the cached copy"
 // @Success 200 {object} response.CategoriesResponse "Successfully retrieved all categories"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Success 304 "The cached copy is current"
+// @Header 200 {string} ETag "Version of the category list"
+// @Header 200 {string} Last-Modified "Time of the last change to the category list"
+// @Header 200 {string} Cache-Control "public, max-age=60"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /categories [get]
 func (h *CategoryHandler) GetAll(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", getAllOp).Logger()
 
+	// The list version is checked first, so clients with a current copy are answered without loading it.
+	version, err := h.Usecase.GetAllVersion(c.Request.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get categories version")
+		middleware.Abort(c, err)
+		return
+	}
+	if middleware.NotModified(c, middleware.ETag(version.Version), version.UpdatedAt) {
+		return
+	}
+
 	posts, err := h.Usecase.GetAll(c.Request.Context())
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get all categories")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -158,10 +184,10 @@ func (h *CategoryHandler) GetAll(c *gin.Context) {
 // @Tags categories
 // @Param id path int true "Category ID" Format(int64)
 // @Success 200 "Category deleted successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid category ID"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an admin)"
-// @Failure 500 {object} response.ErrorResponseForum "Failed to delete category"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (missing category.manage permission)"
+// @Failure 500 {object} response.ErrorResponse "Failed to delete category"
 // @Security ApiKeyAuth
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
@@ -170,13 +196,13 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to parse category id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
 		return
 	}
 
 	if err := h.Usecase.Delete(c.Request.Context(), categoryID); err != nil {
 		log.Error().Err(err).Msg("Failed to delete category")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete category"})
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -191,11 +217,16 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID" Format(int64)
 // @Param category_update body request.UpdateRequestCategory true "Category update data"
+// @Param If-Match header string true "ETag of the version being edited"
 // @Success 200 "Category updated successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid category ID or request payload"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an admin)"
-// @Failure 500 {object} response.ErrorResponseForum "Failed to update category"
+// @Header 200 {string} ETag "New version of the category"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (missing category.manage permission)"
+// @Failure 404 {object} response.ErrorResponse "Category not found"
+// @Failure 412 {object} response.ErrorResponse "Changed since it was read; details.current holds the current version"
+// @Failure 428 {object} response.ErrorResponse "If-Match header is missing"
+// @Failure 500 {object} response.ErrorResponse "Failed to update category"
 // @Security ApiKeyAuth
 // @Router /categories/{id} [patch]
 func (h *CategoryHandler) Update(c *gin.Context) {
@@ -204,23 +235,27 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to parse category id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
+		return
+	}
+	version, ok := middleware.IfMatch(c)
+	if !ok {
 		return
 	}
 
 	var req request.UpdateRequestCategory
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn().Err(err).Msg("Failed to bind request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	if err := h.Usecase.Update(c.Request.Context(), categoryID, req.Title, req.Description); err != nil {
+	newVersion, err := h.Usecase.Update(c.Request.Context(), categoryID, req.Title, req.Description, version)
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to update category")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update category"})
+		middleware.Abort(c, err)
 		return
 	}
 
+	middleware.SetETag(c, newVersion)
 	c.Status(http.StatusOK)
 }
 
@@ -243,7 +278,7 @@ func (h *ChatHandler) ServeWs(c *gin.Context) {
 	}
 
 	if !exists {
-		client := ws.NewUnauthorizedClient(h.hub, conn, h.chatUsecase)
+		client := ws.NewUnauthorizedClient(h.hub, conn, i18n.FromContext(c.Request.Context()), h.chatUsecase)
 		h.hub.Register <- client
 		go client.WritePump()
 		go client.ReadPump()
@@ -257,7 +292,7 @@ func (h *ChatHandler) ServeWs(c *gin.Context) {
 		return
 	}
 
-	client := ws.NewAuthorizedClient(h.hub, conn, userID, username, h.chatUsecase)
+	client := ws.NewAuthorizedClient(h.hub, conn, userID, username, i18n.FromContext(c.Request.Context()), h.chatUsecase)
 	h.hub.Register <- client
 
 	go client.WritePump()
@@ -265,36 +300,54 @@ func (h *ChatHandler) ServeWs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Connected to chat"})
 }
 
+// Create godoc
+// @Summary Create a new post
+// @Description Creates a post in a topic, optionally as a reply to another post of the topic. Requires authentication.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Topic ID to post in" Format(int64)
+// @Param post body request.CreateRequestPost true "Post data to create"
+// @Param Idempotency-Key header string false "Client-chosen key that makes retries of this request replay the first reply"
+// @Success 200 {object} response.IDResponse "Post created successfully"
+// @Header 200 {string} Idempotent-Replayed "true when the reply is repeated from an earlier request with the same key"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is banned or the topic is locked)"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 409 {object} response.ErrorResponse "A request with the same Idempotency-Key is still in progress"
+// @Failure 413 {object} response.ErrorResponse "Request body sent with an Idempotency-Key is over 1 MiB"
+// @Failure 422 {object} response.ErrorResponse "Idempotency-Key was already used for a different request"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "User service unavailable or the author's account not replicated yet, retry later"
+// @Security ApiKeyAuth
+// @Router /topics/{id}/posts [post]
 func (h *PostHandler) Create(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
 
 	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
 		return
 	}
 
-	var post entity.Post
-	if err := c.ShouldBindJSON(&post); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req request.CreateRequestPost
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	post.TopicID = topicID
-	post.AuthorID = &userID
-
-	id, err := h.Usecase.Create(c.Request.Context(), post)
+	id, err := h.Usecase.Create(c.Request.Context(), entity.Post{
+		TopicID:  topicID,
+		AuthorID: &userID,
+		Content:  req.Content,
+		ReplyTo:  req.ReplyTo,
+	})
 	if err != nil {
-		if errors.Is(err, usecase.ErrTopicNotFound) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -303,128 +356,165 @@ func (h *PostHandler) Create(c *gin.Context) {
 
 // GetByTopic godoc
 // @Summary Get posts by topic ID
-// @Description Retrieves a list of posts for a topic ID.
+// @Description Retrieves a list of posts for a topic ID. If the user service is unavailable, authors are placeholders and "degraded" is true.
 // @Tags posts
 // @Produce json
 // @Param id path int true "Topic ID" Format(int64)
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of the cached copy"
 // @Success 200 {object} response.PostsResponse "Successfully retrieved posts"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid topic ID"
-// @Failure 404 {object} response.ErrorResponseForum "Topic not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Success 304 "The cached copy is current"
+// @Header 200 {string} ETag "Version of the topic's post list and its authors"
+// @Header 200 {string} Last-Modified "Time a post was last created, edited or deleted in the topic"
+// @Header 200 {string} Cache-Control "public, no-cache, or no-store when degraded"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /topics/{id}/posts [get]
 func (h *PostHandler) GetByTopic(c *gin.Context) {
 	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic id"})
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
+		return
+	}
+
+	version, err := h.Usecase.GetByTopicVersion(c.Request.Context(), topicID)
+	if err != nil {
+		middleware.Abort(c, err)
 		return
 	}
 
-	posts, err := h.Usecase.GetByTopic(c.Request.Context(), topicID)
+	posts, degraded, err := h.Usecase.GetByTopic(c.Request.Context(), topicID)
 	if err != nil {
-		if errors.Is(err, usecase.ErrTopicNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
+		middleware.Abort(c, err)
+		return
+	}
+	res := gin.H{"posts": posts}
+	// The user service keeps no version of profiles, so the tag hashes the authors in with the post list.
+	if middleware.NotModified(c, middleware.ContentETag(version.Version, res), version.UpdatedAt) {
+		return
+	}
+	if degraded {
+		res["degraded"] = true
+		middleware.NoStore(c)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary Get a post by ID
+// @Description Retrieves a single post. If the user service is unavailable, the author is a placeholder and "degraded" is true.
+// @Tags posts
+// @Produce json
+// @Param id path int true "Post ID" Format(int64)
+// @Success 200 {object} response.PostResponse "Successfully retrieved post"
+// @Header 200 {string} ETag "Version of the post, for If-Match"
+// @Failure 400 {object} response.ErrorResponse "Invalid post ID"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /posts/{id} [get]
+func (h *PostHandler) GetByID(c *gin.Context) {
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("post id"))
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	post, degraded, err := h.Usecase.GetByID(c.Request.Context(), postID)
+	if err != nil {
+		middleware.Abort(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"posts": posts})
+
+	res := gin.H{"post": post}
+	if degraded {
+		res["degraded"] = true
+	}
+	middleware.SetETag(c, post.Version)
+	c.JSON(http.StatusOK, res)
 }
 
 // Update godoc
 // @Summary Update a post
-// @Description Updates a post. Requires authentication and ownership or admin role.
+// @Description Updates a post. Requires authentication and ownership, the matching moderation permission or moderator rights in the category.
 // @Tags posts
 // @Accept json
 // @Produce json
 // @Param id path int true "Post ID" Format(int64)
 // @Param post_update body request.UpdateRequestPost true "Post update data (only content)"
+// @Param If-Match header string true "ETag of the version being edited"
 // @Success 200 {object} response.SuccessMessageResponse "Post updated successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid post ID or request payload"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an owner or admin)"
-// @Failure 404 {object} response.ErrorResponseForum "Post not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Header 200 {string} ETag "New version of the post"
+// @Failure 400 {object} response.ErrorResponse "Invalid post ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not an owner or moderator)"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
+// @Failure 412 {object} response.ErrorResponse "Changed since it was read; details.current holds the current version"
+// @Failure 428 {object} response.ErrorResponse "If-Match header is missing"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /posts/{id} [patch]
 func (h *PostHandler) Update(c *gin.Context) {
-	userID, exists := middleware.GetUserIDFromContext(c)
+	actor, exists := middleware.GetActorFromContext(c)
 	if !exists {
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
-	role, _ := middleware.GetRoleFromContext(c)
 
 	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		middleware.Abort(c, middleware.InvalidParameter("post id"))
+		return
+	}
+	version, ok := middleware.IfMatch(c)
+	if !ok {
 		return
 	}
 
 	var req request.UpdateRequestPost
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	err = h.Usecase.Update(c.Request.Context(), postID, userID, role, req.Content)
+	newVersion, err := h.Usecase.Update(c.Request.Context(), postID, actor, req.Content, version)
 	if err != nil {
-		if errors.Is(err, usecase.ErrForbidden) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			return
-		}
-		if errors.Is(err, usecase.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		middleware.Abort(c, err)
 		return
 	}
 
+	middleware.SetETag(c, newVersion)
 	c.JSON(http.StatusOK, gin.H{"message": "post updated"})
 }
 
 // Delete godoc
 // @Summary Delete a post
-// @Description Deletes a post by its ID. Requires authentication and ownership or admin role.
+// @Description Deletes a post by its ID. Requires authentication and ownership, the matching moderation permission or moderator rights in the category.
 // @Tags posts
 // @Param id path int true "Post ID" Format(int64)
 // @Success 200 {object} response.SuccessMessageResponse "Post deleted successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid post ID"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an owner or admin)"
-// @Failure 404 {object} response.ErrorResponseForum "Post not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Failure 400 {object} response.ErrorResponse "Invalid post ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not an owner or moderator)"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /posts/{id} [delete]
 func (h *PostHandler) Delete(c *gin.Context) {
-	userID, exists := middleware.GetUserIDFromContext(c)
+	actor, exists := middleware.GetActorFromContext(c)
 	if !exists {
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
-	role, _ := middleware.GetRoleFromContext(c)
 
 	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		middleware.Abort(c, middleware.InvalidParameter("post id"))
 		return
 	}
 
-	err = h.Usecase.Delete(c.Request.Context(), postID, userID, role)
+	err = h.Usecase.Delete(c.Request.Context(), postID, actor)
 	if err != nil {
-		if errors.Is(err, usecase.ErrForbidden) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			return
-		}
-		if errors.Is(err, usecase.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		middleware.Abort(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "post deleted"})
@@ -437,12 +527,18 @@ func (h *PostHandler) Delete(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Category ID to create topic in" Format(int64)
-// @Param topic body entity.Topic true "Topic data to create. ID, AuthorID, CategoryID, CreatedAt, UpdatedAt will be ignored or overridden."
+// @Param topic body request.CreateRequestTopic true "Topic data to create"
+// @Param Idempotency-Key header string false "Client-chosen key that makes retries of this request replay the first reply"
 // @Success 200 {object} response.IDResponse "Topic created successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid category ID or request payload"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not authorized or trying to impersonate)"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Header 200 {string} Idempotent-Replayed "true when the reply is repeated from an earlier request with the same key"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is banned, not authorized or trying to impersonate)"
+// @Failure 409 {object} response.ErrorResponse "A request with the same Idempotency-Key is still in progress"
+// @Failure 413 {object} response.ErrorResponse "Request body sent with an Idempotency-Key is over 1 MiB"
+// @Failure 422 {object} response.ErrorResponse "Idempotency-Key was already used for a different request"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Failure 503 {object} response.ErrorResponse "User service unavailable or the author's account not replicated yet, retry later"
 // @Security ApiKeyAuth
 // @Router /categories/{id}/topics [post]
 func (h *TopicHandler) Create(c *gin.Context) {
@@ -451,37 +547,26 @@ func (h *TopicHandler) Create(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
 	if !exists {
 		log.Warn().Msg("insufficient permissions")
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
 
 	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Msg("invalid category id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
 		return
 	}
 
-	var topic entity.Topic
-	if err := c.ShouldBindJSON(&topic); err != nil {
-		log.Warn().Err(err).Msg("failed to bind request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req request.CreateRequestTopic
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	topic.AuthorID = &userID
-	topic.CategoryID = categoryID
-
-	id, err := h.Usecase.Create(c.Request.Context(), topic)
+	id, err := h.Usecase.Create(c.Request.Context(), entity.Topic{CategoryID: categoryID, AuthorID: &userID, Title: req.Title})
 	if err != nil {
-		if errors.Is(err, usecase.ErrCategoryNotFound) {
-			log.Warn().Msg("category not found")
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
 		log.Error().Err(err).Msg("failed to create topic")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Abort(c, err)
 		return
 	}
 
@@ -490,13 +575,20 @@ func (h *TopicHandler) Create(c *gin.Context) {
 
 // GetByID godoc
 // @Summary Get a topic by ID
-// @Description Retrieves a specific topic by its ID.
+// @Description Retrieves a specific topic by its ID. If the user service is unavailable, the author is a placeholder and "degraded" is true.
 // @Tags topics
 // @Produce json
 // @Param id path int true "Topic ID" Format(int64)
 // @Success 200 {object} response.TopicResponse "Successfully retrieved topic"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid topic ID"
-// @Failure 500 {object} response.ErrorResponseForum "Failed to get topic"
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of the cached copy"
+// @Success 304 "The cached copy is current"
+// @Header 200 {string} ETag "Version of the topic and its author, for If-Match and If-None-Match"
+// @Header 200 {string} Last-Modified "Time of the last change to the topic"
+// @Header 200 {string} Cache-Control "public, no-cache, or no-store when degraded"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 500 {object} response.ErrorResponse "Failed to get topic"
 // @Router /topics/{id} [get]
 func (h *TopicHandler) GetByID(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", getByIDTopicOP).Logger()
@@ -504,31 +596,39 @@ func (h *TopicHandler) GetByID(c *gin.Context) {
 	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to parse topic id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic id"})
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
 		return
 	}
 
-	topic, err := h.Usecase.GetByID(c.Request.Context(), topicID)
+	topic, degraded, err := h.Usecase.GetByID(c.Request.Context(), topicID)
 	if err != nil {
 		log.Error().Err(err).Int64("topic_id", topicID).Msg("Failed to get topic")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get topic"})
+		middleware.Abort(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"topic": topic})
+	res := gin.H{"topic": topic}
+	if middleware.NotModified(c, middleware.ContentETag(topic.Version, res), topic.UpdatedAt) {
+		return
+	}
+	if degraded {
+		res["degraded"] = true
+		middleware.NoStore(c)
+	}
+	c.JSON(http.StatusOK, res)
 
 }
 
 // GetByCategory godoc
 // @Summary Get topics by category ID
-// @Description Retrieves a list of topics for a category ID.
+// @Description Retrieves a list of topics for a category ID. If the user service is unavailable, authors are placeholders and "degraded" is true.
 // @Tags topics
 // @Produce json
 // @Param id path int true "Category ID" Format(int64)
 // @Success 200 {object} response.TopicsResponse "Successfully retrieved topics"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid category ID"
-// @Failure 404 {object} response.ErrorResponseForum "Category not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID"
+// @Failure 404 {object} response.ErrorResponse "Category not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /categories/{id}/topics [get]
 func (h *TopicHandler) GetByCategory(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", getByCategoryOp).Logger()
@@ -536,137 +636,185 @@ func (h *TopicHandler) GetByCategory(c *gin.Context) {
 	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Msg("invalid category id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
 		return
 	}
 
-	topics, err := h.Usecase.GetByCategory(c.Request.Context(), categoryID)
+	topics, degraded, err := h.Usecase.GetByCategory(c.Request.Context(), categoryID)
 	if err != nil {
-		if errors.Is(err, usecase.ErrCategoryNotFound) {
-			log.Warn().Msg("category not found")
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
 
 		log.Error().Err(err).Msg("failed to get topics by category")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		middleware.Abort(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"topics": topics})
+	res := gin.H{"topics": topics}
+	if degraded {
+		res["degraded"] = true
+	}
+	c.JSON(http.StatusOK, res)
 }
 
 // Update godoc
 // @Summary Update a topic
-// @Description Updates a topic. Requires authentication and ownership or admin role.
+// @Description Updates a topic. Requires authentication and ownership, the matching moderation permission or moderator rights in the category.
 // @Tags topics
 // @Accept json
 // @Produce json
 // @Param id path int true "Topic ID" Format(int64)
 // @Param topic_update body request.UpdateRequestTopic true "Topic update data (only title)"
+// @Param If-Match header string true "ETag of the version being edited"
 // @Success 200 {object} response.SuccessMessageResponse "Topic updated successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid topic ID or request payload"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an owner or admin)"
-// @Failure 404 {object} response.ErrorResponseForum "Topic not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Header 200 {string} ETag "New version of the topic"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not an owner or moderator)"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 412 {object} response.ErrorResponse "Changed since it was read; details.current holds the current version"
+// @Failure 428 {object} response.ErrorResponse "If-Match header is missing"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /topics/{id} [patch]
 func (h *TopicHandler) Update(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", updateTopicOp).Logger()
 
-	userID, exists := middleware.GetUserIDFromContext(c)
+	actor, exists := middleware.GetActorFromContext(c)
 	if !exists {
 		log.Warn().Msg("insufficient permissions")
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
-	role, _ := middleware.GetRoleFromContext(c)
 
 	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic id"})
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
+		return
+	}
+	version, ok := middleware.IfMatch(c)
+	if !ok {
 		return
 	}
 
 	var req request.UpdateRequestTopic
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn().Err(err).Msg("failed to bind request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindJSON(c, &req) {
 		return
 	}
 
-	err = h.Usecase.Update(c.Request.Context(), topicID, userID, role, req.Title)
+	newVersion, err := h.Usecase.Update(c.Request.Context(), topicID, actor, req.Title, version)
 	if err != nil {
-		if errors.Is(err, usecase.ErrForbidden) {
-			log.Warn().Msg("insufficient permissions")
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			return
-		}
-		if errors.Is(err, usecase.ErrPostNotFound) {
-			log.Warn().Msg("post not found")
-			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		middleware.Abort(c, err)
 		return
 	}
 
+	middleware.SetETag(c, newVersion)
 	c.JSON(http.StatusOK, gin.H{"message": "post updated"})
 }
 
 // Delete godoc
 // @Summary Delete a topic
-// @Description Deletes a topic by its ID. Requires authentication and ownership or role.
+// @Description Deletes a topic by its ID. Requires authentication and ownership, the matching moderation permission or moderator rights in the category.
 // @Tags topics
 // @Param id path int true "Topic ID" Format(int64)
 // @Success 200 {object} response.SuccessMessageResponse "Topic deleted successfully"
-// @Failure 400 {object} response.ErrorResponseForum "Invalid topic ID"
-// @Failure 401 {object} response.ErrorResponseForum "Unauthorized (token is missing or invalid)"
-// @Failure 403 {object} response.ErrorResponseForum "Forbidden (user is not an owner or admin)"
-// @Failure 404 {object} response.ErrorResponseForum "Topic not found"
-// @Failure 500 {object} response.ErrorResponseForum "Internal server error"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not an owner or moderator)"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /topics/{id} [delete]
 func (h *TopicHandler) Delete(c *gin.Context) {
 	log := h.getRequestLogger(c).With().Str("op", deleteTopicOp).Logger()
-	userID, exists := middleware.GetUserIDFromContext(c)
+	actor, exists := middleware.GetActorFromContext(c)
 
 	if !exists {
 		log.Warn().Msg("insufficient permissions")
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
 		return
 	}
-	role, _ := middleware.GetRoleFromContext(c)
 
 	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Warn().Msg("invalid topic id")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid topic id"})
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
 		return
 	}
 
-	err = h.Usecase.Delete(c.Request.Context(), topicID, userID, role)
+	err = h.Usecase.Delete(c.Request.Context(), topicID, actor)
 	if err != nil {
-		if errors.Is(err, usecase.ErrForbidden) {
-			log.Warn().Msg("insufficient permissions")
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			return
-		}
-		if errors.Is(err, usecase.ErrPostNotFound) {
-			log.Warn().Msg("post not found")
-			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
-			return
-		}
 		log.Error().Err(err).Msg("failed to delete topic")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		middleware.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "topic deleted"})
 }
 
+// Lock godoc
+// @Summary Lock a topic
+// @Description Locks a topic so no new posts can be added. Requires the topic.lock permission globally or as a moderator of the category.
+// @Tags topics
+// @Param id path int true "Topic ID" Format(int64)
+// @Success 200 {object} response.SuccessMessageResponse "Topic locked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not a moderator)"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /topics/{id}/lock [post]
+func (h *TopicHandler) Lock(c *gin.Context) {
+	h.setLocked(c, lockTopicOp, true)
+}
+
+// Unlock godoc
+// @Summary Unlock a topic
+// @Description Unlocks a topic. Requires the topic.lock permission globally or as a moderator of the category.
+// @Tags topics
+// @Param id path int true "Topic ID" Format(int64)
+// @Success 200 {object} response.SuccessMessageResponse "Topic unlocked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid topic ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized (token is missing or invalid)"
+// @Failure 403 {object} response.ErrorResponse "Forbidden (user is not a moderator)"
+// @Failure 404 {object} response.ErrorResponse "Topic not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /topics/{id}/unlock [post]
+func (h *TopicHandler) Unlock(c *gin.Context) {
+	h.setLocked(c, unlockTopicOp, false)
+}
+
+func (h *TopicHandler) setLocked(c *gin.Context, op string, locked bool) {
+	log := h.getRequestLogger(c).With().Str("op", op).Logger()
+
+	actor, exists := middleware.GetActorFromContext(c)
+	if !exists {
+		log.Warn().Msg("insufficient permissions")
+		middleware.Abort(c, middleware.ErrInsufficientPermissions)
+		return
+	}
+
+	topicID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Warn().Msg("invalid topic id")
+		middleware.Abort(c, middleware.InvalidParameter("topic id"))
+		return
+	}
+
+	err = h.Usecase.SetLocked(c.Request.Context(), topicID, actor, locked)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to change topic lock")
+		middleware.Abort(c, err)
+		return
+	}
+
+	if locked {
+		c.JSON(http.StatusOK, gin.H{"message": "topic locked"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "topic unlocked"})
+}
+
 func (h *TopicHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
 	reqLog := h.Log.With().
 		Str("method", c.Request.Method).
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -13,11 +14,13 @@ import (
 
 	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
 	requests "github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/internal/ws"
 	mocksf "github.com/Van-programan/Forum_GO/mocks/forum"
 	mocks "github.com/Van-programan/Forum_GO/mocks/forum/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
@@ -32,7 +35,7 @@ const (
 
 func TestCategoryHandler_Create_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -63,7 +66,7 @@ func TestCategoryHandler_Create_Success(t *testing.T) {
 
 func TestCategoryHandler_Create_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -84,7 +87,7 @@ func TestCategoryHandler_Create_InvalidJSON(t *testing.T) {
 
 func TestCategoryHandler_Create_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -109,13 +112,13 @@ func TestCategoryHandler_Create_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestCategoryHandler_GetByID_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -125,7 +128,7 @@ func TestCategoryHandler_GetByID_Success(t *testing.T) {
 	categoryID := int64(1)
 	router.GET("/categories/:id", handler.GetByID)
 
-	expectedCategory := &entity.Category{ID: categoryID, Title: "Test", Description: "Test Desc", CreatedAt: time.Now()}
+	expectedCategory := &entity.Category{ID: categoryID, Title: "Test", Description: "Test Desc", Version: 5, CreatedAt: time.Now()}
 	mockUsecase.On("GetByID", mock.Anything, categoryID).Return(expectedCategory, nil).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories/"+strconv.FormatInt(categoryID, 10), nil)
@@ -133,6 +136,7 @@ func TestCategoryHandler_GetByID_Success(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
 	var respBody map[string]entity.Category
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
@@ -141,9 +145,43 @@ func TestCategoryHandler_GetByID_Success(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestCategoryHandler_GetByID_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewCategoryUsecase(t)
+	logger := zerolog.Nop()
+	handler := &CategoryHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	categoryID := int64(1)
+	router.GET("/categories/:id", handler.GetByID)
+
+	updatedAt := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+	category := &entity.Category{ID: categoryID, Title: "Test", Version: 5, UpdatedAt: updatedAt}
+	mockUsecase.On("GetByID", mock.Anything, categoryID).Return(category, nil).Twice()
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories/"+strconv.FormatInt(categoryID, 10), nil)
+	req.Header.Set("If-Modified-Since", "Sat, 01 Mar 2025 12:00:00 GMT")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+
+	// If-None-Match takes precedence, so an outdated tag gets the category even though the date matches.
+	req.Header.Set("If-None-Match", `"4"`)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestCategoryHandler_GetByID_InvalidID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -162,7 +200,7 @@ func TestCategoryHandler_GetByID_InvalidID(t *testing.T) {
 
 func TestCategoryHandler_GetByID_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -183,13 +221,13 @@ func TestCategoryHandler_GetByID_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "failed to get category", respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestCategoryHandler_GetAll_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -202,6 +240,8 @@ func TestCategoryHandler_GetAll_Success(t *testing.T) {
 		{ID: 1, Title: "Cat1", Description: "D1", CreatedAt: time.Now()},
 		{ID: 2, Title: "Cat2", Description: "D2", CreatedAt: time.Now()},
 	}
+	updatedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockUsecase.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 4, UpdatedAt: updatedAt}, nil).Once()
 	mockUsecase.On("GetAll", mock.Anything).Return(expectedCategories, nil).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories", nil)
@@ -209,6 +249,8 @@ func TestCategoryHandler_GetAll_Success(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+	assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", rr.Header().Get("Last-Modified"))
 	var respBody map[string][]entity.Category
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
@@ -219,7 +261,7 @@ func TestCategoryHandler_GetAll_Success(t *testing.T) {
 
 func TestCategoryHandler_GetAll_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -229,6 +271,7 @@ func TestCategoryHandler_GetAll_UsecaseError(t *testing.T) {
 	router.GET("/categories", handler.GetAll)
 
 	usecaseError := errors.New("usecase get all error")
+	mockUsecase.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 4}, nil).Once()
 	mockUsecase.On("GetAll", mock.Anything).Return(nil, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories", nil)
@@ -239,13 +282,39 @@ func TestCategoryHandler_GetAll_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
+	assert.Empty(t, rr.Header().Get("ETag"))
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestCategoryHandler_GetAll_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewCategoryUsecase(t)
+	logger := zerolog.Nop()
+	handler := &CategoryHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	router.GET("/categories", handler.GetAll)
+
+	mockUsecase.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 4, UpdatedAt: time.Now()}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories", nil)
+	req.Header.Set("If-None-Match", `"3", "4"`)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+	mockUsecase.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
 func TestCategoryHandler_Delete_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -267,7 +336,7 @@ func TestCategoryHandler_Delete_Success(t *testing.T) {
 
 func TestCategoryHandler_Delete_InvalidID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -286,7 +355,7 @@ func TestCategoryHandler_Delete_InvalidID(t *testing.T) {
 
 func TestCategoryHandler_Delete_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -307,13 +376,13 @@ func TestCategoryHandler_Delete_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "failed to delete category", respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestCategoryHandler_Update_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -324,22 +393,24 @@ func TestCategoryHandler_Update_Success(t *testing.T) {
 	router.PUT("/categories/:id", handler.Update)
 
 	reqBody := requests.UpdateRequestCategory{Title: "updated title", Description: "updated desc"}
-	mockUsecase.On("Update", mock.Anything, categoryID, reqBody.Title, reqBody.Description).Return(nil).Once()
+	mockUsecase.On("Update", mock.Anything, categoryID, reqBody.Title, reqBody.Description, int64(2)).Return(int64(3), nil).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/categories/"+strconv.FormatInt(categoryID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestCategoryHandler_Update_InvalidID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -352,6 +423,7 @@ func TestCategoryHandler_Update_InvalidID(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/categories/invalid", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -362,7 +434,7 @@ func TestCategoryHandler_Update_InvalidID(t *testing.T) {
 
 func TestCategoryHandler_Update_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -374,6 +446,7 @@ func TestCategoryHandler_Update_InvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest(http.MethodPut, "/categories/"+strconv.FormatInt(categoryID, 10), bytes.NewBufferString("{invalid_json"))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -384,7 +457,7 @@ func TestCategoryHandler_Update_InvalidJSON(t *testing.T) {
 
 func TestCategoryHandler_Update_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewCategoryUsecase(t)
 	logger := zerolog.Nop()
 	handler := &CategoryHandler{
@@ -396,11 +469,12 @@ func TestCategoryHandler_Update_UsecaseError(t *testing.T) {
 
 	reqBody := requests.UpdateRequestCategory{Title: "updated title", Description: "updated desc"}
 	usecaseError := errors.New("usecase update error")
-	mockUsecase.On("Update", mock.Anything, categoryID, reqBody.Title, reqBody.Description).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, categoryID, reqBody.Title, reqBody.Description, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/categories/"+strconv.FormatInt(categoryID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -409,13 +483,13 @@ func TestCategoryHandler_Update_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "failed to update category", respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func setupTestServerForChatOnlyUpgrade(t *testing.T, handler *ChatHandler) (*httptest.Server, string) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/ws", handler.ServeWs)
 	server := httptest.NewServer(router)
 	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
@@ -427,7 +501,7 @@ func TestChatHandler_ServeWs_UpgradesConnection_UnauthorizedPath(t *testing.T) {
 	logger := zerolog.Nop()
 	emptyMockChatUsecase := new(mocks.ChatUsecase)
 	emptyMockUserClient := new(mocksf.UserClient)
-	dummyHub := ws.NewHub(&logger)
+	dummyHub := ws.NewHub(&logger, nil)
 
 	chatHandler := NewChatHandler(dummyHub, emptyMockChatUsecase, emptyMockUserClient, &logger)
 	_, wsURL := setupTestServerForChatOnlyUpgrade(t, chatHandler)
@@ -454,7 +528,7 @@ func TestChatHandler_ServeWs_UpgradesConnection_AuthorizedPath(t *testing.T) {
 	logger := zerolog.Nop()
 	emptyMockChatUsecase := new(mocks.ChatUsecase)
 	mockUserClientActual := new(mocksf.UserClient)
-	dummyHub := ws.NewHub(&logger)
+	dummyHub := ws.NewHub(&logger, nil)
 
 	expectedUserID := int64(123)
 	expectedUsername := "testuser"
@@ -464,7 +538,7 @@ func TestChatHandler_ServeWs_UpgradesConnection_AuthorizedPath(t *testing.T) {
 	chatHandler := NewChatHandler(dummyHub, emptyMockChatUsecase, mockUserClientActual, &logger)
 
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	router.Use(func(c *gin.Context) {
 		c.Set(middleware.ContextUserIDKey, expectedUserID)
 		c.Next()
@@ -497,7 +571,7 @@ func TestChatHandler_ServeWs_UpgradeFail_BadOrigin_NoHubLogic(t *testing.T) {
 	logger := zerolog.Nop()
 	emptyMockChatUsecase := new(mocks.ChatUsecase)
 	emptyMockUserClient := new(mocksf.UserClient)
-	dummyHub := ws.NewHub(&logger)
+	dummyHub := ws.NewHub(&logger, nil)
 
 	chatHandler := NewChatHandler(dummyHub, emptyMockChatUsecase, emptyMockUserClient, &logger)
 	_, wsURL := setupTestServerForChatOnlyUpgrade(t, chatHandler)
@@ -519,7 +593,7 @@ func TestChatHandler_ServeWs_UpgradeFail_BadOrigin_NoHubLogic(t *testing.T) {
 
 func TestPostHandler_Create_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -556,7 +630,7 @@ func TestPostHandler_Create_Success(t *testing.T) {
 
 func TestPostHandler_Create_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -578,7 +652,7 @@ func TestPostHandler_Create_NoUserIDInContext(t *testing.T) {
 
 func TestPostHandler_Create_InvalidTopicID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -602,13 +676,52 @@ func TestPostHandler_Create_InvalidTopicID(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "invalid category id", respBody["error"])
+	assert.Equal(t, "invalid topic id", respBody["error"])
+	mockUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_Create_ContentTooLong(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	userID := int64(10)
+	router.POST("/topics/:id/posts", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		handler.Create(c)
+	})
+
+	jsonBody, _ := json.Marshal(map[string]string{"content": strings.Repeat("a", 20001)})
+	req, _ := http.NewRequest(http.MethodPost, "/topics/1/posts", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "ru")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var respBody struct {
+		Code    string `json:"code"`
+		Details struct {
+			Fields []response.FieldError `json:"fields"`
+		} `json:"details"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation_failed", respBody.Code)
+	assert.Equal(t, []response.FieldError{{
+		Field:   "content",
+		Rule:    "max",
+		Message: "длина поля content должна быть не больше 20000",
+	}}, respBody.Details.Fields)
 	mockUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
 func TestPostHandler_Create_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -633,7 +746,7 @@ func TestPostHandler_Create_InvalidJSON(t *testing.T) {
 
 func TestPostHandler_Create_TopicNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -659,17 +772,54 @@ func TestPostHandler_Create_TopicNotFound(t *testing.T) {
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
 	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "topic_not_found", respBody["code"])
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_Create_AuthorBanned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	topicID := int64(1)
+	userID := int64(10)
+	router.POST("/topics/:id/posts", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		c.Set(ContextRoleKey, "user")
+		handler.Create(c)
+	})
+
+	reqBody := entity.Post{Content: "Test Content"}
+	usecaseError := fmt.Errorf("ForumService - PostUsecase - Create: %w", usecase.ErrUserBanned)
+
+	expectedEntityPost := entity.Post{TopicID: topicID, AuthorID: &userID, Content: reqBody.Content}
+	mockUsecase.On("Create", mock.Anything, expectedEntityPost).Return(int64(0), usecaseError).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	var respBody map[string]string
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.ErrUserBanned.Error(), respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestPostHandler_Create_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -699,13 +849,13 @@ func TestPostHandler_Create_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestPostHandler_GetByTopic_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -714,10 +864,11 @@ func TestPostHandler_GetByTopic_Success(t *testing.T) {
 	router.GET("/topics/:id/posts", handler.GetByTopic)
 
 	expectedPosts := []entity.Post{
-		{ID: 1, TopicID: topicID, Content: "Post 1", Username: "User1"},
-		{ID: 2, TopicID: topicID, Content: "Post 2", Username: "User2"},
+		{ID: 1, TopicID: topicID, Content: "Post 1", Author: &entity.Author{ID: 1, Username: "User1"}},
+		{ID: 2, TopicID: topicID, Content: "Post 2", Author: &entity.Author{ID: 2, Username: "User2"}},
 	}
-	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(expectedPosts, nil).Once()
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{Version: 9, UpdatedAt: time.Now()}, nil).Once()
+	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(expectedPosts, false, nil).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
 	rr := httptest.NewRecorder()
@@ -729,12 +880,97 @@ func TestPostHandler_GetByTopic_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, respBody["posts"], 2)
 	assert.Equal(t, expectedPosts[0].Content, respBody["posts"][0].Content)
+	assert.NotContains(t, rr.Body.String(), "degraded")
+	assert.True(t, strings.HasPrefix(rr.Header().Get("ETag"), `"9-`))
+	assert.NotEmpty(t, rr.Header().Get("Last-Modified"))
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetByTopic_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	topicID := int64(1)
+	router.GET("/topics/:id/posts", handler.GetByTopic)
+
+	posts := []entity.Post{{ID: 1, TopicID: topicID, Content: "Post 1", Author: &entity.Author{ID: 1, Username: "User1"}}}
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{Version: 9, UpdatedAt: time.Now()}, nil).Once()
+	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(posts, false, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
+	req.Header.Set("If-None-Match", "W/"+middleware.ContentETag(9, gin.H{"posts": posts}))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetByTopic_AuthorChanged(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	topicID := int64(1)
+	router.GET("/topics/:id/posts", handler.GetByTopic)
+
+	cached := []entity.Post{{ID: 1, TopicID: topicID, Content: "Post 1", Author: &entity.Author{ID: 1, Username: "User1"}}}
+	renamed := []entity.Post{{ID: 1, TopicID: topicID, Content: "Post 1", Author: &entity.Author{ID: 1, Username: "Renamed"}}}
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{Version: 9, UpdatedAt: time.Now()}, nil).Once()
+	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(renamed, false, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
+	req.Header.Set("If-None-Match", middleware.ContentETag(9, gin.H{"posts": cached}))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Renamed")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetByTopic_Degraded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	topicID := int64(1)
+	router.GET("/topics/:id/posts", handler.GetByTopic)
+
+	expectedPosts := []entity.Post{
+		{ID: 1, TopicID: topicID, Content: "Post 1", Author: &entity.Author{ID: 7, Username: "Пользователь #7"}},
+	}
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{Version: 9}, nil).Once()
+	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(expectedPosts, true, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var respBody struct {
+		Posts    []entity.Post `json:"posts"`
+		Degraded bool          `json:"degraded"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.True(t, respBody.Degraded)
+	assert.Len(t, respBody.Posts, 1)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestPostHandler_GetByTopic_InvalidTopicID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -755,7 +991,7 @@ func TestPostHandler_GetByTopic_InvalidTopicID(t *testing.T) {
 
 func TestPostHandler_GetByTopic_TopicNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -764,7 +1000,7 @@ func TestPostHandler_GetByTopic_TopicNotFound(t *testing.T) {
 	router.GET("/topics/:id/posts", handler.GetByTopic)
 
 	usecaseError := usecase.ErrTopicNotFound
-	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(nil, usecaseError).Once()
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{}, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
 	rr := httptest.NewRecorder()
@@ -780,7 +1016,7 @@ func TestPostHandler_GetByTopic_TopicNotFound(t *testing.T) {
 
 func TestPostHandler_GetByTopic_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -789,7 +1025,8 @@ func TestPostHandler_GetByTopic_UsecaseError(t *testing.T) {
 	router.GET("/topics/:id/posts", handler.GetByTopic)
 
 	usecaseError := errors.New("some other get by topic error")
-	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(nil, usecaseError).Once()
+	mockUsecase.On("GetByTopicVersion", mock.Anything, topicID).Return(entity.CollectionVersion{Version: 9}, nil).Once()
+	mockUsecase.On("GetByTopic", mock.Anything, topicID).Return(nil, false, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10)+"/posts", nil)
 	rr := httptest.NewRecorder()
@@ -799,13 +1036,13 @@ func TestPostHandler_GetByTopic_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestPostHandler_Update_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -821,16 +1058,18 @@ func TestPostHandler_Update_Success(t *testing.T) {
 	})
 
 	reqBody := requests.UpdateRequestPost{Content: "updated content"}
-	mockUsecase.On("Update", mock.Anything, postID, userID, userRole, reqBody.Content).Return(nil).Once()
+	mockUsecase.On("Update", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Content, int64(2)).Return(int64(3), nil).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
@@ -840,7 +1079,7 @@ func TestPostHandler_Update_Success(t *testing.T) {
 
 func TestPostHandler_Update_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -852,6 +1091,7 @@ func TestPostHandler_Update_NoUserIDInContext(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -862,7 +1102,7 @@ func TestPostHandler_Update_NoUserIDInContext(t *testing.T) {
 
 func TestPostHandler_Update_InvalidPostID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -879,6 +1119,7 @@ func TestPostHandler_Update_InvalidPostID(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/invalid", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -893,7 +1134,7 @@ func TestPostHandler_Update_InvalidPostID(t *testing.T) {
 
 func TestPostHandler_Update_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -909,6 +1150,7 @@ func TestPostHandler_Update_InvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBufferString("{invalid_json"))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -919,7 +1161,7 @@ func TestPostHandler_Update_InvalidJSON(t *testing.T) {
 
 func TestPostHandler_Update_Forbidden(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -935,11 +1177,12 @@ func TestPostHandler_Update_Forbidden(t *testing.T) {
 
 	reqBody := requests.UpdateRequestPost{Content: "updated content"}
 	usecaseError := usecase.ErrForbidden
-	mockUsecase.On("Update", mock.Anything, postID, userID, userRole, reqBody.Content).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Content, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -954,7 +1197,7 @@ func TestPostHandler_Update_Forbidden(t *testing.T) {
 
 func TestPostHandler_Update_PostNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -970,11 +1213,12 @@ func TestPostHandler_Update_PostNotFound(t *testing.T) {
 
 	reqBody := requests.UpdateRequestPost{Content: "updated content"}
 	usecaseError := usecase.ErrPostNotFound
-	mockUsecase.On("Update", mock.Anything, postID, userID, userRole, reqBody.Content).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Content, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -989,7 +1233,7 @@ func TestPostHandler_Update_PostNotFound(t *testing.T) {
 
 func TestPostHandler_Update_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1005,11 +1249,12 @@ func TestPostHandler_Update_UsecaseError(t *testing.T) {
 
 	reqBody := requests.UpdateRequestPost{Content: "updated content"}
 	usecaseError := errors.New("some other update error")
-	mockUsecase.On("Update", mock.Anything, postID, userID, userRole, reqBody.Content).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Content, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1022,9 +1267,131 @@ func TestPostHandler_Update_UsecaseError(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestPostHandler_Update_MissingIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	postID := int64(1)
+	router.PUT("/posts/:id", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, int64(10))
+		c.Set(ContextRoleKey, "user")
+		handler.Update(c)
+	})
+
+	jsonBody, _ := json.Marshal(requests.UpdateRequestPost{Content: "updated content"})
+	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, rr.Code)
+	var respBody response.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "precondition_required", respBody.Code)
+	mockUsecase.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_Update_VersionConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	postID := int64(1)
+	userID := int64(10)
+	userRole := "user"
+	router.PUT("/posts/:id", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		c.Set(ContextRoleKey, userRole)
+		handler.Update(c)
+	})
+
+	reqBody := requests.UpdateRequestPost{Content: "updated content"}
+	current := &entity.Post{ID: postID, Content: "edited elsewhere", Version: 4}
+	usecaseError := usecase.ErrVersionConflict.WithDetails(map[string]any{"current": current})
+	mockUsecase.On("Update", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Content, int64(2)).Return(int64(0), usecaseError).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPut, "/posts/"+strconv.FormatInt(postID, 10), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+	var respBody struct {
+		Code    string `json:"code"`
+		Details struct {
+			Current entity.Post `json:"current"`
+		} `json:"details"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "version_conflict", respBody.Code)
+	assert.Equal(t, int64(4), respBody.Details.Current.Version)
+	assert.Equal(t, "edited elsewhere", respBody.Details.Current.Content)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetByID_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	postID := int64(1)
+	router.GET("/posts/:id", handler.GetByID)
+
+	expectedPost := &entity.Post{ID: postID, TopicID: 2, Content: "content", Version: 3}
+	mockUsecase.On("GetByID", mock.Anything, postID).Return(expectedPost, false, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/posts/"+strconv.FormatInt(postID, 10), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+	var respBody response.PostResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPost.Content, respBody.Post.Content)
+	assert.False(t, respBody.Degraded)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetByID_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	postID := int64(1)
+	router.GET("/posts/:id", handler.GetByID)
+
+	mockUsecase.On("GetByID", mock.Anything, postID).Return(nil, false, usecase.ErrPostNotFound).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/posts/"+strconv.FormatInt(postID, 10), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestPostHandler_Delete_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1039,7 +1406,7 @@ func TestPostHandler_Delete_Success(t *testing.T) {
 		handler.Delete(c)
 	})
 
-	mockUsecase.On("Delete", mock.Anything, postID, userID, userRole).Return(nil).Once()
+	mockUsecase.On("Delete", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}).Return(nil).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/posts/"+strconv.FormatInt(postID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1055,7 +1422,7 @@ func TestPostHandler_Delete_Success(t *testing.T) {
 
 func TestPostHandler_Delete_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1073,7 +1440,7 @@ func TestPostHandler_Delete_NoUserIDInContext(t *testing.T) {
 
 func TestPostHandler_Delete_InvalidPostID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1100,7 +1467,7 @@ func TestPostHandler_Delete_InvalidPostID(t *testing.T) {
 
 func TestPostHandler_Delete_Forbidden(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1115,7 +1482,7 @@ func TestPostHandler_Delete_Forbidden(t *testing.T) {
 	})
 
 	usecaseError := usecase.ErrForbidden
-	mockUsecase.On("Delete", mock.Anything, postID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/posts/"+strconv.FormatInt(postID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1131,7 +1498,7 @@ func TestPostHandler_Delete_Forbidden(t *testing.T) {
 
 func TestPostHandler_Delete_PostNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1146,7 +1513,7 @@ func TestPostHandler_Delete_PostNotFound(t *testing.T) {
 	})
 
 	usecaseError := usecase.ErrPostNotFound
-	mockUsecase.On("Delete", mock.Anything, postID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/posts/"+strconv.FormatInt(postID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1162,7 +1529,7 @@ func TestPostHandler_Delete_PostNotFound(t *testing.T) {
 
 func TestPostHandler_Delete_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewPostUsecase(t)
 	handler := &PostHandler{
 		Usecase: mockUsecase,
@@ -1177,7 +1544,7 @@ func TestPostHandler_Delete_UsecaseError(t *testing.T) {
 	})
 
 	usecaseError := errors.New("some other delete error")
-	mockUsecase.On("Delete", mock.Anything, postID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, postID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/posts/"+strconv.FormatInt(postID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1193,7 +1560,7 @@ func TestPostHandler_Delete_UsecaseError(t *testing.T) {
 
 func TestTopicHandler_Create_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1232,7 +1599,7 @@ func TestTopicHandler_Create_Success(t *testing.T) {
 
 func TestTopicHandler_Create_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1256,7 +1623,7 @@ func TestTopicHandler_Create_NoUserIDInContext(t *testing.T) {
 
 func TestTopicHandler_Create_InvalidCategoryID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1288,7 +1655,7 @@ func TestTopicHandler_Create_InvalidCategoryID(t *testing.T) {
 
 func TestTopicHandler_Create_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1315,7 +1682,7 @@ func TestTopicHandler_Create_InvalidJSON(t *testing.T) {
 
 func TestTopicHandler_Create_CategoryNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1343,17 +1710,18 @@ func TestTopicHandler_Create_CategoryNotFound(t *testing.T) {
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
 	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "category_not_found", respBody["code"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_Create_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1385,13 +1753,51 @@ func TestTopicHandler_Create_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTopicHandler_Create_UserServiceUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewTopicUsecase(t)
+	logger := zerolog.Nop()
+	handler := &TopicHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	categoryID := int64(1)
+	userID := int64(10)
+	router.POST("/categories/:id/topics", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		c.Set(ContextRoleKey, "user")
+		handler.Create(c)
+	})
+
+	reqBody := entity.Topic{Title: "new topic"}
+	usecaseError := fmt.Errorf("ForumService - TopicUsecase - Create: %w", usecase.ErrUserServiceUnavailable)
+
+	expectedEntityTopic := entity.Topic{CategoryID: categoryID, AuthorID: &userID, Title: reqBody.Title}
+	mockUsecase.On("Create", mock.Anything, expectedEntityTopic).Return(int64(0), usecaseError).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest(http.MethodPost, "/categories/"+strconv.FormatInt(categoryID, 10)+"/topics", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var respBody map[string]string
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.ErrUserServiceUnavailable.Error(), respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_GetByID_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1401,8 +1807,8 @@ func TestTopicHandler_GetByID_Success(t *testing.T) {
 	topicID := int64(1)
 	router.GET("/topics/:id", handler.GetByID)
 
-	expectedTopic := &entity.Topic{ID: topicID, Title: "Test Topic", Username: "Author"}
-	mockUsecase.On("GetByID", mock.Anything, topicID).Return(expectedTopic, nil).Once()
+	expectedTopic := &entity.Topic{ID: topicID, Title: "Test Topic", Author: &entity.Author{ID: 1, Username: "Author"}}
+	mockUsecase.On("GetByID", mock.Anything, topicID).Return(expectedTopic, false, nil).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1416,9 +1822,34 @@ func TestTopicHandler_GetByID_Success(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestTopicHandler_GetByID_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewTopicUsecase(t)
+	logger := zerolog.Nop()
+	handler := &TopicHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	topicID := int64(1)
+	router.GET("/topics/:id", handler.GetByID)
+
+	topic := &entity.Topic{ID: topicID, Title: "Test Topic", Version: 2, UpdatedAt: time.Now(), Author: &entity.Author{ID: 1, Username: "Author"}}
+	mockUsecase.On("GetByID", mock.Anything, topicID).Return(topic, false, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10), nil)
+	req.Header.Set("If-None-Match", middleware.ContentETag(2, gin.H{"topic": topic}))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestTopicHandler_GetByID_InvalidTopicID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1437,7 +1868,7 @@ func TestTopicHandler_GetByID_InvalidTopicID(t *testing.T) {
 
 func TestTopicHandler_GetByID_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1448,7 +1879,7 @@ func TestTopicHandler_GetByID_UsecaseError(t *testing.T) {
 	router.GET("/topics/:id", handler.GetByID)
 
 	usecaseError := errors.New("usecase get by id error")
-	mockUsecase.On("GetByID", mock.Anything, topicID).Return(nil, usecaseError).Once()
+	mockUsecase.On("GetByID", mock.Anything, topicID).Return(nil, false, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1458,13 +1889,13 @@ func TestTopicHandler_GetByID_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, "failed to get topic", respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_GetByCategory_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1475,10 +1906,10 @@ func TestTopicHandler_GetByCategory_Success(t *testing.T) {
 	router.GET("/categories/:id/topics", handler.GetByCategory)
 
 	expectedTopics := []entity.Topic{
-		{ID: 1, CategoryID: categoryID, Title: "Topic 1", Username: "User1"},
-		{ID: 2, CategoryID: categoryID, Title: "Topic 2", Username: "User2"},
+		{ID: 1, CategoryID: categoryID, Title: "Topic 1", Author: &entity.Author{ID: 1, Username: "User1"}},
+		{ID: 2, CategoryID: categoryID, Title: "Topic 2", Author: &entity.Author{ID: 2, Username: "User2"}},
 	}
-	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(expectedTopics, nil).Once()
+	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(expectedTopics, false, nil).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories/"+strconv.FormatInt(categoryID, 10)+"/topics", nil)
 	rr := httptest.NewRecorder()
@@ -1495,7 +1926,7 @@ func TestTopicHandler_GetByCategory_Success(t *testing.T) {
 
 func TestTopicHandler_GetByCategory_InvalidCategoryID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1514,7 +1945,7 @@ func TestTopicHandler_GetByCategory_InvalidCategoryID(t *testing.T) {
 
 func TestTopicHandler_GetByCategory_CategoryNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1525,7 +1956,7 @@ func TestTopicHandler_GetByCategory_CategoryNotFound(t *testing.T) {
 	router.GET("/categories/:id/topics", handler.GetByCategory)
 
 	usecaseError := usecase.ErrCategoryNotFound
-	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(nil, usecaseError).Once()
+	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(nil, false, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories/"+strconv.FormatInt(categoryID, 10)+"/topics", nil)
 	rr := httptest.NewRecorder()
@@ -1541,7 +1972,7 @@ func TestTopicHandler_GetByCategory_CategoryNotFound(t *testing.T) {
 
 func TestTopicHandler_GetByCategory_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1552,7 +1983,7 @@ func TestTopicHandler_GetByCategory_UsecaseError(t *testing.T) {
 	router.GET("/categories/:id/topics", handler.GetByCategory)
 
 	usecaseError := errors.New("some other get by category error")
-	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(nil, usecaseError).Once()
+	mockUsecase.On("GetByCategory", mock.Anything, categoryID).Return(nil, false, usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodGet, "/categories/"+strconv.FormatInt(categoryID, 10)+"/topics", nil)
 	rr := httptest.NewRecorder()
@@ -1562,13 +1993,13 @@ func TestTopicHandler_GetByCategory_UsecaseError(t *testing.T) {
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, usecaseError.Error(), respBody["error"])
+	assert.Equal(t, "internal server error", respBody["error"])
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_Update_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1586,16 +2017,18 @@ func TestTopicHandler_Update_Success(t *testing.T) {
 	})
 
 	reqBody := requests.UpdateRequestTopic{Title: "Updated Topic Title"}
-	mockUsecase.On("Update", mock.Anything, topicID, userID, userRole, reqBody.Title).Return(nil).Once()
+	mockUsecase.On("Update", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Title, int64(2)).Return(int64(3), nil).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
 	var respBody map[string]string
 	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
@@ -1605,7 +2038,7 @@ func TestTopicHandler_Update_Success(t *testing.T) {
 
 func TestTopicHandler_Update_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1619,6 +2052,7 @@ func TestTopicHandler_Update_NoUserIDInContext(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1629,7 +2063,7 @@ func TestTopicHandler_Update_NoUserIDInContext(t *testing.T) {
 
 func TestTopicHandler_Update_InvalidTopicID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1648,6 +2082,7 @@ func TestTopicHandler_Update_InvalidTopicID(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/invalid", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1658,7 +2093,7 @@ func TestTopicHandler_Update_InvalidTopicID(t *testing.T) {
 
 func TestTopicHandler_Update_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1676,6 +2111,7 @@ func TestTopicHandler_Update_InvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBufferString("{invalid_json"))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1686,7 +2122,7 @@ func TestTopicHandler_Update_InvalidJSON(t *testing.T) {
 
 func TestTopicHandler_Update_Forbidden(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1704,11 +2140,12 @@ func TestTopicHandler_Update_Forbidden(t *testing.T) {
 
 	reqBody := requests.UpdateRequestTopic{Title: "Updated Topic Title"}
 	usecaseError := usecase.ErrForbidden
-	mockUsecase.On("Update", mock.Anything, topicID, userID, userRole, reqBody.Title).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Title, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1723,7 +2160,7 @@ func TestTopicHandler_Update_Forbidden(t *testing.T) {
 
 func TestTopicHandler_Update_TopicOrPostNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1741,24 +2178,25 @@ func TestTopicHandler_Update_TopicOrPostNotFound(t *testing.T) {
 
 	reqBody := requests.UpdateRequestTopic{Title: "Updated Topic Title"}
 	usecaseError := usecase.ErrTopicNotFound
-	mockUsecase.On("Update", mock.Anything, topicID, userID, userRole, reqBody.Title).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Title, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Contains(t, rr.Body.String(), "internal server error")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "topic not found")
 
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_Update_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1776,11 +2214,12 @@ func TestTopicHandler_Update_UsecaseError(t *testing.T) {
 
 	reqBody := requests.UpdateRequestTopic{Title: "Updated Topic Title"}
 	usecaseError := errors.New("some other update error")
-	mockUsecase.On("Update", mock.Anything, topicID, userID, userRole, reqBody.Title).Return(usecaseError).Once()
+	mockUsecase.On("Update", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}, reqBody.Title, int64(2)).Return(int64(0), usecaseError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPut, "/topics/"+strconv.FormatInt(topicID, 10), bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -1791,7 +2230,7 @@ func TestTopicHandler_Update_UsecaseError(t *testing.T) {
 
 func TestTopicHandler_Delete_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1808,7 +2247,7 @@ func TestTopicHandler_Delete_Success(t *testing.T) {
 		handler.Delete(c)
 	})
 
-	mockUsecase.On("Delete", mock.Anything, topicID, userID, userRole).Return(nil).Once()
+	mockUsecase.On("Delete", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}).Return(nil).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1824,7 +2263,7 @@ func TestTopicHandler_Delete_Success(t *testing.T) {
 
 func TestTopicHandler_Delete_NoUserIDInContext(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1844,7 +2283,7 @@ func TestTopicHandler_Delete_NoUserIDInContext(t *testing.T) {
 
 func TestTopicHandler_Delete_InvalidTopicID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1869,7 +2308,7 @@ func TestTopicHandler_Delete_InvalidTopicID(t *testing.T) {
 
 func TestTopicHandler_Delete_Forbidden(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1886,7 +2325,7 @@ func TestTopicHandler_Delete_Forbidden(t *testing.T) {
 	})
 
 	usecaseError := usecase.ErrForbidden
-	mockUsecase.On("Delete", mock.Anything, topicID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1898,7 +2337,7 @@ func TestTopicHandler_Delete_Forbidden(t *testing.T) {
 
 func TestTopicHandler_Delete_TopicOrPostNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1915,21 +2354,21 @@ func TestTopicHandler_Delete_TopicOrPostNotFound(t *testing.T) {
 	})
 
 	usecaseError := usecase.ErrTopicNotFound
-	mockUsecase.On("Delete", mock.Anything, topicID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Contains(t, rr.Body.String(), "internal server error")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "topic not found")
 
 	mockUsecase.AssertExpectations(t)
 }
 
 func TestTopicHandler_Delete_UsecaseError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	router := newTestRouter()
 	mockUsecase := mocks.NewTopicUsecase(t)
 	logger := zerolog.Nop()
 	handler := &TopicHandler{
@@ -1946,7 +2385,7 @@ func TestTopicHandler_Delete_UsecaseError(t *testing.T) {
 	})
 
 	usecaseError := errors.New("some other delete error")
-	mockUsecase.On("Delete", mock.Anything, topicID, userID, userRole).Return(usecaseError).Once()
+	mockUsecase.On("Delete", mock.Anything, topicID, entity.Actor{UserID: userID, Role: userRole}).Return(usecaseError).Once()
 
 	req, _ := http.NewRequest(http.MethodDelete, "/topics/"+strconv.FormatInt(topicID, 10), nil)
 	rr := httptest.NewRecorder()
@@ -1955,3 +2394,119 @@ func TestTopicHandler_Delete_UsecaseError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestTopicHandler_Lock_ModeratorFromToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewTopicUsecase(t)
+	logger := zerolog.Nop()
+	handler := &TopicHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	j := jwt.New("secret", time.Minute, time.Hour)
+	topicID := int64(1)
+	userID := int64(10)
+
+	router.POST("/topics/:id/lock", middleware.NewAuthMiddleware(j, nil).Auth(), handler.Lock)
+
+	actor := entity.Actor{UserID: userID, Role: "user", Permissions: []string{}, ModeratedCategories: []int64{3}}
+	mockUsecase.On("SetLocked", mock.Anything, topicID, actor, true).Return(nil).Once()
+
+	token, err := j.GenerateAccessToken(userID, "user", []string{}, []int64{3})
+	assert.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodPost, "/topics/"+strconv.FormatInt(topicID, 10)+"/lock", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "topic locked")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTopicHandler_Unlock_Forbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewTopicUsecase(t)
+	logger := zerolog.Nop()
+	handler := &TopicHandler{
+		Usecase: mockUsecase,
+		Log:     &logger,
+	}
+	topicID := int64(1)
+	userID := int64(10)
+
+	router.POST("/topics/:id/unlock", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		c.Set(ContextRoleKey, "user")
+		handler.Unlock(c)
+	})
+
+	mockUsecase.On("SetLocked", mock.Anything, topicID, entity.Actor{UserID: userID, Role: "user"}, false).Return(usecase.ErrForbidden).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/topics/"+strconv.FormatInt(topicID, 10)+"/unlock", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_Create_TopicLocked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPostUsecase(t)
+	handler := &PostHandler{
+		Usecase: mockUsecase,
+	}
+	userID := int64(10)
+
+	router.POST("/topics/:id/posts", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, userID)
+		handler.Create(c)
+	})
+
+	mockUsecase.On("Create", mock.Anything, mock.AnythingOfType("entity.Post")).Return(int64(0), usecase.ErrTopicLocked).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/topics/1/posts", strings.NewReader(`{"content":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "topic is locked")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name        string
+		permissions []string
+		wantStatus  int
+	}{
+		{name: "granted", permissions: []string{entity.PermCategoryManage}, wantStatus: http.StatusOK},
+		{name: "missing", permissions: []string{entity.PermTopicLock}, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.POST("/categories", func(c *gin.Context) {
+				c.Set(ContextUserIDKey, int64(1))
+				c.Set(middleware.ContextPermissionsKey, tc.permissions)
+				c.Next()
+			}, middleware.RequirePermission(entity.PermCategoryManage), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "/categories", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantStatus, rr.Code)
+		})
+	}
+}
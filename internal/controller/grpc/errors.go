@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain identifies the user service in ErrorInfo details.
+const errorDomain = "user.forum-go"
+
+// Reasons sent in ErrorInfo details, so clients need not parse messages.
+const (
+	ReasonUserNotFound   = "USER_NOT_FOUND"
+	ReasonInvalidUserIDs = "INVALID_USER_IDS"
+	ReasonDBUnavailable  = "DB_UNAVAILABLE"
+	ReasonDBTimeout      = "DB_TIMEOUT"
+)
+
+// unavailableRetryDelay is suggested to clients in RetryInfo when the database is unreachable.
+const unavailableRetryDelay = time.Second
+
+// toStatus turns a usecase error into a gRPC status. Internal details stay in the server log; the client
+// gets a code it can branch on and, where it helps, ErrorInfo, BadRequest or RetryInfo details.
+func toStatus(err error, userIDs ...int64) error {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound), errors.Is(err, pgx.ErrNoRows):
+		return withDetails(codes.NotFound, "user not found", errorInfo(ReasonUserNotFound, userIDs))
+
+	case errors.Is(err, usecase.ErrInvalidUserIDs):
+		return withDetails(codes.InvalidArgument, "invalid user ids",
+			errorInfo(ReasonInvalidUserIDs, nil),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "user_ids", Description: err.Error()},
+			}})
+
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return withDetails(codes.DeadlineExceeded, "database did not answer in time", errorInfo(ReasonDBTimeout, nil))
+
+	case isDBUnavailable(err):
+		return withDetails(codes.Unavailable, "database unavailable",
+			errorInfo(ReasonDBUnavailable, nil),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(unavailableRetryDelay)})
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// isDBUnavailable reports whether err means Postgres could not be reached or is refusing work for now.
+func isDBUnavailable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection exceptions, 53 insufficient resources and 57P0x operator intervention.
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") || strings.HasPrefix(pgErr.Code, "57P0")
+	}
+
+	return strings.Contains(err.Error(), "closed pool")
+}
+
+func errorInfo(reason string, userIDs []int64) *errdetails.ErrorInfo {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+	if len(userIDs) == 1 {
+		info.Metadata = map[string]string{"user_id": strconv.FormatInt(userIDs[0], 10)}
+	}
+	return info
+}
+
+func withDetails(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus_Codes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", fmt.Errorf("wrapped: %w", usecase.ErrUserNotFound), codes.NotFound},
+		{"invalid ids", fmt.Errorf("%w: want 1 to 1000 ids, got 0", usecase.ErrInvalidUserIDs), codes.InvalidArgument},
+		{"canceled", context.Canceled, codes.Canceled},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"connect failure", &pgconn.ConnectError{}, codes.Unavailable},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, codes.Unavailable},
+		{"constraint violation", &pgconn.PgError{Code: "23505"}, codes.Internal},
+		{"anything else", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(toStatus(tt.err)))
+		})
+	}
+}
+
+func TestToStatus_NotFoundDetails(t *testing.T) {
+	st := status.Convert(toStatus(usecase.ErrUserNotFound, 42))
+
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, ReasonUserNotFound, info.GetReason())
+	assert.Equal(t, "42", info.GetMetadata()["user_id"])
+}
+
+func TestToStatus_InvalidArgumentDetails(t *testing.T) {
+	st := status.Convert(toStatus(fmt.Errorf("%w: id -1 is not positive", usecase.ErrInvalidUserIDs)))
+
+	require.Len(t, st.Details(), 2)
+	violations, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "user_ids", violations.GetFieldViolations()[0].GetField())
+	assert.Contains(t, violations.GetFieldViolations()[0].GetDescription(), "id -1 is not positive")
+}
+
+func TestToStatus_UnavailableSuggestsRetry(t *testing.T) {
+	st := status.Convert(toStatus(&pgconn.ConnectError{}))
+
+	require.Len(t, st.Details(), 2)
+	retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, unavailableRetryDelay, retry.GetRetryDelay().AsDuration())
+}
@@ -0,0 +1,272 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Van-programan/Forum_GO/internal/client"
+	"github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	forumpb "github.com/Van-programan/Forum_GO/pkg/proto/forum"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+type forumServerAPI struct {
+	forumpb.UnimplementedForumServiceServer
+	categories usecase.CategoryUsecase
+	topics     usecase.TopicUsecase
+	posts      usecase.PostUsecase
+	log        *zerolog.Logger
+}
+
+// RegisterForum serves the forum's internal API from the same usecases as the REST API.
+func RegisterForum(grpcServer *grpc.Server, categories usecase.CategoryUsecase, topics usecase.TopicUsecase, posts usecase.PostUsecase, log *zerolog.Logger) {
+	forumpb.RegisterForumServiceServer(grpcServer, &forumServerAPI{categories: categories, topics: topics, posts: posts, log: log})
+}
+
+func (s *forumServerAPI) ListCategories(ctx context.Context, _ *forumpb.ListCategoriesRequest) (*forumpb.ListCategoriesResponse, error) {
+	categories, err := s.categories.GetAll(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.ListCategories").Msg("failed to get categories")
+		return nil, forumStatus(err)
+	}
+
+	res := &forumpb.ListCategoriesResponse{Categories: make([]*forumpb.Category, 0, len(categories))}
+	for _, category := range categories {
+		res.Categories = append(res.Categories, toProtoCategory(category))
+	}
+	return res, nil
+}
+
+func (s *forumServerAPI) GetCategory(ctx context.Context, req *forumpb.GetCategoryRequest) (*forumpb.Category, error) {
+	category, err := s.categories.GetByID(ctx, req.CategoryId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.GetCategory").Int64("category_id", req.CategoryId).Msg("failed to get category")
+		return nil, forumStatus(err)
+	}
+	return toProtoCategory(*category), nil
+}
+
+func (s *forumServerAPI) ListTopics(ctx context.Context, req *forumpb.ListTopicsRequest) (*forumpb.ListTopicsResponse, error) {
+	offset, size, err := parsePage(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, degraded, err := s.topics.GetByCategory(ctx, req.CategoryId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.ListTopics").Int64("category_id", req.CategoryId).Msg("failed to get topics")
+		return nil, forumStatus(err)
+	}
+
+	page, next := paginate(topics, offset, size)
+	res := &forumpb.ListTopicsResponse{Topics: make([]*forumpb.Topic, 0, len(page)), NextPageToken: next, Degraded: degraded}
+	for _, topic := range page {
+		res.Topics = append(res.Topics, toProtoTopic(topic))
+	}
+	return res, nil
+}
+
+func (s *forumServerAPI) GetTopic(ctx context.Context, req *forumpb.GetTopicRequest) (*forumpb.GetTopicResponse, error) {
+	topic, degraded, err := s.topics.GetByID(ctx, req.TopicId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.GetTopic").Int64("topic_id", req.TopicId).Msg("failed to get topic")
+		return nil, forumStatus(err)
+	}
+	return &forumpb.GetTopicResponse{Topic: toProtoTopic(*topic), Degraded: degraded}, nil
+}
+
+func (s *forumServerAPI) ListPosts(ctx context.Context, req *forumpb.ListPostsRequest) (*forumpb.ListPostsResponse, error) {
+	offset, size, err := parsePage(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, degraded, err := s.posts.GetByTopic(ctx, req.TopicId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.ListPosts").Int64("topic_id", req.TopicId).Msg("failed to get posts")
+		return nil, forumStatus(err)
+	}
+
+	page, next := paginate(posts, offset, size)
+	res := &forumpb.ListPostsResponse{Posts: make([]*forumpb.Post, 0, len(page)), NextPageToken: next, Degraded: degraded}
+	for _, post := range page {
+		res.Posts = append(res.Posts, toProtoPost(post))
+	}
+	return res, nil
+}
+
+// CreatePost posts on behalf of req.AuthorId. The server only runs with mTLS and an identity allowlist,
+// so the caller is one of the allowlisted services, which are trusted to have authenticated that user.
+func (s *forumServerAPI) CreatePost(ctx context.Context, req *forumpb.CreatePostRequest) (*forumpb.CreatePostResponse, error) {
+	if req.AuthorId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "author_id is required")
+	}
+	if req.TopicId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "topic_id is required")
+	}
+
+	// The content and reply get the same checks as posts sent to the REST API.
+	body := request.CreateRequestPost{Content: req.Content}
+	if req.ReplyTo != 0 {
+		body.ReplyTo = &req.ReplyTo
+	}
+	body.Normalize()
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	post := entity.Post{TopicID: req.TopicId, AuthorID: &req.AuthorId, Content: body.Content, ReplyTo: body.ReplyTo}
+
+	id, err := s.posts.Create(ctx, post)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "ForumServer.CreatePost").Int64("topic_id", req.TopicId).Int64("author_id", req.AuthorId).Msg("failed to create post")
+		return nil, forumStatus(err)
+	}
+	return &forumpb.CreatePostResponse{PostId: id}, nil
+}
+
+// invalidArgument reports the fields of a request DTO that break their validation tags.
+func invalidArgument(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rules := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		rules = append(rules, strings.ToLower(fe.Field())+": "+rule)
+	}
+	return status.Error(codes.InvalidArgument, "invalid "+strings.Join(rules, ", "))
+}
+
+// forumStatus turns a forum usecase error into a gRPC status, keeping internal details in the server log.
+func forumStatus(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		return status.Error(codes.NotFound, "category not found")
+	case errors.Is(err, usecase.ErrTopicNotFound):
+		return status.Error(codes.NotFound, "topic not found")
+	case errors.Is(err, usecase.ErrPostNotFound):
+		return status.Error(codes.NotFound, "post not found")
+	case errors.Is(err, client.ErrUserNotFound):
+		return status.Error(codes.NotFound, "author not found")
+	case errors.Is(err, usecase.ErrTopicLocked):
+		return status.Error(codes.FailedPrecondition, "topic is locked")
+	case errors.Is(err, usecase.ErrUserBanned):
+		return status.Error(codes.PermissionDenied, usecase.ErrUserBanned.Error())
+	case errors.Is(err, usecase.ErrUserServiceUnavailable):
+		return status.Error(codes.Unavailable, usecase.ErrUserServiceUnavailable.Error())
+	case errors.Is(err, usecase.ErrAuthorNotReplicated):
+		return status.Error(codes.Unavailable, usecase.ErrAuthorNotReplicated.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request timed out")
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+// parsePage reads a page request. Page tokens are opaque to callers; inside they are the offset of the page.
+func parsePage(pageSize int32, pageToken string) (offset, size int, err error) {
+	switch {
+	case pageSize < 0:
+		return 0, 0, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case pageSize == 0:
+		size = defaultPageSize
+	default:
+		size = min(int(pageSize), maxPageSize)
+	}
+
+	if pageToken == "" {
+		return 0, size, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err == nil {
+		offset, err = strconv.Atoi(string(raw))
+	}
+	if err != nil || offset < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return offset, size, nil
+}
+
+// paginate returns the page of items starting at offset and the token of the next page, empty on the last one.
+func paginate[T any](items []T, offset, size int) ([]T, string) {
+	if offset >= len(items) {
+		return nil, ""
+	}
+	end := min(offset+size, len(items))
+	if end == len(items) {
+		return items[offset:end], ""
+	}
+	return items[offset:end], base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+}
+
+func toProtoCategory(c entity.Category) *forumpb.Category {
+	return &forumpb.Category{
+		Id:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		CreatedAt:   c.CreatedAt.Unix(),
+		UpdatedAt:   c.UpdatedAt.Unix(),
+	}
+}
+
+func toProtoAuthor(a *entity.Author) *forumpb.Author {
+	if a == nil {
+		return nil
+	}
+	return &forumpb.Author{
+		Id:          a.ID,
+		Username:    a.Username,
+		DisplayName: a.DisplayName,
+		AvatarUrl:   a.AvatarURL,
+		Role:        a.Role,
+	}
+}
+
+func toProtoTopic(t entity.Topic) *forumpb.Topic {
+	return &forumpb.Topic{
+		Id:         t.ID,
+		CategoryId: t.CategoryID,
+		Title:      t.Title,
+		Author:     toProtoAuthor(t.Author),
+		Locked:     t.Locked,
+		CreatedAt:  t.CreatedAt.Unix(),
+		UpdatedAt:  t.UpdatedAt.Unix(),
+	}
+}
+
+func toProtoPost(p entity.Post) *forumpb.Post {
+	post := &forumpb.Post{
+		Id:        p.ID,
+		TopicId:   p.TopicID,
+		Author:    toProtoAuthor(p.Author),
+		Content:   p.Content,
+		CreatedAt: p.CreatedAt.Unix(),
+		UpdatedAt: p.UpdatedAt.Unix(),
+	}
+	if p.ReplyTo != nil {
+		post.ReplyTo = *p.ReplyTo
+	}
+	return post
+}
@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum/usecase"
+	forumpb "github.com/Van-programan/Forum_GO/pkg/proto/forum"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestForumServer(t *testing.T) (*forumServerAPI, *mocks.TopicUsecase, *mocks.PostUsecase) {
+	logger := zerolog.Nop()
+	topics := mocks.NewTopicUsecase(t)
+	posts := mocks.NewPostUsecase(t)
+	return &forumServerAPI{categories: mocks.NewCategoryUsecase(t), topics: topics, posts: posts, log: &logger}, topics, posts
+}
+
+func TestForumServer_ListPostsPages(t *testing.T) {
+	ctx := context.Background()
+	s, _, posts := newTestForumServer(t)
+	all := []entity.Post{{ID: 1}, {ID: 2}, {ID: 3}}
+	posts.On("GetByTopic", ctx, int64(7)).Return(all, true, nil)
+
+	first, err := s.ListPosts(ctx, &forumpb.ListPostsRequest{TopicId: 7, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Posts, 2)
+	assert.Equal(t, int64(2), first.Posts[1].Id)
+	assert.True(t, first.Degraded)
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := s.ListPosts(ctx, &forumpb.ListPostsRequest{TopicId: 7, PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Posts, 1)
+	assert.Equal(t, int64(3), second.Posts[0].Id)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestForumServer_ListTopicsCapsPageSize(t *testing.T) {
+	ctx := context.Background()
+	s, topics, _ := newTestForumServer(t)
+	all := make([]entity.Topic, maxPageSize+1)
+	topics.On("GetByCategory", ctx, int64(9)).Return(all, false, nil).Once()
+
+	res, err := s.ListTopics(ctx, &forumpb.ListTopicsRequest{CategoryId: 9, PageSize: 1000})
+
+	require.NoError(t, err)
+	assert.Len(t, res.Topics, maxPageSize)
+	assert.NotEmpty(t, res.NextPageToken)
+}
+
+func TestForumServer_ListPostsRejectsBadPage(t *testing.T) {
+	s, _, _ := newTestForumServer(t)
+
+	_, err := s.ListPosts(context.Background(), &forumpb.ListPostsRequest{TopicId: 7, PageToken: "not a token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ListPosts(context.Background(), &forumpb.ListPostsRequest{TopicId: 7, PageSize: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestForumServer_ListTopicsNotFound(t *testing.T) {
+	ctx := context.Background()
+	s, topics, _ := newTestForumServer(t)
+	topics.On("GetByCategory", ctx, int64(9)).Return(nil, false, usecase.ErrCategoryNotFound)
+
+	_, err := s.ListTopics(ctx, &forumpb.ListTopicsRequest{CategoryId: 9})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestForumServer_CreatePost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		s, _, posts := newTestForumServer(t)
+		posts.On("Create", ctx, mock.MatchedBy(func(p entity.Post) bool {
+			return p.TopicID == 7 && *p.AuthorID == 3 && p.Content == "hello" && *p.ReplyTo == 5
+		})).Return(int64(11), nil).Once()
+
+		res, err := s.CreatePost(ctx, &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: "hello", ReplyTo: 5})
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), res.PostId)
+	})
+
+	t.Run("Trims content", func(t *testing.T) {
+		s, _, posts := newTestForumServer(t)
+		posts.On("Create", ctx, mock.MatchedBy(func(p entity.Post) bool {
+			return p.Content == "hello" && p.ReplyTo == nil
+		})).Return(int64(12), nil).Once()
+
+		_, err := s.CreatePost(ctx, &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: "  hello\n"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Missing author", func(t *testing.T) {
+		s, _, _ := newTestForumServer(t)
+		_, err := s.CreatePost(ctx, &forumpb.CreatePostRequest{TopicId: 7, Content: "hello"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	invalidTests := []struct {
+		name string
+		req  *forumpb.CreatePostRequest
+	}{
+		{"missing topic", &forumpb.CreatePostRequest{AuthorId: 3, Content: "hello"}},
+		{"negative topic", &forumpb.CreatePostRequest{TopicId: -7, AuthorId: 3, Content: "hello"}},
+		{"blank content", &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: " \t\n"}},
+		{"content too long", &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: strings.Repeat("a", 20001)}},
+		{"negative reply", &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: "hello", ReplyTo: -5}},
+	}
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestForumServer(t)
+			_, err := s.CreatePost(ctx, tt.req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	errTests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"locked", fmt.Errorf("ForumService - PostUsecase - Create: %w", usecase.ErrTopicLocked), codes.FailedPrecondition},
+		{"banned", fmt.Errorf("ForumService - PostUsecase - Create: %w", usecase.ErrUserBanned), codes.PermissionDenied},
+		{"user service down", fmt.Errorf("ForumService - PostUsecase - Create: %w", usecase.ErrUserServiceUnavailable), codes.Unavailable},
+		{"author not replicated", fmt.Errorf("ForumService - PostUsecase - Create - postRepo.Create(): %w", usecase.ErrAuthorNotReplicated), codes.Unavailable},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, posts := newTestForumServer(t)
+			posts.On("Create", ctx, mock.Anything).Return(int64(0), tt.err).Once()
+
+			_, err := s.CreatePost(ctx, &forumpb.CreatePostRequest{TopicId: 7, AuthorId: 3, Content: "hello"})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger checks that a dependency is reachable. The Postgres pool implements it.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// RegisterHealth registers grpc.health.v1 and keeps both the overall status and the status of each named
+// service in line with db, pinging it every interval until ctx is done. Then it reports NOT_SERVING.
+func RegisterHealth(ctx context.Context, grpcServer *grpc.Server, db Pinger, interval time.Duration, log *zerolog.Logger, services ...string) {
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, hs)
+
+	check := func() {
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		defer cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err := db.Ping(pingCtx); err != nil {
+			log.Warn().Err(err).Str("op", "GRPCServer.health").Msg("database ping failed")
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		hs.SetServingStatus("", status)
+		for _, service := range services {
+			hs.SetServingStatus(service, status)
+		}
+	}
+
+	check()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				hs.Shutdown()
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
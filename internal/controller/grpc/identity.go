@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Van-programan/Forum_GO/pkg/mtls"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// identityCheck lets through only callers whose client certificate names one of the allowed identities.
+// Health checks are exempt so probes only need a certificate from the CA.
+type identityCheck struct {
+	allowed []string
+	log     *zerolog.Logger
+}
+
+func (i *identityCheck) authorize(ctx context.Context, method string) error {
+	if method == healthpb.Health_Check_FullMethodName || method == healthpb.Health_Watch_FullMethodName {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	ids := mtls.Identities(tlsInfo.State.VerifiedChains[0][0])
+	for _, id := range ids {
+		if slices.Contains(i.allowed, id) {
+			return nil
+		}
+	}
+
+	i.log.Warn().Str("op", "GRPCServer.identity").Str("method", method).Strs("identities", ids).Msg("caller not allowed")
+	return status.Error(codes.PermissionDenied, "caller identity not allowed")
+}
+
+func (i *identityCheck) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := i.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *identityCheck) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := i.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,211 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/mtls"
+	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	forumIdentity    = "spiffe://forum-go/forum-service"
+	intruderIdentity = "spiffe://forum-go/intruder"
+)
+
+// testCA signs certificates for one test and writes them, with its own certificate, to a temp dir.
+type testCA struct {
+	t      *testing.T
+	dir    string
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "forum-go test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{t: t, dir: t.TempDir(), cert: cert, key: key, serial: 1}
+	writePEM(t, filepath.Join(ca.dir, "ca.crt"), "CERTIFICATE", der)
+	return ca
+}
+
+// issue writes name.crt and name.key for a leaf certificate with the given DNS and URI SANs.
+func (ca *testCA) issue(name string, dnsNames []string, uris ...string) mtls.Config {
+	t := ca.t
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ca.serial++
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+	}
+	for _, raw := range uris {
+		uri, err := url.Parse(raw)
+		require.NoError(t, err)
+		tmpl.URIs = append(tmpl.URIs, uri)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cfg := mtls.Config{
+		CertFile: filepath.Join(ca.dir, name+".crt"),
+		KeyFile:  filepath.Join(ca.dir, name+".key"),
+		CAFile:   filepath.Join(ca.dir, "ca.crt"),
+	}
+	writePEM(t, cfg.CertFile, "CERTIFICATE", der)
+	writePEM(t, cfg.KeyFile, "EC PRIVATE KEY", keyDER)
+	return cfg
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+// usernameOnly answers GetUsernameById; the rest of the usecase is not needed here.
+type usernameOnly struct {
+	usecase.UserUsecase
+}
+
+func (usernameOnly) GetUsernameById(ctx context.Context, id int64) (string, error) {
+	return "alice", nil
+}
+
+func startMTLSServer(t *testing.T, ca *testCA) string {
+	logger := zerolog.Nop()
+	reloader, err := mtls.NewReloader(ca.issue("user-service", []string{"user-service"}), &logger)
+	require.NoError(t, err)
+
+	opts := append([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.ServerTLSConfig()))},
+		ServerOptions(0, []string{forumIdentity}, &logger)...)
+	server := grpc.NewServer(opts...)
+	Register(server, usernameOnly{}, &logger)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(l)
+	t.Cleanup(server.Stop)
+	return l.Addr().String()
+}
+
+func dialMTLS(t *testing.T, addr string, reloader *mtls.Reloader) *grpc.ClientConn {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(reloader.ClientTLSConfig("user-service"))))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func getUsername(conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := userpb.NewUserServiceClient(conn).GetUsername(ctx, &userpb.GetUsernameRequest{UserId: 1})
+	return err
+}
+
+func TestMTLS_AllowsListedIdentity(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+	logger := zerolog.Nop()
+	reloader, err := mtls.NewReloader(ca.issue("forum", nil, forumIdentity), &logger)
+	require.NoError(t, err)
+
+	assert.NoError(t, getUsername(dialMTLS(t, addr, reloader)))
+}
+
+func TestMTLS_RejectsOtherIdentityButNotHealthChecks(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+	logger := zerolog.Nop()
+	reloader, err := mtls.NewReloader(ca.issue("intruder", nil, intruderIdentity), &logger)
+	require.NoError(t, err)
+	conn := dialMTLS(t, addr, reloader)
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(getUsername(conn)))
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
+
+func TestMTLS_RejectsCertificateFromOtherCA(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+	logger := zerolog.Nop()
+
+	stranger := newTestCA(t)
+	cfg := stranger.issue("forum", nil, forumIdentity)
+	cfg.CAFile = filepath.Join(ca.dir, "ca.crt")
+	reloader, err := mtls.NewReloader(cfg, &logger)
+	require.NoError(t, err)
+
+	assert.Equal(t, codes.Unavailable, status.Code(getUsername(dialMTLS(t, addr, reloader))))
+}
+
+func TestMTLS_ReloadsRotatedCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+	logger := zerolog.Nop()
+
+	cfg := ca.issue("forum", nil, intruderIdentity)
+	cfg.ReloadInterval = 10 * time.Millisecond
+	reloader, err := mtls.NewReloader(cfg, &logger)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx)
+
+	require.Equal(t, codes.PermissionDenied, status.Code(getUsername(dialMTLS(t, addr, reloader))))
+
+	ca.issue("forum", nil, forumIdentity)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(cfg.CertFile, later, later))
+
+	assert.Eventually(t, func() bool {
+		return getUsername(dialMTLS(t, addr, reloader)) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+}
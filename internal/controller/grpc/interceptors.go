@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOptions returns the interceptor chains for the user and forum services: request IDs, access logs, panic
+// recovery and, for unary calls, a cap of maxDeadline on how long a call may run. A zero maxDeadline
+// leaves deadlines to the caller. With allowedIdentities set, callers must present a client certificate
+// naming one of them, which needs mTLS credentials on the server.
+func ServerOptions(maxDeadline time.Duration, allowedIdentities []string, log *zerolog.Logger) []grpc.ServerOption {
+	i := &interceptors{maxDeadline: maxDeadline, log: log}
+	unary := []grpc.UnaryServerInterceptor{i.requestID, i.accessLog, i.recovery}
+	stream := []grpc.StreamServerInterceptor{i.streamRequestID, i.streamAccessLog, i.streamRecovery}
+	if len(allowedIdentities) > 0 {
+		check := &identityCheck{allowed: allowedIdentities, log: log}
+		unary = append(unary, check.unary)
+		stream = append(stream, check.stream)
+	}
+	unary = append(unary, i.deadline)
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+type interceptors struct {
+	maxDeadline time.Duration
+	log         *zerolog.Logger
+}
+
+// withRequestID reuses the caller's request ID when it sent a valid one and echoes it back in the header.
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 && requestid.Valid(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = requestid.New()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+	return requestid.NewContext(ctx, id)
+}
+
+// withLocale puts the locale negotiated from the caller's accept-language metadata into ctx, so
+// placeholder author names and other generated strings come back in the caller's language.
+func withLocale(ctx context.Context) context.Context {
+	var acceptLanguage string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		acceptLanguage = strings.Join(md.Get("accept-language"), ",")
+	}
+	return i18n.NewContext(ctx, i18n.Negotiate(acceptLanguage))
+}
+
+func (i *interceptors) requestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withLocale(withRequestID(ctx)), req)
+}
+
+func (i *interceptors) streamRequestID(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: withLocale(withRequestID(ss.Context()))})
+}
+
+func (i *interceptors) logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	event := i.log.Info()
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists, codes.Canceled:
+	default:
+		event = i.log.Error().Err(err)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		event = event.Str("peer", p.Addr.String())
+	}
+	event.Str("op", "GRPCServer.accessLog").
+		Str("method", method).
+		Str("code", code.String()).
+		Str("request_id", requestid.FromContext(ctx)).
+		Dur("duration", time.Since(start)).
+		Msg("grpc call")
+}
+
+func (i *interceptors) accessLog(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	i.logCall(ctx, info.FullMethod, start, err)
+	return res, err
+}
+
+func (i *interceptors) streamAccessLog(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	i.logCall(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func (i *interceptors) panicked(ctx context.Context, method string, p any) error {
+	i.log.Error().
+		Str("op", "GRPCServer.recovery").
+		Str("method", method).
+		Str("request_id", requestid.FromContext(ctx)).
+		Any("panic", p).
+		Bytes("stack", debug.Stack()).
+		Msg("panic in grpc handler")
+	return status.Error(codes.Internal, "internal error")
+}
+
+func (i *interceptors) recovery(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			res, err = nil, i.panicked(ctx, info.FullMethod, p)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (i *interceptors) streamRecovery(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = i.panicked(ss.Context(), info.FullMethod, p)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// deadline shortens the call's deadline to maxDeadline when the caller set none or a later one.
+func (i *interceptors) deadline(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if i.maxDeadline <= 0 {
+		return handler(ctx, req)
+	}
+	if d, ok := ctx.Deadline(); ok && time.Until(d) <= i.maxDeadline {
+		return handler(ctx, req)
+	}
+	ctx, cancel := context.WithTimeout(ctx, i.maxDeadline)
+	defer cancel()
+	return handler(ctx, req)
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
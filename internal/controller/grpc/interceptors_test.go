@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestInterceptors(maxDeadline time.Duration) *interceptors {
+	logger := zerolog.Nop()
+	return &interceptors{maxDeadline: maxDeadline, log: &logger}
+}
+
+var testInfo = &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUsername"}
+
+func TestRequestID_ReusesIncoming(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.MetadataKey, "abc-123"))
+
+	var got string
+	_, err := newTestInterceptors(0).requestID(ctx, nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		got = requestid.FromContext(ctx)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "abc-123", got)
+}
+
+func TestRequestID_GeneratesWhenMissingOrInvalid(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.MetadataKey, "bad id\n"))
+
+	var got string
+	_, err := newTestInterceptors(0).requestID(ctx, nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		got = requestid.FromContext(ctx)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, got, 32)
+}
+
+func TestRecovery_TurnsPanicIntoInternal(t *testing.T) {
+	res, err := newTestInterceptors(0).recovery(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestDeadline_CapsMissingAndLongDeadlines(t *testing.T) {
+	i := newTestInterceptors(time.Second)
+	remaining := func(ctx context.Context) time.Duration {
+		var left time.Duration
+		_, _ = i.deadline(ctx, nil, testInfo, func(ctx context.Context, req any) (any, error) {
+			d, ok := ctx.Deadline()
+			require.True(t, ok)
+			left = time.Until(d)
+			return nil, nil
+		})
+		return left
+	}
+
+	assert.LessOrEqual(t, remaining(context.Background()), time.Second)
+
+	long, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	assert.LessOrEqual(t, remaining(long), time.Second)
+
+	short, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.LessOrEqual(t, remaining(short), 100*time.Millisecond)
+}
+
+func TestDeadline_ZeroLeavesCallerDeadline(t *testing.T) {
+	_, err := newTestInterceptors(0).deadline(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil, nil
+	})
+	require.NoError(t, err)
+}
@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	userpb "github.com/Van-programan/Forum_GO/pkg/proto"
 	"github.com/rs/zerolog"
@@ -24,7 +26,7 @@ func (s *serverAPI) GetUsernames(ctx context.Context, req *userpb.GetUsernamesRe
 	usernames, err := s.usecase.GetUsernamesByIds(ctx, req.UserIds)
 	if err != nil {
 		s.log.Error().Err(err).Str("op", "UserServer.GetUsernames").Msg("failed to get usernames")
-		return nil, fmt.Errorf("UserService - UserServer - GetUsernames - usecase.GetUsernamesByIds: %w", err)
+		return nil, toStatus(err)
 	}
 
 	s.log.Info().Str("op", "UserServer.GetUsernames").Msg("success")
@@ -35,9 +37,146 @@ func (s *serverAPI) GetUsername(ctx context.Context, req *userpb.GetUsernameRequ
 	username, err := s.usecase.GetUsernameById(ctx, req.UserId)
 	if err != nil {
 		s.log.Error().Err(err).Str("op", "UserServer.GetUsername").Msg("failed to get username")
-		return nil, fmt.Errorf("UserService - UserServer - GetUsername - usecase.GetUsernameById: %w", err)
+		return nil, toStatus(err, req.UserId)
 	}
 
 	s.log.Info().Str("op", "UserServer.GetUsername").Msg("success")
 	return &userpb.GetUsernameResponse{UserId: req.UserId, Username: username}, nil
 }
+
+func (s *serverAPI) GetUserStatus(ctx context.Context, req *userpb.GetUserStatusRequest) (*userpb.GetUserStatusResponse, error) {
+	status, err := s.usecase.GetUserStatus(ctx, req.UserId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "UserServer.GetUserStatus").Msg("failed to get user status")
+		return nil, toStatus(err, req.UserId)
+	}
+
+	return toProtoStatus(*status), nil
+}
+
+func (s *serverAPI) GetUserStatuses(ctx context.Context, req *userpb.GetUserStatusesRequest) (*userpb.GetUserStatusesResponse, error) {
+	statuses, err := s.usecase.GetUserStatuses(ctx, req.UserIds)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "UserServer.GetUserStatuses").Msg("failed to get user statuses")
+		return nil, toStatus(err)
+	}
+
+	res := &userpb.GetUserStatusesResponse{Statuses: make(map[int64]*userpb.GetUserStatusResponse, len(statuses))}
+	for id, status := range statuses {
+		res.Statuses[id] = toProtoStatus(status)
+	}
+	return res, nil
+}
+
+func toProtoStatus(status entity.UserStatus) *userpb.GetUserStatusResponse {
+	res := &userpb.GetUserStatusResponse{UserId: status.UserID, Banned: status.Banned, BanReason: status.Reason}
+	if status.BannedUntil != nil {
+		res.BannedUntil = status.BannedUntil.Unix()
+	}
+	return res
+}
+
+func (s *serverAPI) ValidateToken(ctx context.Context, req *userpb.ValidateTokenRequest) (*userpb.ValidateTokenResponse, error) {
+	identity, err := s.usecase.ValidateToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidPersonalToken) {
+			return &userpb.ValidateTokenResponse{Valid: false}, nil
+		}
+		s.log.Error().Err(err).Str("op", "UserServer.ValidateToken").Msg("failed to validate token")
+		return nil, toStatus(err)
+	}
+
+	return &userpb.ValidateTokenResponse{
+		Valid:   true,
+		TokenId: identity.TokenID,
+		UserId:  identity.UserID,
+		Role:    identity.Role,
+		Scopes:  identity.Scopes,
+	}, nil
+}
+
+func (s *serverAPI) GetUserProfiles(ctx context.Context, req *userpb.GetUserProfilesRequest) (*userpb.GetUserProfilesResponse, error) {
+	profiles, err := s.usecase.GetUserProfiles(ctx, req.UserIds)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "UserServer.GetUserProfiles").Msg("failed to get profiles")
+		return nil, toStatus(err)
+	}
+
+	res := &userpb.GetUserProfilesResponse{Profiles: make(map[int64]*userpb.UserProfile, len(profiles))}
+	for id, profile := range profiles {
+		res.Profiles[id] = toProtoProfile(profile)
+	}
+	return res, nil
+}
+
+func (s *serverAPI) GetUserProfile(ctx context.Context, req *userpb.GetUserProfileRequest) (*userpb.GetUserProfileResponse, error) {
+	profile, err := s.usecase.GetUserProfile(ctx, req.UserId)
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "UserServer.GetUserProfile").Int64("user_id", req.UserId).Msg("failed to get profile")
+		return nil, toStatus(err, req.UserId)
+	}
+
+	return &userpb.GetUserProfileResponse{Profile: toProtoProfile(*profile)}, nil
+}
+
+func toProtoProfile(p entity.UserProfile) *userpb.UserProfile {
+	return &userpb.UserProfile{
+		UserId:      p.UserID,
+		Username:    p.Username,
+		DisplayName: p.DisplayName,
+		Bio:         p.Bio,
+		AvatarUrl:   p.AvatarURL,
+		Role:        p.Role,
+		Signature:   p.Signature,
+		JoinedAt:    p.JoinedAt.Unix(),
+	}
+}
+
+func (s *serverAPI) WatchUserChanges(_ *userpb.WatchUserChangesRequest, stream grpc.ServerStreamingServer[userpb.UserChangeEvent]) error {
+	changes, unsubscribe := s.usecase.WatchUserChanges()
+	defer unsubscribe()
+
+	// Changes made before the subscription are unknown to the subscriber, so it starts from a clean cache.
+	if err := stream.Send(&userpb.UserChangeEvent{Type: userpb.UserChangeEvent_RESET}); err != nil {
+		return fmt.Errorf("UserService - UserServer - WatchUserChanges - stream.Send: %w", err)
+	}
+
+	s.log.Info().Str("op", "UserServer.WatchUserChanges").Msg("subscriber connected")
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				s.log.Warn().Str("op", "UserServer.WatchUserChanges").Msg("subscriber fell behind, closing stream")
+				return errors.New("UserService - UserServer - WatchUserChanges: subscriber fell behind")
+			}
+			if err := stream.Send(&userpb.UserChangeEvent{Type: userChangeTypes[change.Type], UserId: change.UserID}); err != nil {
+				return fmt.Errorf("UserService - UserServer - WatchUserChanges - stream.Send: %w", err)
+			}
+		}
+	}
+}
+
+var userChangeTypes = map[string]userpb.UserChangeEvent_Type{
+	entity.UserChangeCreated: userpb.UserChangeEvent_CREATED,
+	entity.UserChangeRenamed: userpb.UserChangeEvent_RENAMED,
+	entity.UserChangeUpdated: userpb.UserChangeEvent_UPDATED,
+	entity.UserChangeDeleted: userpb.UserChangeEvent_DELETED,
+	entity.UserChangeReset:   userpb.UserChangeEvent_RESET,
+}
+
+func (s *serverAPI) StreamUsers(req *userpb.StreamUsersRequest, stream grpc.ServerStreamingServer[userpb.UserRecordPage]) error {
+	err := s.usecase.StreamUsers(stream.Context(), req.Since, func(records []entity.UserRecord, checkpoint int64) error {
+		page := &userpb.UserRecordPage{Users: make([]*userpb.UserRecord, 0, len(records)), Checkpoint: checkpoint}
+		for _, rec := range records {
+			page.Users = append(page.Users, &userpb.UserRecord{UserId: rec.ID, Username: rec.Username, Role: rec.Role, Deleted: rec.Deleted})
+		}
+		return stream.Send(page)
+	})
+	if err != nil {
+		s.log.Error().Err(err).Str("op", "UserServer.StreamUsers").Int64("since", req.Since).Msg("failed to stream users")
+		return toStatus(err)
+	}
+	return nil
+}
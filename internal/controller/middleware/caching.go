@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache-Control policies of the read endpoints. Rarely edited lists may be reused for a minute; content
+// users write is revalidated on every use, so edits show up at once.
+const (
+	CacheShortLived = "public, max-age=60"
+	CacheRevalidate = "public, no-cache"
+)
+
+const (
+	cacheControlHeader = "Cache-Control"
+	cacheNoStore       = "no-store"
+)
+
+// CacheControl sets the Cache-Control policy of a route's replies. Errors replace it with no-store.
+func CacheControl(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(cacheControlHeader, policy)
+		c.Next()
+	}
+}
+
+// NoStore keeps the reply out of caches, for replies such as degraded ones that should not be reused.
+func NoStore(c *gin.Context) {
+	c.Header(cacheControlHeader, cacheNoStore)
+}
+
+// NotModified tags the reply with the validators of the representation, leaving out a zero lastModified.
+// When the request's If-None-Match or, lacking one, its If-Modified-Since shows the client has that
+// representation already, it replies 304 and returns true.
+func NotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if !fresh(c.Request, etag, lastModified) {
+		return false
+	}
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// fresh evaluates the request's cache validators the way RFC 9110 section 13.2.2 orders them.
+func fresh(r *http.Request, etag string, lastModified time.Time) bool {
+	if tags := r.Header.Get("If-None-Match"); tags != "" {
+		return etagMatches(tags, etag)
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	// Last-Modified has whole seconds, so the client's copy is current when nothing changed after its second.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches reports whether the If-None-Match list names etag, comparing weakly.
+func etagMatches(tags, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 3, 1, 12, 0, 0, 250, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"No validators", nil, http.StatusOK},
+		{"Matching tag", map[string]string{"If-None-Match": `"7"`}, http.StatusNotModified},
+		{"Matching weak tag", map[string]string{"If-None-Match": `W/"7"`}, http.StatusNotModified},
+		{"Tag in list", map[string]string{"If-None-Match": `"5", "7"`}, http.StatusNotModified},
+		{"Wildcard", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"Outdated tag", map[string]string{"If-None-Match": `"6"`}, http.StatusOK},
+		{"Same second", map[string]string{"If-Modified-Since": "Sat, 01 Mar 2025 12:00:00 GMT"}, http.StatusNotModified},
+		{"Older copy", map[string]string{"If-Modified-Since": "Sat, 01 Mar 2025 11:59:59 GMT"}, http.StatusOK},
+		{"Malformed date", map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		{"Tag takes precedence", map[string]string{
+			"If-None-Match":     `"6"`,
+			"If-Modified-Since": "Sat, 01 Mar 2025 12:00:00 GMT",
+		}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if NotModified(c, ETag(7), lastModified) {
+					return
+				}
+				c.String(http.StatusOK, "body")
+			})
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, `"7"`, rr.Header().Get("ETag"))
+			assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotModified_WithoutLastModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		if NotModified(c, ETag(7), time.Time{}) {
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-Modified-Since", "Sat, 01 Mar 2025 12:00:00 GMT")
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Last-Modified"))
+}
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(Errors(&log))
+	router.GET("/ok", CacheControl(CacheShortLived), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/fail", CacheControl(CacheShortLived), func(c *gin.Context) {
+		SetETag(c, 7)
+		Abort(c, errors.New("db error"))
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ok", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, CacheShortLived, rr.Header().Get("Cache-Control"))
+
+	rr = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/fail", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	assert.Empty(t, rr.Header().Get("ETag"))
+}
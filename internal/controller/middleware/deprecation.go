@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// successorRouteKey holds the route pattern of the successor of a deprecated route.
+const successorRouteKey = "successor_route"
+
+// Deprecated marks responses of a route that has been superseded by successor, a path pattern using the
+// same parameters as the route. Clients get the RFC 9745 Deprecation header, the RFC 8594 Sunset header
+// and a Link to the successor with the parameters of the request filled in. The successor is also kept
+// in the context, so middleware that tells requests apart by route treats the alias like the successor.
+func Deprecated(successor string, deprecatedAt, sunset time.Time) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *gin.Context) {
+		c.Set(successorRouteKey, successor)
+
+		link := successor
+		for _, p := range c.Params {
+			link = strings.Replace(link, ":"+p.Key, p.Value, 1)
+		}
+
+		c.Header("Deprecation", deprecation)
+		c.Header("Sunset", sunsetHeader)
+		c.Header("Link", "<"+link+`>; rel="successor-version"`)
+
+		c.Next()
+	}
+}
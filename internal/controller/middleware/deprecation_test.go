@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated_SetsHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	router := gin.New()
+	router.GET("/categories/topics/:id", Deprecated("/api/v1/categories/:id/topics", deprecatedAt, sunset), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/categories/topics/42", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "@1792368000", rr.Header().Get("Deprecation"))
+	assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/categories/42/topics>; rel="successor-version"`, rr.Header().Get("Link"))
+}
+
+func TestDeprecated_KeepsHeadersOnAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(Errors(&log))
+	router.DELETE("/posts/:id", Deprecated("/api/v1/posts/:id", time.Now(), time.Now()), func(c *gin.Context) {
+		Abort(c, ErrUnauthorized)
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/posts/7", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v1/posts/7>; rel="successor-version"`, rr.Header().Get("Link"))
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// Errors the middlewares and handlers reply with themselves.
+var (
+	ErrUnauthorized            = entity.NewError(entity.KindUnauthorized, "unauthorized", "unauthorized")
+	ErrInvalidToken            = entity.NewError(entity.KindUnauthorized, "invalid_token", "invalid token")
+	ErrInsufficientPermissions = entity.NewError(entity.KindForbidden, "insufficient_permissions", "insufficient permissions")
+	ErrInsufficientScope       = entity.NewError(entity.KindForbidden, "insufficient_scope", "token lacks the required scope")
+	ErrInvalidParameter        = entity.NewError(entity.KindInvalid, "invalid_parameter", "invalid parameter")
+	ErrInvalidRequest          = entity.NewError(entity.KindInvalid, "invalid_request", "invalid request")
+)
+
+var errTokenValidationUnavailable = entity.NewError(entity.KindUnavailable, "token_validation_unavailable", "token validation unavailable")
+
+// errInternal replaces errors that are not domain errors, whose text may hold SQL or other internals.
+var errInternal = entity.NewError(entity.KindInternal, "internal_error", "internal server error")
+
+var kindStatus = map[entity.ErrorKind]int{
+	entity.KindInvalid:       http.StatusBadRequest,
+	entity.KindUnauthorized:  http.StatusUnauthorized,
+	entity.KindForbidden:     http.StatusForbidden,
+	entity.KindNotFound:      http.StatusNotFound,
+	entity.KindConflict:      http.StatusConflict,
+	entity.KindUnprocessable: http.StatusUnprocessableEntity,
+	entity.KindUnavailable:   http.StatusServiceUnavailable,
+
+	entity.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	entity.KindPreconditionRequired: http.StatusPreconditionRequired,
+	entity.KindTooLarge:             http.StatusRequestEntityTooLarge,
+}
+
+// InvalidParameter reports a malformed path or query parameter, such as "topic id".
+func InvalidParameter(name string) error {
+	return ErrInvalidParameter.WithMessage("invalid " + name)
+}
+
+// InvalidRequest reports a request body that could not be bound.
+func InvalidRequest(err error) error {
+	return ErrInvalidRequest.WithMessage(err.Error())
+}
+
+// Abort stops the request with err, which Errors turns into the response.
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// Errors replies to requests that ended with an error, recorded by Abort or c.Error, with an
+// ErrorResponse. Domain errors keep their code and message, which is translated when the request's
+// locale has a message for the code; any other error is logged and answered with a generic 500, so
+// database and other internal details never reach the client. Error replies are never cached.
+func Errors(log *zerolog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+		reqID := requestid.FromContext(c.Request.Context())
+
+		var domainErr *entity.Error
+		if !errors.As(err, &domainErr) || domainErr.Kind == entity.KindInternal {
+			log.Error().Err(err).
+				Str("op", "middleware.Errors").
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Str("request_id", reqID).
+				Msg("request failed")
+			domainErr = errInternal
+		}
+
+		status, ok := kindStatus[domainErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		message := domainErr.Message
+		if translated, ok := i18n.Lookup(i18n.FromContext(c.Request.Context()), i18n.ErrorCode(domainErr.Code)); ok {
+			message = translated
+		}
+		// Error replies are not cached and do not carry the validators of the representation asked for.
+		header := c.Writer.Header()
+		header.Set(cacheControlHeader, cacheNoStore)
+		header.Del("ETag")
+		header.Del("Last-Modified")
+		c.JSON(status, response.ErrorResponse{
+			Code:      domainErr.Code,
+			Error:     message,
+			RequestID: reqID,
+			Details:   domainErr.Details,
+		})
+	}
+}
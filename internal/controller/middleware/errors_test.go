@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, response.ErrorResponse) {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(RequestID(), Errors(&log))
+	router.GET("/", func(c *gin.Context) {
+		Abort(c, err)
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	router.ServeHTTP(rr, req)
+
+	var body response.ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	return rr, body
+}
+
+func TestErrors_MapsKindsToStatus(t *testing.T) {
+	tests := []struct {
+		kind   entity.ErrorKind
+		status int
+	}{
+		{entity.KindInvalid, http.StatusBadRequest},
+		{entity.KindUnauthorized, http.StatusUnauthorized},
+		{entity.KindForbidden, http.StatusForbidden},
+		{entity.KindNotFound, http.StatusNotFound},
+		{entity.KindConflict, http.StatusConflict},
+		{entity.KindUnprocessable, http.StatusUnprocessableEntity},
+		{entity.KindUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		rr, body := serveError(t, entity.NewError(tt.kind, "some_code", "some message"))
+
+		assert.Equal(t, tt.status, rr.Code)
+		assert.Equal(t, "some_code", body.Code)
+		assert.Equal(t, "some message", body.Error)
+		assert.NotEmpty(t, body.RequestID)
+		assert.Equal(t, rr.Header().Get("X-Request-ID"), body.RequestID)
+	}
+}
+
+func TestErrors_UnwrapsDomainError(t *testing.T) {
+	notFound := entity.NewError(entity.KindNotFound, "topic_not_found", "topic not found")
+
+	rr, body := serveError(t, fmt.Errorf("TopicUsecase - getTopic - topicRepo.GetByID(): %w", notFound))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "topic_not_found", body.Code)
+	assert.Equal(t, "topic not found", body.Error)
+}
+
+func TestErrors_HidesInternalErrors(t *testing.T) {
+	rr, body := serveError(t, errors.New(`ERROR: relation "topics" does not exist (SQLSTATE 42P01)`))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "internal_error", body.Code)
+	assert.Equal(t, "internal server error", body.Error)
+}
+
+func TestErrors_KeepsDetails(t *testing.T) {
+	banned := entity.NewError(entity.KindForbidden, "user_banned", "user is banned")
+
+	rr, body := serveError(t, banned.WithDetails(map[string]any{"reason": "spam"}))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, "spam", body.Details["reason"])
+	assert.ErrorIs(t, banned.WithDetails(nil), banned)
+}
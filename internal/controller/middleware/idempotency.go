@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// IdempotencyKeyHeader names the header clients send to make a POST safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on replies repeated from an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+var (
+	ErrIdempotencyKeyReused     = entity.NewError(entity.KindUnprocessable, "idempotency_key_reused", "Idempotency-Key was already used for a different request")
+	ErrIdempotencyKeyInProgress = entity.NewError(entity.KindConflict, "idempotency_key_in_progress", "a request with this Idempotency-Key is still in progress")
+	ErrRequestTooLarge          = entity.NewError(entity.KindTooLarge, "request_too_large", "request body is too large")
+)
+
+// IdempotencyStore keeps the requests sent with an Idempotency-Key. repo.IdempotencyRepository
+// implements it.
+type IdempotencyStore interface {
+	Acquire(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+	Release(ctx context.Context, record *entity.IdempotencyRecord) error
+}
+
+// IdempotencyConfig sets how long replies are kept for retries and how long a request that never
+// finished holds its key.
+type IdempotencyConfig struct {
+	TTL     time.Duration
+	LockTTL time.Duration
+}
+
+// Idempotency makes authenticated requests sent with an Idempotency-Key run at most once per user and
+// key. A repeat of an answered request gets the stored reply, a repeat of one still running gets 409 and
+// a request that reuses the key with another method, path or body gets 422. Only successful replies are
+// stored: a request that fails releases its key so the client can retry it. Requests without the header
+// pass through.
+func Idempotency(store IdempotencyStore, cfg IdempotencyConfig, log *zerolog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		userID, ok := GetUserIDFromContext(c)
+		if key == "" || !ok {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey(key) {
+			Abort(c, InvalidParameter(IdempotencyKeyHeader+" header"))
+			return
+		}
+
+		// The body is read whole to hash it, so it gets the limit BindJSON would apply.
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				Abort(c, ErrRequestTooLarge)
+				return
+			}
+			Abort(c, InvalidRequest(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := &entity.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(c.Request.Method, routeOf(c), c.Params, body),
+			ExpiresAt:   time.Now().Add(cfg.LockTTL),
+		}
+		stored, acquired, err := store.Acquire(c.Request.Context(), record)
+		if err != nil {
+			Abort(c, err)
+			return
+		}
+		if !acquired {
+			replay(c, record, stored)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The reply has to be stored or the key released even when the client has gone away.
+		ctx := context.WithoutCancel(c.Request.Context())
+		status := recorder.Status()
+		if len(c.Errors) > 0 || !recorder.Written() || status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, record); err != nil {
+				log.Error().Err(err).Str("op", "middleware.Idempotency").Int64("user_id", userID).Msg("Failed to release idempotency key")
+			}
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Body = recorder.body.Bytes()
+		record.ExpiresAt = time.Now().Add(cfg.TTL)
+		if err := store.Complete(ctx, record); err != nil {
+			log.Error().Err(err).Str("op", "middleware.Idempotency").Int64("user_id", userID).Msg("Failed to store idempotent reply")
+		}
+	}
+}
+
+// replay answers a request whose key is taken from the stored record.
+func replay(c *gin.Context, record, stored *entity.IdempotencyRecord) {
+	switch {
+	case stored.RequestHash != record.RequestHash:
+		Abort(c, ErrIdempotencyKeyReused)
+	case stored.StatusCode == 0:
+		c.Header("Retry-After", "1")
+		Abort(c, ErrIdempotencyKeyInProgress)
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+		c.Abort()
+	}
+}
+
+// validIdempotencyKey accepts up to maxIdempotencyKeyLength printable ASCII characters, which covers
+// UUIDs and the other keys clients generate.
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// apiVersionPrefix matches the version prefix of a route, such as "/api/v1".
+var apiVersionPrefix = regexp.MustCompile(`^/api/v[0-9]+`)
+
+// routeOf returns the route pattern of the request without the API version prefix. A deprecated alias
+// reports the route of its successor, so the alias and the versioned path are the same request.
+func routeOf(c *gin.Context) string {
+	route := c.FullPath()
+	if successor := c.GetString(successorRouteKey); successor != "" {
+		route = successor
+	}
+	return apiVersionPrefix.ReplaceAllString(route, "")
+}
+
+// requestHash identifies what a key was used for, so it cannot be replayed for another request. It
+// covers the route pattern and the path parameters rather than the raw path, which differs between a
+// route and its aliases.
+func requestHash(method, route string, params gin.Params, body []byte) string {
+	values := url.Values{}
+	for _, p := range params {
+		values.Add(p.Key, p.Value)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(method + " " + route + "?" + values.Encode() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the reply body while writing it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testIdempotencyConfig = IdempotencyConfig{TTL: time.Hour, LockTTL: time.Minute}
+
+var testTopicParams = gin.Params{{Key: "id", Value: "3"}}
+
+// newIdempotencyRouter serves POST /topics/:id/posts for user 1 behind Idempotency. The handler replies
+// with status and counts its calls.
+func newIdempotencyRouter(store IdempotencyStore, status int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(Errors(&log))
+	router.POST("/topics/:id/posts", func(c *gin.Context) {
+		c.Set(ContextUserIDKey, int64(1))
+	}, Idempotency(store, testIdempotencyConfig, &log), func(c *gin.Context) {
+		*calls++
+		if status >= http.StatusInternalServerError {
+			Abort(c, errors.New("db error"))
+			return
+		}
+		c.JSON(status, gin.H{"id": 7})
+	})
+	return router
+}
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/topics/3/posts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotency_StoresFirstReply(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	var acquired entity.IdempotencyRecord
+	store.On("Acquire", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		acquired = *args.Get(1).(*entity.IdempotencyRecord)
+	}).Return(nil, true, nil).Once()
+	store.On("Complete", mock.Anything, mock.MatchedBy(func(r *entity.IdempotencyRecord) bool {
+		return r.UserID == 1 && r.Key == "key-1" && r.StatusCode == http.StatusCreated &&
+			r.ContentType == "application/json; charset=utf-8" && string(r.Body) == `{"id":7}` &&
+			r.ExpiresAt.After(time.Now().Add(testIdempotencyConfig.LockTTL))
+	})).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"hi"}`))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, requestHash(http.MethodPost, "/topics/:id/posts", testTopicParams, []byte(`{"content":"hi"}`)), acquired.RequestHash)
+	assert.WithinDuration(t, time.Now().Add(testIdempotencyConfig.LockTTL), acquired.ExpiresAt, time.Second)
+}
+
+func TestIdempotency_ReplaysStoredReply(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).Return(&entity.IdempotencyRecord{
+		RequestHash: requestHash(http.MethodPost, "/topics/:id/posts", testTopicParams, []byte(`{"content":"hi"}`)),
+		StatusCode:  http.StatusCreated,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":7}`),
+	}, false, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"hi"}`))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, `{"id":7}`, rr.Body.String())
+	assert.Equal(t, "true", rr.Header().Get(IdempotentReplayedHeader))
+	assert.Zero(t, calls)
+}
+
+func TestIdempotency_RejectsReusedKey(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).Return(&entity.IdempotencyRecord{
+		RequestHash: requestHash(http.MethodPost, "/topics/:id/posts", testTopicParams, []byte(`{"content":"first"}`)),
+		StatusCode:  http.StatusCreated,
+	}, false, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"second"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	var body response.ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "idempotency_key_reused", body.Code)
+	assert.Zero(t, calls)
+}
+
+func TestIdempotency_ConflictWhileInProgress(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).Return(&entity.IdempotencyRecord{
+		RequestHash: requestHash(http.MethodPost, "/topics/:id/posts", testTopicParams, []byte(`{"content":"hi"}`)),
+	}, false, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"hi"}`))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.Zero(t, calls)
+}
+
+func TestIdempotency_ReleasesKeyOnFailure(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusInternalServerError, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.IdempotencyRecord).LockToken = "token"
+	}).Return(nil, true, nil).Once()
+	store.On("Release", mock.Anything, mock.MatchedBy(func(r *entity.IdempotencyRecord) bool {
+		return r.UserID == 1 && r.Key == "key-1" && r.LockToken == "token"
+	})).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"hi"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, 1, calls)
+	store.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_AliasHashesLikeVersionedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	store := mocks.NewIdempotencyRepository(t)
+
+	var hashes []string
+	store.On("Acquire", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		hashes = append(hashes, args.Get(1).(*entity.IdempotencyRecord).RequestHash)
+	}).Return(nil, true, nil)
+	store.On("Complete", mock.Anything, mock.Anything).Return(nil)
+
+	handlers := []gin.HandlerFunc{func(c *gin.Context) {
+		c.Set(ContextUserIDKey, int64(1))
+	}, Idempotency(store, testIdempotencyConfig, &log), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": 7})
+	}}
+	router := gin.New()
+	router.POST("/api/v1/categories/:id/topics", handlers...)
+	router.POST("/categories/topics/:id/", append([]gin.HandlerFunc{
+		Deprecated("/api/v1/categories/:id/topics", time.Now(), time.Now().Add(time.Hour)),
+	}, handlers...)...)
+
+	for _, path := range []string{"/api/v1/categories/3/topics", "/categories/topics/3/", "/api/v1/categories/4/topics"} {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(`{"title":"hi"}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.NotEqual(t, hashes[0], hashes[2])
+	assert.Equal(t, requestHash(http.MethodPost, "/categories/:id/topics", testTopicParams, []byte(`{"title":"hi"}`)), hashes[0])
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("", `{"content":"hi"}`))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+	store.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_InvalidKey(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	for _, key := range []string{strings.Repeat("k", maxIdempotencyKeyLength+1), "key\x7f"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, newIdempotentRequest(key, `{"content":"hi"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	}
+	assert.Zero(t, calls)
+	store.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	store := mocks.NewIdempotencyRepository(t)
+	calls := 0
+	router := newIdempotencyRouter(store, http.StatusCreated, &calls)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newIdempotentRequest("key-1", `{"content":"`+strings.Repeat("x", maxBodyBytes)+`"}`))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	var body response.ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "request_too_large", body.Code)
+	assert.Zero(t, calls)
+	store.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything)
+}
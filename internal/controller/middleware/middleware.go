@@ -1,70 +1,128 @@
 package middleware
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"errors"
+	"slices"
 	"strings"
-	"time"
 
+	"github.com/Van-programan/Forum_GO/internal/client"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 )
 
 type AccessClaims struct {
-	UserID int64  `mapstructure:"user_id"`
-	Role   string `mapstructure:"role"`
-	Exp    int64  `mapstructure:"exp"`
-	Iat    int64  `mapstructure:"iat"`
+	UserID              int64    `mapstructure:"user_id"`
+	Role                string   `mapstructure:"role"`
+	Permissions         []string `mapstructure:"permissions"`
+	ModeratedCategories []int64  `mapstructure:"moderated_categories"`
+	Type                string   `mapstructure:"type"`
+	Exp                 int64    `mapstructure:"exp"`
+	Iat                 int64    `mapstructure:"iat"`
+}
+
+// TokenValidator resolves personal access tokens. client.UserClient implements it.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error)
 }
 
 type AuthMiddleware struct {
-	jwt *jwt.JWT
+	jwt    *jwt.JWT
+	tokens TokenValidator
 }
 
-func NewAuthMiddleware(jwt *jwt.JWT) *AuthMiddleware {
-	return &AuthMiddleware{jwt: jwt}
+// NewAuthMiddleware creates the middleware. With a nil tokens only Bearer access tokens are accepted.
+func NewAuthMiddleware(jwt *jwt.JWT, tokens TokenValidator) *AuthMiddleware {
+	return &AuthMiddleware{jwt: jwt, tokens: tokens}
 }
 
 const (
-	ContextUserIDKey = "user_id"
-	ContextRoleKey   = "role"
+	ContextUserIDKey              = "user_id"
+	ContextRoleKey                = "role"
+	ContextPermissionsKey         = "permissions"
+	ContextModeratedCategoriesKey = "moderated_categories"
+	ContextScopesKey              = "token_scopes"
 )
 
 func (m *AuthMiddleware) Auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
-		fmt.Println("authHeader", authHeader)
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header is required"})
+			Abort(c, ErrUnauthorized.WithMessage("authorization header is required"))
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
+		if len(parts) == 2 && parts[0] == "Token" && m.tokens != nil {
+			if m.personalToken(c, parts[1]) {
+				c.Next()
+			}
+			return
+		}
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+			Abort(c, ErrUnauthorized.WithMessage("invalid authorization header format"))
 			return
 		}
 
 		token := parts[1]
 		claims, err := m.jwt.ParseToken(token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			Abort(c, ErrInvalidToken)
 			return
 		}
 
 		var accessClaims AccessClaims
 		mapstructure.Decode(claims, &accessClaims)
-		fmt.Println(time.Now().Unix(), accessClaims.Exp)
-		c.Set(ContextUserIDKey, accessClaims.UserID)
-		c.Set(ContextRoleKey, accessClaims.Role)
+		if accessClaims.Type == jwt.TokenTypeMFA {
+			Abort(c, ErrInvalidToken)
+			return
+		}
+		setClaims(c, &accessClaims)
 
 		c.Next()
 	}
 }
 
+// personalToken authenticates the request with a personal access token and aborts it when that fails.
+// The token carries the user's role but no permissions, so it can only do what the owner may do to their
+// own content, within its scopes.
+func (m *AuthMiddleware) personalToken(c *gin.Context, token string) bool {
+	identity, err := m.tokens.ValidateToken(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, client.ErrInvalidToken) {
+			Abort(c, ErrInvalidToken)
+			return false
+		}
+		Abort(c, errTokenValidationUnavailable)
+		return false
+	}
+
+	c.Set(ContextUserIDKey, identity.UserID)
+	c.Set(ContextRoleKey, identity.Role)
+	c.Set(ContextScopesKey, identity.Scopes)
+	return true
+}
+
+// ChatAuth authenticates the websocket upgrade when it can and lets anonymous clients through read-only.
+// Bots send "Authorization: Token ..." with the chat:write scope; browsers pass an access token as ?token=.
 func (m *AuthMiddleware) ChatAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Token "); ok && m.tokens != nil {
+			if !m.personalToken(c, token) {
+				return
+			}
+			if !hasScope(c, entity.ScopeChatWrite) {
+				Abort(c, ErrInsufficientScope.WithMessage("token lacks scope "+entity.ScopeChatWrite))
+				return
+			}
+			c.Next()
+			return
+		}
+
 		token := c.Query("token")
 		if token == "" {
 			c.Next()
@@ -79,23 +137,72 @@ func (m *AuthMiddleware) ChatAuth() gin.HandlerFunc {
 
 		var accessClaims AccessClaims
 		mapstructure.Decode(claims, &accessClaims)
-		c.Set(ContextUserIDKey, accessClaims.UserID)
-		c.Set(ContextRoleKey, accessClaims.Role)
+		if accessClaims.Type == jwt.TokenTypeMFA {
+			c.Next()
+			return
+		}
+		setClaims(c, &accessClaims)
+
+		c.Next()
+	}
+}
+
+// RequestID tags the request with the caller's X-Request-ID, or a new one, and echoes it in the response.
+// The ID travels in the request context, so calls to the user service carry it too.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// LocaleCookie holds the locale the user picked, which takes precedence over Accept-Language.
+const LocaleCookie = "locale"
+
+// Locale puts the request's locale into its context for handlers and usecases to translate with. The
+// user's choice in LocaleCookie wins; otherwise the locale is negotiated from Accept-Language.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := ""
+		if preferred, err := c.Cookie(LocaleCookie); err == nil {
+			locale = i18n.Normalize(preferred)
+		}
+		if locale == "" {
+			locale = i18n.Negotiate(c.GetHeader("Accept-Language"))
+		}
+		c.Request = c.Request.WithContext(i18n.NewContext(c.Request.Context(), locale))
+		c.Header("Content-Language", locale)
+		c.Header("Vary", "Accept-Language, Cookie")
 
 		c.Next()
 	}
 }
 
-func RequireAdmin() gin.HandlerFunc {
+func setClaims(c *gin.Context, claims *AccessClaims) {
+	c.Set(ContextUserIDKey, claims.UserID)
+	c.Set(ContextRoleKey, claims.Role)
+	c.Set(ContextPermissionsKey, claims.Permissions)
+	c.Set(ContextModeratedCategoriesKey, claims.ModeratedCategories)
+}
+
+// RequirePermission lets the request through only if the access token grants perm globally.
+// Category-scoped moderator permissions are checked by the usecases, which know the category.
+func RequirePermission(perm string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := GetRoleFromContext(c)
+		actor, exists := GetActorFromContext(c)
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "unauthorized"})
+			Abort(c, ErrUnauthorized)
 			return
 		}
 
-		if role != "admin" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		if !actor.Can(perm) {
+			Abort(c, ErrInsufficientPermissions)
 			return
 		}
 
@@ -103,6 +210,28 @@ func RequireAdmin() gin.HandlerFunc {
 	}
 }
 
+// RequireScope limits requests made with a personal access token to tokens holding scope.
+// Requests made with an access token from an interactive login are not affected.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasScope(c, scope) {
+			Abort(c, ErrInsufficientScope.WithMessage("token lacks scope "+scope))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func hasScope(c *gin.Context, scope string) bool {
+	scopes, ok := c.Get(ContextScopesKey)
+	if !ok {
+		return true
+	}
+	granted, _ := scopes.([]string)
+	return slices.Contains(granted, scope)
+}
+
 func GetUserIDFromContext(c *gin.Context) (int64, bool) {
 	userID, exists := c.Get(ContextUserIDKey)
 	if !exists {
@@ -118,3 +247,20 @@ func GetRoleFromContext(c *gin.Context) (string, bool) {
 	}
 	return role.(string), true
 }
+
+func GetActorFromContext(c *gin.Context) (entity.Actor, bool) {
+	userID, exists := GetUserIDFromContext(c)
+	if !exists {
+		return entity.Actor{}, false
+	}
+
+	actor := entity.Actor{UserID: userID}
+	actor.Role, _ = GetRoleFromContext(c)
+	if perms, ok := c.Get(ContextPermissionsKey); ok {
+		actor.Permissions, _ = perms.([]string)
+	}
+	if categories, ok := c.Get(ContextModeratedCategoriesKey); ok {
+		actor.ModeratedCategories, _ = categories.([]int64)
+	}
+	return actor, true
+}
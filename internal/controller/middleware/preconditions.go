@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrPreconditionRequired is returned for edits sent without If-Match, which would overwrite changes
+// the client has not seen.
+var ErrPreconditionRequired = entity.NewError(entity.KindPreconditionRequired, "precondition_required", "If-Match header is required")
+
+// ETag formats the version of a resource as a strong entity tag.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ContentETag is the ETag of a response that embeds data its version does not cover, such as author
+// profiles from the user service: the version followed by a hash of content, so the tag changes with
+// either. IfMatch reads the version back.
+func ContentETag(version int64, content any) string {
+	body, err := json.Marshal(content)
+	if err != nil {
+		return ETag(version)
+	}
+	sum := sha256.Sum256(body)
+	return `"` + strconv.FormatInt(version, 10) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// SetETag tags the response with the version of the resource it carries.
+func SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", ETag(version))
+}
+
+// IfMatch returns the version named by the request's If-Match header, which must be a single tag made
+// by ETag or ContentETag. It aborts the request and returns false when the header is missing or malformed.
+func IfMatch(c *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		Abort(c, ErrPreconditionRequired)
+		return 0, false
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	tag, _, _ = strings.Cut(tag, "-")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if !ok || err != nil || version <= 0 {
+		Abort(c, InvalidParameter("If-Match header"))
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		status  int
+		version int64
+	}{
+		{"Valid", `"7"`, http.StatusNoContent, 7},
+		{"Content tag", `"7-1f2e3d4c5b6a7988"`, http.StatusNoContent, 7},
+		{"Missing", "", http.StatusPreconditionRequired, 0},
+		{"Unquoted", "7", http.StatusBadRequest, 0},
+		{"Weak", `W/"7"`, http.StatusBadRequest, 0},
+		{"Wildcard", "*", http.StatusBadRequest, 0},
+		{"Zero", `"0"`, http.StatusBadRequest, 0},
+		{"Negative", `"-7"`, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			log := zerolog.Nop()
+			router := gin.New()
+			router.Use(Errors(&log))
+
+			var got int64
+			router.PATCH("/", func(c *gin.Context) {
+				version, ok := IfMatch(c)
+				if !ok {
+					return
+				}
+				got = version
+				c.Status(http.StatusNoContent)
+			})
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPatch, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.version, got)
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"42"`, ETag(42))
+}
+
+func TestContentETag(t *testing.T) {
+	tag := ContentETag(42, map[string]string{"author": "alice"})
+	assert.Regexp(t, `^"42-[0-9a-f]{16}"$`, tag)
+	assert.Equal(t, tag, ContentETag(42, map[string]string{"author": "alice"}))
+	assert.NotEqual(t, tag, ContentETag(42, map[string]string{"author": "bob"}))
+	assert.NotEqual(t, tag, ContentETag(43, map[string]string{"author": "alice"}))
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrValidation is returned for bodies that break the validation tags of their request DTO. Its details
+// list every failing field under "fields".
+var ErrValidation = entity.NewError(entity.KindInvalid, "validation_failed", "request validation failed")
+
+// Normalizer is implemented by request DTOs that clean their fields, such as trimming titles, before
+// they are validated.
+type Normalizer interface {
+	Normalize()
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+	_ = v.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-'
+		}) < 0
+	})
+	_ = v.RegisterValidation("singleline", func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), unicode.IsControl) < 0
+	})
+}
+
+// maxBodyBytes bounds the JSON bodies BindJSON reads; validation tags set the real limits per field.
+const maxBodyBytes = 1 << 20
+
+// BindJSON decodes the body into obj, normalizes it and checks its validation tags. On failure it
+// aborts the request, with field-level messages in the client's language for validation errors, and
+// returns false.
+func BindJSON(c *gin.Context, obj any) bool {
+	if c.Request.Body == nil {
+		Abort(c, ErrInvalidRequest.WithMessage("request body is required"))
+		return false
+	}
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)
+	if err := json.NewDecoder(body).Decode(obj); err != nil {
+		Abort(c, InvalidRequest(err))
+		return false
+	}
+
+	if n, ok := obj.(Normalizer); ok {
+		n.Normalize()
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			Abort(c, InvalidRequest(err))
+			return false
+		}
+		Abort(c, validationError(c.Request.Context(), fieldErrs))
+		return false
+	}
+	return true
+}
+
+func validationError(ctx context.Context, fieldErrs validator.ValidationErrors) error {
+	locale := i18n.FromContext(ctx)
+	fields := make([]response.FieldError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields = append(fields, response.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(locale, fe),
+		})
+	}
+	return ErrValidation.WithMessage(i18n.Translate(locale, i18n.ValidationFailed)).WithDetails(map[string]any{"fields": fields})
+}
+
+// fieldMessage translates the broken rule of fe. Length rules get the message for the kind of value.
+func fieldMessage(locale string, fe validator.FieldError) string {
+	kind := ""
+	switch fe.Tag() {
+	case "min", "max", "len":
+		switch fe.Kind() {
+		case reflect.String:
+			kind = "string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			kind = "list"
+		default:
+			kind = "number"
+		}
+	}
+
+	key := i18n.ValidationRule(fe.Tag(), kind)
+	if kind != "" && fe.Param() == "1" {
+		if format, ok := i18n.Lookup(locale, key+".one"); ok {
+			return fmt.Sprintf(format, fe.Field(), fe.Param())
+		}
+	}
+	if format, ok := i18n.Lookup(locale, key); ok {
+		return fmt.Sprintf(format, fe.Field(), fe.Param())
+	}
+	return i18n.Translate(locale, i18n.ValidationInvalid, fe.Field())
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequest struct {
+	Title string   `json:"title" binding:"required,max=5,singleline"`
+	Name  string   `json:"name" binding:"omitempty,username"`
+	Tags  []string `json:"tags" binding:"max=1"`
+}
+
+func (r *testRequest) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+}
+
+type validationBody struct {
+	Code    string `json:"code"`
+	Error   string `json:"error"`
+	Details struct {
+		Fields []struct {
+			Field   string `json:"field"`
+			Rule    string `json:"rule"`
+			Message string `json:"message"`
+		} `json:"fields"`
+	} `json:"details"`
+}
+
+func serveBind(t *testing.T, body, acceptLanguage string) (*httptest.ResponseRecorder, *testRequest, validationBody) {
+	gin.SetMode(gin.TestMode)
+	log := zerolog.Nop()
+	router := gin.New()
+	router.Use(Locale(), Errors(&log))
+
+	var bound *testRequest
+	router.POST("/", func(c *gin.Context) {
+		var req testRequest
+		if !BindJSON(c, &req) {
+			return
+		}
+		bound = &req
+		c.Status(http.StatusNoContent)
+	})
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	router.ServeHTTP(rr, req)
+
+	var resp validationBody
+	if rr.Code != http.StatusNoContent {
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	}
+	return rr, bound, resp
+}
+
+func TestBindJSON_Valid(t *testing.T) {
+	rr, bound, _ := serveBind(t, `{"title":"  hi  ","name":"иван.p"}`, "")
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "hi", bound.Title)
+}
+
+func TestBindJSON_BlankAfterTrimming(t *testing.T) {
+	rr, bound, resp := serveBind(t, `{"title":"   "}`, "")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Nil(t, bound)
+	assert.Equal(t, "validation_failed", resp.Code)
+	if assert.Len(t, resp.Details.Fields, 1) {
+		assert.Equal(t, "title", resp.Details.Fields[0].Field)
+		assert.Equal(t, "required", resp.Details.Fields[0].Rule)
+		assert.Equal(t, "title is required", resp.Details.Fields[0].Message)
+	}
+}
+
+func TestBindJSON_ReportsEveryField(t *testing.T) {
+	rr, _, resp := serveBind(t, `{"title":"too long","name":"a b","tags":["x","y"]}`, "")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	messages := map[string]string{}
+	for _, f := range resp.Details.Fields {
+		messages[f.Field] = f.Message
+	}
+	assert.Equal(t, map[string]string{
+		"title": "title must be at most 5 characters long",
+		"name":  "name may contain only letters, digits and the characters . _ -",
+		"tags":  "tags must contain at most 1 item",
+	}, messages)
+}
+
+func TestBindJSON_RussianMessages(t *testing.T) {
+	rr, _, resp := serveBind(t, `{"title":"a\nb"}`, "ru-RU,ru;q=0.9,en;q=0.8")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "запрос не прошёл проверку", resp.Error)
+	if assert.Len(t, resp.Details.Fields, 1) {
+		assert.Equal(t, "поле title не должно содержать переводов строки и управляющих символов", resp.Details.Fields[0].Message)
+	}
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	rr, _, resp := serveBind(t, `{"title":`, "")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "invalid_request", resp.Code)
+}
@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type OIDCHandler struct {
+	Usecase usecase.OIDCUsecase
+	Log     *zerolog.Logger
+}
+
+const (
+	oidcLoginOp      = "OIDCHandler.Login"
+	oidcCallbackOp   = "OIDCHandler.Callback"
+	oidcLinkOp       = "OIDCHandler.Link"
+	oidcUnlinkOp     = "OIDCHandler.Unlink"
+	oidcIdentitiesOp = "OIDCHandler.Identities"
+)
+
+// oidcBrowserCookie keeps the nonce that binds an authorization request to the browser that started it.
+const oidcBrowserCookie = "oidc_browser"
+
+var (
+	errOIDCProvider = entity.NewError(entity.KindInvalid, "oidc_provider_error", "identity provider returned an error")
+	errOIDCFailed   = entity.NewError(entity.KindUnauthorized, "oidc_authentication_failed", "oidc authentication failed")
+)
+
+// Login godoc
+// @Summary Start an OIDC login
+// @Description Redirects the browser to the identity provider's authorization endpoint (authorization code flow with PKCE). Sets the oidc_browser cookie the callback checks.
+// @Tags oidc
+// @Param provider path string true "Provider name"
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} response.ErrorResponse "Unknown provider"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", oidcLoginOp).Logger()
+
+	url, browserNonce, err := h.Usecase.AuthURL(c.Request.Context(), c.Param("provider"), 0)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to start oidc login")
+		middleware.Abort(c, err)
+		return
+	}
+
+	setBrowserCookie(c, browserNonce, int(usecase.OIDCStateTTL.Seconds()))
+	c.Redirect(http.StatusFound, url)
+}
+
+// Callback godoc
+// @Summary Complete an OIDC login
+// @Description Handles the redirect back from the identity provider. The oidc_browser cookie must match the one set when the flow started. New external accounts get a user created on first login. Returns the same payload as /login, including the 2FA challenge, and sets the refresh token cookie. A link flow only returns {"message": "identity linked"} and keeps the current session.
+// @Tags oidc
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State returned by the provider"
+// @Success 200 {object} response.LoginSuccessResponse "Successfully logged in"
+// @Success 202 {object} response.MFAChallengeResponse "Second factor required"
+// @Failure 400 {object} response.ErrorResponse "Invalid state, state started in another browser or provider error"
+// @Failure 401 {object} response.ErrorResponse "Authentication with the provider failed"
+// @Failure 403 {object} response.ErrorResponse "User is banned"
+// @Failure 404 {object} response.ErrorResponse "Unknown provider"
+// @Failure 409 {object} response.ErrorResponse "Identity is linked to another account"
+// @Router /oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", oidcCallbackOp).Logger()
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		log.Warn().Str("error", providerErr).Str("description", c.Query("error_description")).Msg("Provider returned an error")
+		middleware.Abort(c, errOIDCProvider.WithMessage(providerErr))
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		middleware.Abort(c, middleware.ErrInvalidRequest.WithMessage("code and state are required"))
+		return
+	}
+
+	// Every callback consumes the state, so the nonce is of no further use either way.
+	browserNonce, _ := c.Cookie(oidcBrowserCookie)
+	setBrowserCookie(c, "", -1)
+
+	res, err := h.Usecase.Callback(c.Request.Context(), c.Param("provider"), code, state, browserNonce)
+	if err != nil {
+		var domainErr *entity.Error
+		switch {
+		case errors.As(err, &domainErr):
+			log.Warn().Err(err).Msg("OIDC login rejected")
+		case errors.Is(err, oidc.ErrInvalidIDToken), errors.Is(err, oidc.ErrNonceMismatch):
+			log.Warn().Err(err).Msg("OIDC authentication failed")
+			err = errOIDCFailed
+		default:
+			log.Error().Err(err).Msg("Failed to complete oidc login")
+			err = errOIDCFailed
+		}
+		middleware.Abort(c, err)
+		return
+	}
+
+	if res.Linked {
+		c.JSON(http.StatusOK, gin.H{"message": "identity linked"})
+		return
+	}
+
+	if res.MFARequired {
+		c.SetCookie("refresh_token", "", -1, "/", "", false, true)
+		c.JSON(http.StatusAccepted, gin.H{"mfa_required": true, "mfa_token": res.MFAToken})
+		return
+	}
+
+	c.SetCookie("refresh_token", res.Tokens.RefreshToken, 3600*24*30, "/", "", false, true)
+
+	c.JSON(http.StatusOK, gin.H{"user": res.User, "access_token": res.Tokens.AccessToken})
+}
+
+// Link godoc
+// @Summary Link an external account
+// @Description Starts an authorization code flow that links the provider account to the current user. The client should navigate to the returned URL in the browser that received the oidc_browser cookie.
+// @Tags oidc
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} response.OIDCAuthURLResponse "Authorization URL"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Unknown provider"
+// @Failure 409 {object} response.ErrorResponse "Provider is already linked"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/identities/{provider} [post]
+func (h *OIDCHandler) Link(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", oidcLinkOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	url, browserNonce, err := h.Usecase.AuthURL(c.Request.Context(), c.Param("provider"), userID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to start oidc link")
+		middleware.Abort(c, err)
+		return
+	}
+
+	setBrowserCookie(c, browserNonce, int(usecase.OIDCStateTTL.Seconds()))
+	c.JSON(http.StatusOK, gin.H{"auth_url": url})
+}
+
+// Unlink godoc
+// @Summary Unlink an external account
+// @Description Removes the link between the current user and the provider account. The last sign-in method of an account without a password cannot be removed.
+// @Tags oidc
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} response.SuccessMessageResponse "Identity unlinked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Identity not found"
+// @Failure 409 {object} response.ErrorResponse "Last sign-in method"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/identities/{provider} [delete]
+func (h *OIDCHandler) Unlink(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", oidcUnlinkOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	if err := h.Usecase.Unlink(c.Request.Context(), userID, c.Param("provider")); err != nil {
+		log.Warn().Err(err).Msg("Failed to unlink identity")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "identity unlinked"})
+}
+
+// Identities godoc
+// @Summary List linked external accounts
+// @Tags oidc
+// @Produce json
+// @Success 200 {array} entity.UserIdentity "Linked identities"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/identities [get]
+func (h *OIDCHandler) Identities(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", oidcIdentitiesOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	identities, err := h.Usecase.Identities(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get identities")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identities)
+}
+
+// setBrowserCookie sets the oidc_browser cookie, or deletes it with a negative maxAge. It is SameSite=Lax
+// so it comes along with the top-level redirect back from the identity provider.
+func setBrowserCookie(c *gin.Context, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcBrowserCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (h *OIDCHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
+	reqLog := h.Log.With().
+		Str("method", c.Request.Method).
+		Str("path", c.Request.URL.Path).
+		Str("remote_addr", c.ClientIP())
+
+	logger := reqLog.Logger()
+	return &logger
+}
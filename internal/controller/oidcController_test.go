@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authresponse "github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCHandler_Login_Redirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/login", handler.Login)
+
+	authURL := "http://idp.local/authorize?state=abc"
+	mockUsecase.On("AuthURL", mock.Anything, "google", int64(0)).Return(authURL, "browser-nonce", nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/google/login", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusFound, rr.Code)
+	assert.Equal(t, authURL, rr.Header().Get("Location"))
+	cookie := findCookie(rr, oidcBrowserCookie)
+	require.NotNil(t, cookie)
+	assert.Equal(t, "browser-nonce", cookie.Value)
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOIDCHandler_Login_UnknownProvider(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/login", handler.Login)
+
+	mockUsecase.On("AuthURL", mock.Anything, "other", int64(0)).Return("", "", usecase.ErrUnknownProvider).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/other/login", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOIDCHandler_Callback_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/callback", handler.Callback)
+
+	expectedTokens := authresponse.Tokens{AccessToken: "access_token", RefreshToken: "refresh_token"}
+	expectedResponse := &authresponse.LoginResponse{
+		User:   entity.User{ID: 1, Username: "user", Role: "user"},
+		Tokens: expectedTokens,
+	}
+	mockUsecase.On("Callback", mock.Anything, "google", "code", "state", "browser-nonce").Return(expectedResponse, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/google/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: oidcBrowserCookie, Value: "browser-nonce"})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var respBody map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTokens.AccessToken, respBody["access_token"])
+	refresh := findCookie(rr, "refresh_token")
+	require.NotNil(t, refresh)
+	assert.Equal(t, expectedTokens.RefreshToken, refresh.Value)
+	browser := findCookie(rr, oidcBrowserCookie)
+	require.NotNil(t, browser)
+	assert.Negative(t, browser.MaxAge)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOIDCHandler_Callback_InvalidState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/callback", handler.Callback)
+
+	mockUsecase.On("Callback", mock.Anything, "google", "code", "stale", "").Return(nil, usecase.ErrInvalidOIDCState).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/google/callback?code=code&state=stale", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Nil(t, findCookie(rr, "refresh_token"))
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOIDCHandler_Callback_Link(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/callback", handler.Callback)
+
+	mockUsecase.On("Callback", mock.Anything, "google", "code", "state", "browser-nonce").Return(&authresponse.LoginResponse{Linked: true}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/google/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: oidcBrowserCookie, Value: "browser-nonce"})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"message": "identity linked"}`, rr.Body.String())
+	assert.Nil(t, findCookie(rr, "refresh_token"))
+}
+
+func TestOIDCHandler_Callback_ProviderError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewOIDCUsecase(t)
+	log := zerolog.Nop()
+	handler := &OIDCHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/oidc/:provider/callback", handler.Callback)
+
+	req, _ := http.NewRequest(http.MethodGet, "/oidc/google/callback?error=access_denied&state=state", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockUsecase.AssertNotCalled(t, "Callback", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func findCookie(rr *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range rr.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type PersonalTokenHandler struct {
+	Usecase usecase.PersonalTokenUsecase
+	Log     *zerolog.Logger
+}
+
+const (
+	createPersonalTokenOp = "PersonalTokenHandler.Create"
+	listPersonalTokensOp  = "PersonalTokenHandler.List"
+	revokePersonalTokenOp = "PersonalTokenHandler.Revoke"
+)
+
+// Create godoc
+// @Summary Create a personal access token
+// @Description Creates a token for bots and scripts, sent to the forum as "Authorization: Token <token>". The token is shown only in this response. Scopes: posts:write, topics:write, chat:write.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Param request body request.CreatePersonalTokenRequest true "Token name, scopes and optional expiry"
+// @Success 201 {object} response.PersonalTokenCreatedResponse "Token created"
+// @Failure 400 {object} response.ErrorResponse "Invalid payload, unknown scope or expiry in the past"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/tokens [post]
+func (h *PersonalTokenHandler) Create(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", createPersonalTokenOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	var req request.CreatePersonalTokenRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	token, plain, err := h.Usecase.Create(c.Request.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create personal token")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"token": plain, "personal_token": token})
+}
+
+// List godoc
+// @Summary List personal access tokens
+// @Description Lists the caller's tokens with their scopes, expiry and last use. The tokens themselves are not returned.
+// @Tags tokens
+// @Produce json
+// @Success 200 {object} response.PersonalTokensResponse "Tokens"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/tokens [get]
+func (h *PersonalTokenHandler) List(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", listPersonalTokensOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	tokens, err := h.Usecase.List(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list personal tokens")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// Revoke godoc
+// @Summary Revoke a personal access token
+// @Description Deletes one of the caller's tokens. The forum may accept it for up to 30 more seconds while its validation cache expires.
+// @Tags tokens
+// @Produce json
+// @Param id path int true "Token ID" Format(int64)
+// @Success 200 {object} response.SuccessMessageResponse "Token revoked"
+// @Failure 400 {object} response.ErrorResponse "Invalid token ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Token not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/tokens/{id} [delete]
+func (h *PersonalTokenHandler) Revoke(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", revokePersonalTokenOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("token id"))
+		return
+	}
+
+	if err := h.Usecase.Revoke(c.Request.Context(), userID, id); err != nil {
+		log.Error().Err(err).Int64("token_id", id).Msg("Failed to revoke personal token")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "token revoked"})
+}
+
+func (h *PersonalTokenHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
+	reqLog := h.Log.With().
+		Str("method", c.Request.Method).
+		Str("path", c.Request.URL.Path).
+		Str("remote_addr", c.ClientIP())
+
+	logger := reqLog.Logger()
+	return &logger
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPersonalTokenRouter(t *testing.T, userID int64) (*gin.Engine, *mocks.PersonalTokenUsecase) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewPersonalTokenUsecase(t)
+	log := zerolog.Nop()
+	handler := &PersonalTokenHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, userID)
+		c.Next()
+	})
+	router.GET("/tokens", handler.List)
+	router.POST("/tokens", handler.Create)
+	router.DELETE("/tokens/:id", handler.Revoke)
+	return router, mockUsecase
+}
+
+func TestPersonalTokenHandler_Create_Success(t *testing.T) {
+	router, mockUsecase := newPersonalTokenRouter(t, 7)
+
+	token := &entity.PersonalToken{ID: 1, UserID: 7, Name: "bot", Prefix: "fgo_abcdefgh", Scopes: []string{entity.ScopePostsWrite}}
+	mockUsecase.On("Create", mock.Anything, int64(7), "bot", []string{entity.ScopePostsWrite}, (*time.Time)(nil)).
+		Return(token, "fgo_abcdefghsecret", nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/tokens", strings.NewReader(`{"name":"bot","scopes":["posts:write"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"token":"fgo_abcdefghsecret"`)
+	assert.Contains(t, rr.Body.String(), `"prefix":"fgo_abcdefgh"`)
+}
+
+func TestPersonalTokenHandler_Create_NoScopes(t *testing.T) {
+	router, mockUsecase := newPersonalTokenRouter(t, 7)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tokens", strings.NewReader(`{"name":"bot","scopes":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPersonalTokenHandler_Create_UnknownScope(t *testing.T) {
+	router, mockUsecase := newPersonalTokenRouter(t, 7)
+
+	mockUsecase.On("Create", mock.Anything, int64(7), "bot", []string{"admin"}, (*time.Time)(nil)).
+		Return(nil, "", usecase.ErrUnknownScope).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/tokens", strings.NewReader(`{"name":"bot","scopes":["admin"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestPersonalTokenHandler_Revoke_NotFound(t *testing.T) {
+	router, mockUsecase := newPersonalTokenRouter(t, 7)
+
+	mockUsecase.On("Revoke", mock.Anything, int64(7), int64(3)).Return(usecase.ErrPersonalTokenMissing).Once()
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tokens/3", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(scopes []string) *gin.Engine {
+		router := newTestRouter()
+		router.Use(func(c *gin.Context) {
+			if scopes != nil {
+				c.Set(middleware.ContextScopesKey, scopes)
+			}
+			c.Next()
+		})
+		router.POST("/posts", middleware.RequireScope(entity.ScopePostsWrite), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		return router
+	}
+
+	tests := []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{"session without scopes", nil, http.StatusNoContent},
+		{"token with scope", []string{entity.ScopePostsWrite}, http.StatusNoContent},
+		{"token without scope", []string{entity.ScopeChatWrite}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/posts", nil)
+			rr := httptest.NewRecorder()
+			newRouter(tt.scopes).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type ProfileHandler struct {
+	Usecase usecase.ProfileUsecase
+	Log     *zerolog.Logger
+}
+
+const (
+	getProfileOp    = "ProfileHandler.Get"
+	updateProfileOp = "ProfileHandler.Update"
+)
+
+// Get godoc
+// @Summary Get own profile
+// @Description Returns the caller's profile as other users see it next to their topics and posts.
+// @Tags profile
+// @Produce json
+// @Success 200 {object} response.ProfileResponse "Profile"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/profile [get]
+func (h *ProfileHandler) Get(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", getProfileOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	profile, err := h.Usecase.Get(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to get profile")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// Update godoc
+// @Summary Update own profile
+// @Description Changes the fields present in the body; omitted fields keep their value. Display name is limited to 64 characters, bio to 1000 and signature to 300. The avatar URL must be an absolute http(s) URL, or empty to remove the avatar.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param request body request.UpdateProfileRequest true "Profile fields to change"
+// @Success 200 {object} response.ProfileResponse "Updated profile"
+// @Failure 400 {object} response.ErrorResponse "Invalid payload or profile field"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /me/profile [patch]
+func (h *ProfileHandler) Update(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", updateProfileOp).Logger()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		middleware.Abort(c, middleware.ErrUnauthorized)
+		return
+	}
+
+	var req request.UpdateProfileRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	profile, err := h.Usecase.Update(c.Request.Context(), userID, entity.ProfileUpdate{
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		AvatarURL:   req.AvatarURL,
+		Signature:   req.Signature,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to update profile")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+func (h *ProfileHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
+	reqLog := h.Log.With().
+		Str("method", c.Request.Method).
+		Str("path", c.Request.URL.Path).
+		Str("remote_addr", c.ClientIP())
+
+	logger := reqLog.Logger()
+	return &logger
+}
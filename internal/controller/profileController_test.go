@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newProfileRouter(t *testing.T, userID int64) (*gin.Engine, *mocks.ProfileUsecase) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewProfileUsecase(t)
+	log := zerolog.Nop()
+	handler := &ProfileHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, userID)
+		c.Next()
+	})
+	router.GET("/me/profile", handler.Get)
+	router.PATCH("/me/profile", handler.Update)
+	return router, mockUsecase
+}
+
+func TestProfileHandler_Get_Success(t *testing.T) {
+	router, mockUsecase := newProfileRouter(t, 7)
+
+	mockUsecase.On("Get", mock.Anything, int64(7)).Return(&entity.UserProfile{UserID: 7, Username: "bob", Bio: "hi"}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/me/profile", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"bio":"hi"`)
+}
+
+func TestProfileHandler_Update_PartialFields(t *testing.T) {
+	router, mockUsecase := newProfileRouter(t, 7)
+
+	mockUsecase.On("Update", mock.Anything, int64(7), mock.MatchedBy(func(u entity.ProfileUpdate) bool {
+		return u.DisplayName == nil && u.Bio == nil && u.AvatarURL == nil && u.Signature != nil && *u.Signature == "-- bob"
+	})).Return(&entity.UserProfile{UserID: 7, Username: "bob", Signature: "-- bob"}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/me/profile", strings.NewReader(`{"signature":"-- bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"signature":"-- bob"`)
+}
+
+func TestProfileHandler_Update_Invalid(t *testing.T) {
+	router, mockUsecase := newProfileRouter(t, 7)
+
+	mockUsecase.On("Update", mock.Anything, int64(7), mock.Anything).Return(nil, usecase.ErrInvalidProfile).Once()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/me/profile", strings.NewReader(`{"display_name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestProfileHandler_Update_AvatarURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		avatar string
+		valid  bool
+	}{
+		{name: "HTTPS", avatar: "https://example.com/avatar.png", valid: true},
+		{name: "Padded", avatar: "  http://example.com/avatar.png ", valid: true},
+		{name: "Empty removes the avatar", avatar: "", valid: true},
+		{name: "Script scheme", avatar: "javascript:alert(1)"},
+		{name: "Data scheme", avatar: "data:image/png;base64,AAAA"},
+		{name: "FTP scheme", avatar: "ftp://example.com/avatar.png"},
+		{name: "Relative", avatar: "/avatar.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockUsecase := newProfileRouter(t, 7)
+			if tt.valid {
+				mockUsecase.On("Update", mock.Anything, int64(7), mock.Anything).Return(&entity.UserProfile{UserID: 7}, nil).Once()
+			}
+
+			req, _ := http.NewRequest(http.MethodPatch, "/me/profile", strings.NewReader(`{"avatar_url":"`+tt.avatar+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if tt.valid {
+				assert.Equal(t, http.StatusOK, rr.Code)
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"rule":"http_url"`)
+			mockUsecase.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
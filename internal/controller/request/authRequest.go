@@ -1,12 +1,82 @@
 package request
 
+import (
+	"strings"
+	"time"
+)
+
 type RegisterRequest struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required,min=3,max=40,username" example:"ivan_petrov"`
+	Password string `json:"password" binding:"required,max=1024"`
+}
+
+func (r *RegisterRequest) Normalize() {
+	r.Username = strings.TrimSpace(r.Username)
 }
 
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required,max=40"`
+	Password string `json:"password" binding:"required,max=1024"`
+}
+
+func (r *LoginRequest) Normalize() {
+	r.Username = strings.TrimSpace(r.Username)
+}
+
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required,max=4096"`
+	Code     string `json:"code" binding:"required,max=32"`
+}
+
+type TOTPConfirmRequest struct {
+	Code string `json:"code" binding:"required,numeric,len=6" example:"123456"`
+}
+
+type TOTPDisableRequest struct {
+	Password string `json:"password" binding:"required,max=1024"`
+}
+
+type AddModeratorRequest struct {
+	UserID int64 `json:"user_id" binding:"required,gt=0"`
+}
+
+// BanRequest bans a user. Leaving expires_at out makes the ban permanent.
+type BanRequest struct {
+	Reason    string     `json:"reason" binding:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r *BanRequest) Normalize() {
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,max=32"`
+}
+
+// CreatePersonalTokenRequest creates a personal access token. Leaving expires_at out makes the token live until revoked.
+type CreatePersonalTokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=64,singleline"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,max=16,dive,required,max=64"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r *CreatePersonalTokenRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// UpdateProfileRequest changes the caller's profile. Omitted fields are left unchanged, and an empty
+// avatar URL removes the avatar.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name" binding:"omitempty,max=64,singleline" example:"Ivan"`
+	Bio         *string `json:"bio" binding:"omitempty,max=1000"`
+	AvatarURL   *string `json:"avatar_url" binding:"omitzero,max=2048,http_url" example:"https://example.com/avatar.png"`
+	Signature   *string `json:"signature" binding:"omitempty,max=300"`
+}
+
+func (r *UpdateProfileRequest) Normalize() {
+	if r.AvatarURL != nil {
+		avatar := strings.TrimSpace(*r.AvatarURL)
+		r.AvatarURL = &avatar
+	}
 }
@@ -1,14 +1,57 @@
 package request
 
+import "strings"
+
+type CreateRequestCategory struct {
+	Title       string `json:"title" binding:"required,max=100,singleline" example:"General"`
+	Description string `json:"description" binding:"max=1000" example:"Anything that fits nowhere else"`
+}
+
+func (r *CreateRequestCategory) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Description = strings.TrimSpace(r.Description)
+}
+
 type UpdateRequestCategory struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Title       string `json:"title" binding:"required,max=100,singleline"`
+	Description string `json:"description" binding:"max=1000"`
 }
 
-type UpdateRequestPost struct {
-	Content string `json:"content"`
+func (r *UpdateRequestCategory) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Description = strings.TrimSpace(r.Description)
+}
+
+type CreateRequestTopic struct {
+	Title string `json:"title" binding:"required,max=200,singleline" example:"How do I reset my password?"`
+}
+
+func (r *CreateRequestTopic) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
 }
 
 type UpdateRequestTopic struct {
-	Title string `json:"title"`
+	Title string `json:"title" binding:"required,max=200,singleline"`
+}
+
+func (r *UpdateRequestTopic) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+}
+
+// CreateRequestPost adds a post to a topic. reply_to is the ID of the post being answered, if any.
+type CreateRequestPost struct {
+	Content string `json:"content" binding:"required,max=20000"`
+	ReplyTo *int64 `json:"reply_to" binding:"omitempty,gt=0"`
+}
+
+func (r *CreateRequestPost) Normalize() {
+	r.Content = strings.TrimSpace(r.Content)
+}
+
+type UpdateRequestPost struct {
+	Content string `json:"content" binding:"required,max=20000"`
+}
+
+func (r *UpdateRequestPost) Normalize() {
+	r.Content = strings.TrimSpace(r.Content)
 }
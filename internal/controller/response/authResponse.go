@@ -18,8 +18,12 @@ type RegisterSuccessResponse struct {
 }
 
 type LoginResponse struct {
-	User   entity.User `json:"user"`
-	Tokens Tokens      `json:"tokens"`
+	User        entity.User `json:"user"`
+	Tokens      Tokens      `json:"tokens"`
+	MFARequired bool        `json:"mfa_required"`
+	MFAToken    string      `json:"mfa_token,omitempty"`
+	// Linked reports a completed OIDC link, which returns neither a user nor tokens.
+	Linked bool `json:"linked,omitempty"`
 }
 
 type LoginSuccessResponse struct {
@@ -27,6 +31,24 @@ type LoginSuccessResponse struct {
 	AccessToken string      `json:"access_token"`
 }
 
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+type TOTPEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri" example:"otpauth://totp/Forum_go:user?secret=...&issuer=Forum_go"`
+}
+
+type TOTPConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type OIDCAuthURLResponse struct {
+	AuthURL string `json:"auth_url" example:"https://accounts.example.com/authorize?client_id=...&state=..."`
+}
+
 type RefreshResponse struct {
 	Tokens Tokens `json:"tokens"`
 }
@@ -44,6 +66,40 @@ type IsSessionActiveResponse struct {
 	IsActive bool         `json:"is_active"`
 }
 
-type ErrorResponseAuth struct {
-	Error string `json:"error" example:"error message"`
+type ModeratorsResponse struct {
+	Moderators []entity.User `json:"moderators"`
+}
+
+type ModeratorMessageResponse struct {
+	Message string `json:"message" example:"moderator added"`
+}
+
+type UsersPageResponse struct {
+	Users []entity.User `json:"users"`
+	Total int64         `json:"total"`
+}
+
+type UserDetailsResponse struct {
+	User entity.UserDetails `json:"user"`
+}
+
+type BanResponse struct {
+	Ban entity.Ban `json:"ban"`
+}
+
+type AuditLogResponse struct {
+	Entries []entity.AuditEntry `json:"entries"`
+}
+
+type PersonalTokenCreatedResponse struct {
+	Token         string               `json:"token" example:"fgo_3k7q..."`
+	PersonalToken entity.PersonalToken `json:"personal_token"`
+}
+
+type PersonalTokensResponse struct {
+	Tokens []entity.PersonalToken `json:"tokens"`
+}
+
+type ProfileResponse struct {
+	Profile entity.UserProfile `json:"profile"`
 }
@@ -0,0 +1,18 @@
+package response
+
+// ErrorResponse is the body of every error reply from the auth and forum services. Code is stable and
+// meant for programs; Error is a human-readable message. Details holds extra fields for some codes.
+type ErrorResponse struct {
+	Code      string         `json:"code" example:"topic_not_found"`
+	Error     string         `json:"error" example:"topic not found"`
+	RequestID string         `json:"request_id" example:"4f1c2b7e9a0d4e8f"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// FieldError describes one field of a request that failed validation. Rule is the name of the broken
+// rule, such as "required" or "max".
+type FieldError struct {
+	Field   string `json:"field" example:"title"`
+	Rule    string `json:"rule" example:"max"`
+	Message string `json:"message" example:"title must be at most 200 characters long"`
+}
@@ -2,10 +2,6 @@ package response
 
 import "github.com/Van-programan/Forum_GO/internal/entity"
 
-type ErrorResponseForum struct {
-	Error string `json:"error" example:"error message"`
-}
-
 type SuccessMessageResponse struct {
 	Message string `json:"message" example:"operation was successful"`
 }
@@ -22,14 +18,27 @@ type CategoriesResponse struct {
 	Categories []entity.Category `json:"categories"`
 }
 
+// Degraded is only present, as true, when author profiles were replaced by placeholders.
 type TopicResponse struct {
-	Topic entity.Topic `json:"topic"`
+	Topic    entity.Topic `json:"topic"`
+	Degraded bool         `json:"degraded,omitempty"`
 }
 
 type TopicsResponse struct {
-	Topics []entity.Topic `json:"topics"`
+	Topics   []entity.Topic `json:"topics"`
+	Degraded bool           `json:"degraded,omitempty"`
+}
+
+type PostResponse struct {
+	Post     entity.Post `json:"post"`
+	Degraded bool        `json:"degraded,omitempty"`
 }
 
 type PostsResponse struct {
-	Posts []entity.Post `json:"posts"`
+	Posts    []entity.Post `json:"posts"`
+	Degraded bool          `json:"degraded,omitempty"`
+}
+
+type CacheStatsResponse struct {
+	Caches []entity.CacheStats `json:"caches"`
 }
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/controller/request"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type ModeratorHandler struct {
+	Usecase usecase.ModeratorUsecase
+	Log     *zerolog.Logger
+}
+
+const (
+	getModeratorsOp   = "ModeratorHandler.GetModerators"
+	addModeratorOp    = "ModeratorHandler.AddModerator"
+	removeModeratorOp = "ModeratorHandler.RemoveModerator"
+)
+
+// GetModerators godoc
+// @Summary List category moderators
+// @Description Requires the category.manage permission.
+// @Tags moderators
+// @Produce json
+// @Param id path int true "Category ID" Format(int64)
+// @Success 200 {object} response.ModeratorsResponse "Moderators of the category"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing category.manage permission"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /categories/{id}/moderators [get]
+func (h *ModeratorHandler) GetModerators(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", getModeratorsOp).Logger()
+
+	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
+		return
+	}
+
+	moderators, err := h.Usecase.GetModerators(c.Request.Context(), categoryID)
+	if err != nil {
+		log.Error().Err(err).Int64("category_id", categoryID).Msg("Failed to get moderators")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"moderators": moderators})
+}
+
+// AddModerator godoc
+// @Summary Add a category moderator
+// @Description Grants a user moderator rights in a category. Requires the category.manage permission. The rights apply from the user's next token refresh.
+// @Tags moderators
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID" Format(int64)
+// @Param request body request.AddModeratorRequest true "User to promote"
+// @Success 200 {object} response.ModeratorMessageResponse "Moderator added"
+// @Failure 400 {object} response.ErrorResponse "Invalid category ID or request payload"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing category.manage permission"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /categories/{id}/moderators [post]
+func (h *ModeratorHandler) AddModerator(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", addModeratorOp).Logger()
+
+	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
+		return
+	}
+
+	var req request.AddModeratorRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.Usecase.AddModerator(c.Request.Context(), categoryID, req.UserID); err != nil {
+		log.Error().Err(err).Int64("category_id", categoryID).Int64("user_id", req.UserID).Msg("Failed to add moderator")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "moderator added"})
+}
+
+// RemoveModerator godoc
+// @Summary Remove a category moderator
+// @Description Requires the category.manage permission.
+// @Tags moderators
+// @Produce json
+// @Param id path int true "Category ID" Format(int64)
+// @Param user_id path int true "User ID" Format(int64)
+// @Success 200 {object} response.ModeratorMessageResponse "Moderator removed"
+// @Failure 400 {object} response.ErrorResponse "Invalid category or user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Missing category.manage permission"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /categories/{id}/moderators/{user_id} [delete]
+func (h *ModeratorHandler) RemoveModerator(c *gin.Context) {
+	log := h.getRequestLogger(c).With().Str("op", removeModeratorOp).Logger()
+
+	categoryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("category id"))
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		middleware.Abort(c, middleware.InvalidParameter("user id"))
+		return
+	}
+
+	if err := h.Usecase.RemoveModerator(c.Request.Context(), categoryID, userID); err != nil {
+		log.Error().Err(err).Int64("category_id", categoryID).Int64("user_id", userID).Msg("Failed to remove moderator")
+		middleware.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "moderator removed"})
+}
+
+func (h *ModeratorHandler) getRequestLogger(c *gin.Context) *zerolog.Logger {
+	reqLog := h.Log.With().
+		Str("method", c.Request.Method).
+		Str("path", c.Request.URL.Path).
+		Str("remote_addr", c.ClientIP())
+
+	logger := reqLog.Logger()
+	return &logger
+}
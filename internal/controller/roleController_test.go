@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/usecase"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModeratorHandler_GetModerators_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewModeratorUsecase(t)
+	log := zerolog.Nop()
+	handler := &ModeratorHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.GET("/categories/:id/moderators", handler.GetModerators)
+
+	mockUsecase.On("GetModerators", mock.Anything, int64(3)).Return([]entity.User{{ID: 7, Username: "bob"}}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories/3/moderators", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"username":"bob"`)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestModeratorHandler_AddModerator_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewModeratorUsecase(t)
+	log := zerolog.Nop()
+	handler := &ModeratorHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/categories/:id/moderators", handler.AddModerator)
+
+	mockUsecase.On("AddModerator", mock.Anything, int64(3), int64(7)).Return(nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/categories/3/moderators", strings.NewReader(`{"user_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestModeratorHandler_AddModerator_UserNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewModeratorUsecase(t)
+	log := zerolog.Nop()
+	handler := &ModeratorHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.POST("/categories/:id/moderators", handler.AddModerator)
+
+	mockUsecase.On("AddModerator", mock.Anything, int64(3), int64(7)).Return(usecase.ErrUserNotFound).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/categories/3/moderators", strings.NewReader(`{"user_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestModeratorHandler_RemoveModerator_InvalidUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter()
+	mockUsecase := mocks.NewModeratorUsecase(t)
+	log := zerolog.Nop()
+	handler := &ModeratorHandler{
+		Usecase: mockUsecase,
+		Log:     &log,
+	}
+	router.DELETE("/categories/:id/moderators/:user_id", handler.RemoveModerator)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/categories/3/moderators/abc", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockUsecase.AssertNotCalled(t, "RemoveModerator", mock.Anything, mock.Anything, mock.Anything)
+}
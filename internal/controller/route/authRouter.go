@@ -1,45 +1,108 @@
 package route
 
 import (
+	"net/http"
 	"time"
 
+	docs "github.com/Van-programan/Forum_GO/docs/v1"
 	"github.com/Van-programan/Forum_GO/internal/controller"
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
-	_ "github.com/Van-programan/Forum_GO/docs"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func NewAuthRouter(engine *gin.Engine, usecase usecase.AuthUsecase, jwt *jwt.JWT, log *zerolog.Logger) {
+func NewAuthRouter(engine *gin.Engine, usecase usecase.AuthUsecase, oidcUsecase usecase.OIDCUsecase, moderatorUsecase usecase.ModeratorUsecase, adminUsecase usecase.AdminUsecase, tokenUsecase usecase.PersonalTokenUsecase, profileUsecase usecase.ProfileUsecase, jwt *jwt.JWT, log *zerolog.Logger) {
 	h := &controller.AuthHandler{
 		Usecase: usecase,
 		Log:     log,
 	}
+	oh := &controller.OIDCHandler{
+		Usecase: oidcUsecase,
+		Log:     log,
+	}
+	mh := &controller.ModeratorHandler{
+		Usecase: moderatorUsecase,
+		Log:     log,
+	}
+	ah := &controller.AdminHandler{
+		Usecase: adminUsecase,
+		Log:     log,
+	}
+	th := &controller.PersonalTokenHandler{
+		Usecase: tokenUsecase,
+		Log:     log,
+	}
+	ph := &controller.ProfileHandler{
+		Usecase: profileUsecase,
+		Log:     log,
+	}
 
-	docs.SwaggerInfo.Title = "Forum Service API"
-	docs.SwaggerInfo.Description = "API for forum service"
-	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Host = "localhost:3100"
-	docs.SwaggerInfo.BasePath = "/"
-	docs.SwaggerInfo.Schemes = []string{"http"}
+	docs.SwaggerInfov1.Title = "Forum Service API"
+	docs.SwaggerInfov1.Description = "API for forum service"
+	docs.SwaggerInfov1.Version = "1.0"
+	docs.SwaggerInfov1.Host = "localhost:3100"
+	docs.SwaggerInfov1.BasePath = APIv1
+	docs.SwaggerInfov1.Schemes = []string{"http"}
 
 	engine.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:3100"},
-		AllowMethods:     []string{"POST"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", requestid.Header},
+		ExposeHeaders:    []string{requestid.Header, "Deprecation", "Sunset", "Link"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
+	engine.Use(middleware.RequestID(), middleware.Locale(), middleware.Errors(log))
+
+	auth := middleware.NewAuthMiddleware(jwt, nil)
+	authenticated := []gin.HandlerFunc{auth.Auth()}
+	manageCategories := []gin.HandlerFunc{auth.Auth(), middleware.RequirePermission(entity.PermCategoryManage)}
+	manageUsers := []gin.HandlerFunc{auth.Auth(), middleware.RequirePermission(entity.PermUserManage)}
+
+	register(engine, APIv1, []route{
+		{http.MethodPost, "/auth/register", "/register", []gin.HandlerFunc{h.Register}},
+		{http.MethodPost, "/auth/login", "/login", []gin.HandlerFunc{h.Login}},
+		{http.MethodPost, "/auth/login/mfa", "/login/mfa", []gin.HandlerFunc{h.LoginMFA}},
+		{http.MethodPost, "/auth/refresh", "/refresh", []gin.HandlerFunc{h.Refresh}},
+		{http.MethodPost, "/auth/logout", "/logout", []gin.HandlerFunc{h.Logout}},
+		{http.MethodGet, "/auth/session", "/check-session", []gin.HandlerFunc{h.CheckSession}},
+
+		{http.MethodGet, "/oidc/:provider/login", "/oidc/:provider/login", []gin.HandlerFunc{oh.Login}},
+		{http.MethodGet, "/oidc/:provider/callback", "/oidc/:provider/callback", []gin.HandlerFunc{oh.Callback}},
+
+		{http.MethodPost, "/me/mfa/totp/enroll", "/mfa/totp/enroll", with(authenticated, h.EnrollTOTP)},
+		{http.MethodPost, "/me/mfa/totp/confirm", "/mfa/totp/confirm", with(authenticated, h.ConfirmTOTP)},
+		{http.MethodPost, "/me/mfa/totp/disable", "/mfa/totp/disable", with(authenticated, h.DisableTOTP)},
+		{http.MethodGet, "/me/profile", "/me/profile", with(authenticated, ph.Get)},
+		{http.MethodPatch, "/me/profile", "/me/profile", with(authenticated, ph.Update)},
+		{http.MethodGet, "/me/tokens", "/tokens", with(authenticated, th.List)},
+		{http.MethodPost, "/me/tokens", "/tokens", with(authenticated, th.Create)},
+		{http.MethodDelete, "/me/tokens/:id", "/tokens/:id", with(authenticated, th.Revoke)},
+		{http.MethodGet, "/me/identities", "/oidc/identities", with(authenticated, oh.Identities)},
+		{http.MethodPost, "/me/identities/:provider", "/oidc/:provider/link", with(authenticated, oh.Link)},
+		{http.MethodDelete, "/me/identities/:provider", "/oidc/:provider", with(authenticated, oh.Unlink)},
+
+		{http.MethodGet, "/categories/:id/moderators", "/categories/:id/moderators", with(manageCategories, mh.GetModerators)},
+		{http.MethodPost, "/categories/:id/moderators", "/categories/:id/moderators", with(manageCategories, mh.AddModerator)},
+		{http.MethodDelete, "/categories/:id/moderators/:user_id", "/categories/:id/moderators/:user_id", with(manageCategories, mh.RemoveModerator)},
 
-	engine.POST("/register", h.Register)
-	engine.POST("/login", h.Login)
-	engine.POST("/refresh", h.Refresh)
-	engine.POST("/logout", h.Logout)
-	engine.GET("/check-session", h.CheckSession)
+		{http.MethodGet, "/admin/users", "/admin/users", with(manageUsers, ah.ListUsers)},
+		{http.MethodGet, "/admin/users/:id", "/admin/users/:id", with(manageUsers, ah.GetUser)},
+		{http.MethodPatch, "/admin/users/:id/role", "/admin/users/:id/role", with(manageUsers, ah.ChangeRole)},
+		{http.MethodPost, "/admin/users/:id/logout", "/admin/users/:id/logout", with(manageUsers, ah.ForceLogout)},
+		{http.MethodPost, "/admin/users/:id/ban", "/admin/users/:id/ban", with(manageUsers, ah.Ban)},
+		{http.MethodDelete, "/admin/users/:id/ban", "/admin/users/:id/ban", with(manageUsers, ah.Unban)},
+		{http.MethodGet, "/admin/audit", "/admin/audit", with(manageUsers, ah.AuditLog)},
+	})
 
-	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	swagger := ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName(docs.SwaggerInfov1.InstanceName()))
+	engine.GET(APIv1+"/swagger/*any", swagger)
+	engine.GET("/swagger/*any", swagger)
 }
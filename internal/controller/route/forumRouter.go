@@ -1,34 +1,38 @@
 package route
 
 import (
+	"net/http"
 	"time"
 
+	docs "github.com/Van-programan/Forum_GO/docs/v1"
 	"github.com/Van-programan/Forum_GO/internal/client"
 	"github.com/Van-programan/Forum_GO/internal/controller"
 	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
 	"github.com/Van-programan/Forum_GO/internal/ws"
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/requestid"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	_ "github.com/Van-programan/Forum_GO/docs" // swagger docs
 )
 
 func NewForumRouter(engine *gin.Engine, categoryUsecase usecase.CategoryUsecase,
 	topicUsecase usecase.TopicUsecase, postUsecase usecase.PostUsecase,
 	jwt *jwt.JWT, log *zerolog.Logger, hub *ws.Hub, chatUsecase usecase.ChatUsecase,
-	userClient client.UserClient) {
+	userClient client.UserClient, idempotencyStore middleware.IdempotencyStore, idempotencyCfg middleware.IdempotencyConfig,
+	caches []controller.CacheStatsReporter) {
 
 	// Initialize Swagger info
-	docs.SwaggerInfo.Title = "Forum Service API"
-	docs.SwaggerInfo.Description = "API for forum service"
-	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Host = "localhost:3101"
-	docs.SwaggerInfo.BasePath = "/"
-	docs.SwaggerInfo.Schemes = []string{"http"}
+	docs.SwaggerInfov1.Title = "Forum Service API"
+	docs.SwaggerInfov1.Description = "API for forum service"
+	docs.SwaggerInfov1.Version = "1.0"
+	docs.SwaggerInfov1.Host = "localhost:3101"
+	docs.SwaggerInfov1.BasePath = APIv1
+	docs.SwaggerInfov1.Schemes = []string{"http"}
 
 	categoryHandler := &controller.CategoryHandler{
 		Usecase: categoryUsecase,
@@ -39,51 +43,58 @@ func NewForumRouter(engine *gin.Engine, categoryUsecase usecase.CategoryUsecase,
 		Log:     log,
 	}
 	postHandler := &controller.PostHandler{Usecase: postUsecase}
-	auth := middleware.NewAuthMiddleware(jwt)
+	cacheHandler := &controller.CacheHandler{Caches: caches}
+	auth := middleware.NewAuthMiddleware(jwt, userClient)
 	chatHandler := controller.NewChatHandler(hub, chatUsecase, userClient, log)
 
 	engine.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "If-Match", "If-None-Match", "If-Modified-Since", middleware.IdempotencyKeyHeader, requestid.Header},
+		ExposeHeaders:    []string{requestid.Header, "Deprecation", "Sunset", "Link", "ETag", middleware.IdempotentReplayedHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
+	engine.Use(middleware.RequestID(), middleware.Locale(), middleware.Errors(log))
 
-	engine.GET("/ws", auth.ChatAuth(), chatHandler.ServeWs)
+	manageCategories := []gin.HandlerFunc{auth.Auth(), middleware.RequirePermission(entity.PermCategoryManage)}
+	writeTopics := []gin.HandlerFunc{auth.Auth(), middleware.RequireScope(entity.ScopeTopicsWrite)}
+	writePosts := []gin.HandlerFunc{auth.Auth(), middleware.RequireScope(entity.ScopePostsWrite)}
+	// Creating topics and posts is retried by flaky clients, so those requests accept an Idempotency-Key.
+	idempotent := middleware.Idempotency(idempotencyStore, idempotencyCfg, log)
+	createTopics := with(writeTopics, idempotent)
+	createPosts := with(writePosts, idempotent)
+	// Categories change rarely and may be reused for a while; topics and posts are revalidated each time.
+	cacheCategories := []gin.HandlerFunc{middleware.CacheControl(middleware.CacheShortLived)}
+	revalidate := []gin.HandlerFunc{middleware.CacheControl(middleware.CacheRevalidate)}
 
-	categories := engine.Group("/categories")
-	{
-		categories.GET("", categoryHandler.GetAll)
-		categories.GET("/:id", categoryHandler.GetByID)
+	register(engine, APIv1, []route{
+		{http.MethodGet, "/ws", "/ws", []gin.HandlerFunc{auth.ChatAuth(), chatHandler.ServeWs}},
 
-		adminCategories := categories.Group("")
-		adminCategories.Use(auth.Auth(), middleware.RequireAdmin())
-		{
-			adminCategories.POST("", categoryHandler.Create)
-			adminCategories.DELETE("/:id", categoryHandler.Delete)
-			adminCategories.PATCH("/:id", categoryHandler.Update)
-		}
-	}
-
-	engine.GET("/categories/topics/:id", topicHandler.GetByCategory)
-	engine.POST("/categories/topics/:id/", auth.Auth(), topicHandler.Create)
+		{http.MethodGet, "/categories", "/categories", with(cacheCategories, categoryHandler.GetAll)},
+		{http.MethodPost, "/categories", "/categories", with(manageCategories, categoryHandler.Create)},
+		{http.MethodGet, "/categories/:id", "/categories/:id", with(cacheCategories, categoryHandler.GetByID)},
+		{http.MethodPatch, "/categories/:id", "/categories/:id", with(manageCategories, categoryHandler.Update)},
+		{http.MethodDelete, "/categories/:id", "/categories/:id", with(manageCategories, categoryHandler.Delete)},
 
-	engine.GET("/topics/:id", topicHandler.GetByID)
-	topics := engine.Group("/topics").Use(auth.Auth())
-	{
-		topics.DELETE("/:id", topicHandler.Delete)
-		topics.PATCH("/:id", topicHandler.Update)
-	}
+		{http.MethodGet, "/categories/:id/topics", "/categories/topics/:id", []gin.HandlerFunc{topicHandler.GetByCategory}},
+		{http.MethodPost, "/categories/:id/topics", "/categories/topics/:id/", with(createTopics, topicHandler.Create)},
+		{http.MethodGet, "/topics/:id", "/topics/:id", with(revalidate, topicHandler.GetByID)},
+		{http.MethodPatch, "/topics/:id", "/topics/:id", with(writeTopics, topicHandler.Update)},
+		{http.MethodDelete, "/topics/:id", "/topics/:id", with(writeTopics, topicHandler.Delete)},
+		{http.MethodPost, "/topics/:id/lock", "/topics/:id/lock", with(writeTopics, topicHandler.Lock)},
+		{http.MethodPost, "/topics/:id/unlock", "/topics/:id/unlock", with(writeTopics, topicHandler.Unlock)},
 
-	engine.GET("/topics/:id/posts", postHandler.GetByTopic)
-	engine.POST("/topics/:id/posts", auth.Auth(), postHandler.Create)
+		{http.MethodGet, "/topics/:id/posts", "/topics/:id/posts", with(revalidate, postHandler.GetByTopic)},
+		{http.MethodPost, "/topics/:id/posts", "/topics/:id/posts", with(createPosts, postHandler.Create)},
+		{http.MethodGet, "/posts/:id", "", []gin.HandlerFunc{postHandler.GetByID}},
+		{http.MethodPatch, "/posts/:id", "/posts/:id", with(writePosts, postHandler.Update)},
+		{http.MethodDelete, "/posts/:id", "/posts/:id", with(writePosts, postHandler.Delete)},
 
-	posts := engine.Group("/posts").Use(auth.Auth())
-	{
-		posts.DELETE("/:id", postHandler.Delete)
-		posts.PATCH("/:id", postHandler.Update)
-	}
+		{http.MethodGet, "/cache/stats", "", with(manageCategories, cacheHandler.Stats)},
+	})
 
-	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	swagger := ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName(docs.SwaggerInfov1.InstanceName()))
+	engine.GET(APIv1+"/swagger/*any", swagger)
+	engine.GET("/swagger/*any", swagger)
 }
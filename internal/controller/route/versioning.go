@@ -0,0 +1,45 @@
+package route
+
+import (
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// APIv1 is the path prefix of version 1 of the public REST API.
+const APIv1 = "/api/v1"
+
+// The unversioned paths served before /api/v1 stay available as deprecated aliases until LegacySunset.
+var (
+	LegacyDeprecatedAt = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	LegacySunset       = time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// route is an endpoint of a versioned API. legacy is the unversioned path the endpoint was served at
+// before, if any.
+type route struct {
+	method   string
+	path     string
+	legacy   string
+	handlers []gin.HandlerFunc
+}
+
+// with returns the handler chain of middleware followed by h.
+func with(middleware []gin.HandlerFunc, h gin.HandlerFunc) []gin.HandlerFunc {
+	return append(middleware[:len(middleware):len(middleware)], h)
+}
+
+// register serves routes under prefix and their legacy paths as deprecated aliases that point clients
+// to the versioned path.
+func register(engine *gin.Engine, prefix string, routes []route) {
+	api := engine.Group(prefix)
+	for _, r := range routes {
+		api.Handle(r.method, r.path, r.handlers...)
+		if r.legacy == "" {
+			continue
+		}
+		deprecated := middleware.Deprecated(prefix+r.path, LegacyDeprecatedAt, LegacySunset)
+		engine.Handle(r.method, r.legacy, append([]gin.HandlerFunc{deprecated}, r.handlers...)...)
+	}
+}
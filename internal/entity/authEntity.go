@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
 
 type User struct {
 	ID           int64     `json:"id"`
@@ -10,10 +14,227 @@ type User struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// UserDetails is the admin view of an account.
+type UserDetails struct {
+	User
+	SessionCount int64      `json:"session_count"`
+	LastLoginAt  *time.Time `json:"last_login_at"`
+}
+
+// UserProfile is the public face of an account. JoinedAt is the account's creation time.
+type UserProfile struct {
+	UserID      int64     `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Bio         string    `json:"bio"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+	Signature   string    `json:"signature"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// Author is the compact profile embedded in topics and posts.
+func (p UserProfile) Author() *Author {
+	return &Author{
+		ID:          p.UserID,
+		Username:    p.Username,
+		DisplayName: p.DisplayName,
+		AvatarURL:   p.AvatarURL,
+		Role:        p.Role,
+		Signature:   p.Signature,
+		JoinedAt:    p.JoinedAt,
+	}
+}
+
+// Kinds of UserChange. UserChangeReset means changes may have been missed and every cached user is stale.
+const (
+	UserChangeCreated = "created"
+	UserChangeRenamed = "renamed"
+	UserChangeUpdated = "updated"
+	UserChangeDeleted = "deleted"
+	UserChangeReset   = "reset"
+)
+
+// UserChange tells services that cache user data that an account was created, renamed, edited or deleted.
+type UserChange struct {
+	Type   string `json:"type"`
+	UserID int64  `json:"user_id"`
+}
+
+// UserRecord is the part of an account other services mirror. A deleted record only carries the ID.
+// Version orders records; it is the ID of the transaction that last changed the account.
+type UserRecord struct {
+	ID       int64
+	Username string
+	Role     string
+	Deleted  bool
+	Version  int64
+}
+
+// ProfileUpdate holds the profile fields a user wants to change. Nil fields are left as they are.
+type ProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	AvatarURL   *string
+	Signature   *string
+}
+
+type UserFilter struct {
+	Query  string
+	Role   string
+	Limit  int
+	Offset int
+}
+
+// AuditEntry records one admin action. Before and After hold the changed fields as JSON objects.
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	ActorID   int64           `json:"actor_id"`
+	TargetID  int64           `json:"target_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type AuditFilter struct {
+	ActorID  int64
+	TargetID int64
+	Limit    int
+	Offset   int
+}
+
+const (
+	AuditUserView    = "user.view"
+	AuditRoleChange  = "user.role_change"
+	AuditForceLogout = "user.force_logout"
+	AuditBan         = "user.ban"
+	AuditUnban       = "user.unban"
+)
+
+// Ban blocks a user from logging in, posting and chatting. A nil ExpiresAt means the ban is permanent.
+type Ban struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	BannedBy  int64      `json:"banned_by"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// UserStatus is what the user service reports to the forum about an account.
+type UserStatus struct {
+	UserID      int64
+	Banned      bool
+	Reason      string
+	BannedUntil *time.Time
+}
+
+// Scopes a personal access token can be limited to.
+const (
+	ScopePostsWrite  = "posts:write"
+	ScopeTopicsWrite = "topics:write"
+	ScopeChatWrite   = "chat:write"
+)
+
+var TokenScopes = []string{ScopePostsWrite, ScopeTopicsWrite, ScopeChatWrite}
+
+// PersonalToken is a long-lived credential for bots and scripts. Only its hash is stored;
+// Prefix is kept so users can tell their tokens apart. A nil ExpiresAt means the token does not expire.
+type PersonalToken struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"fgo_ab12cd34"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TokenIdentity is the user a valid personal access token acts for.
+type TokenIdentity struct {
+	TokenID int64
+	UserID  int64
+	Role    string
+	Scopes  []string
+}
+
+type UserIdentity struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OIDCState is the server-side half of an authorization request. LinkUserID is zero for a plain login.
+// BrowserHash is the hash of the nonce kept in a cookie of the browser that started the request.
+type OIDCState struct {
+	State        string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	BrowserHash  string
+	LinkUserID   int64
+	ExpiresAt    time.Time
+}
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+const (
+	PermPostEditAny    = "post.edit.any"
+	PermPostDeleteAny  = "post.delete.any"
+	PermTopicEditAny   = "topic.edit.any"
+	PermTopicDeleteAny = "topic.delete.any"
+	PermTopicLock      = "topic.lock"
+	PermCategoryManage = "category.manage"
+	PermUserManage     = "user.manage"
+)
+
+// ModeratorPermissions are granted to category moderators inside the categories they moderate.
+var ModeratorPermissions = []string{PermPostEditAny, PermPostDeleteAny, PermTopicEditAny, PermTopicDeleteAny, PermTopicLock}
+
+// Actor is the authenticated user a forum action is performed for, as described by the access token.
+type Actor struct {
+	UserID              int64
+	Role                string
+	Permissions         []string
+	ModeratedCategories []int64
+}
+
+// Can reports whether the actor holds perm globally.
+func (a Actor) Can(perm string) bool {
+	return slices.Contains(a.Permissions, perm)
+}
+
+// CanInCategory reports whether the actor holds perm globally or as a moderator of categoryID.
+func (a Actor) CanInCategory(perm string, categoryID int64) bool {
+	if a.Can(perm) {
+		return true
+	}
+	return slices.Contains(ModeratorPermissions, perm) && slices.Contains(a.ModeratedCategories, categoryID)
+}
+
 type (
 	AccessClaims struct {
+		UserID              int64    `mapstructure:"user_id"`
+		Role                string   `mapstructure:"role"`
+		Permissions         []string `mapstructure:"permissions"`
+		ModeratedCategories []int64  `mapstructure:"moderated_categories"`
+		Exp                 int64    `mapstructure:"exp"`
+		Iat                 int64    `mapstructure:"iat"`
+	}
+
+	MFAClaims struct {
 		UserID int64  `mapstructure:"user_id"`
-		Role   string `mapstructure:"role"`
+		Type   string `mapstructure:"type"`
+		ID     string `mapstructure:"jti"`
 		Exp    int64  `mapstructure:"exp"`
 		Iat    int64  `mapstructure:"iat"`
 	}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"sort"
+	"sync"
+)
+
+// ErrorKind classifies a domain error. Transports map kinds to their own status codes.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindUnavailable
+	// KindPreconditionFailed is for writes conditioned on a version of the resource that is no longer current.
+	KindPreconditionFailed
+	// KindPreconditionRequired is for writes that must be conditioned on a version but were not.
+	KindPreconditionRequired
+	// KindTooLarge is for request bodies over the size the server reads.
+	KindTooLarge
+)
+
+// Error is a domain error whose message is safe to show to clients and whose Code is a stable,
+// machine-readable name for it. Errors with the same code match with errors.Is, so a copy made by
+// WithDetails still matches the sentinel it came from.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Details map[string]any
+}
+
+// errorCodes records every code passed to NewError, so that catalogs of translated messages can be
+// checked against it.
+var (
+	errorCodesMu sync.Mutex
+	errorCodes   = map[string]struct{}{}
+)
+
+func NewError(kind ErrorKind, code, message string) *Error {
+	errorCodesMu.Lock()
+	errorCodes[code] = struct{}{}
+	errorCodesMu.Unlock()
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// ErrorCodes returns the sorted codes of the errors made so far by NewError. Errors are package-level
+// values, so once a package is loaded its codes are all here.
+func ErrorCodes() []string {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	codes := make([]string, 0, len(errorCodes))
+	for code := range errorCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns a copy of e carrying extra fields for the client, such as the reason for a ban.
+func (e *Error) WithDetails(details map[string]any) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+// WithMessage returns a copy of e with a more specific message.
+func (e *Error) WithMessage(message string) *Error {
+	c := *e
+	c.Message = message
+	return &c
+}
@@ -14,6 +14,7 @@ type Category struct {
 	ID          int64     `json:"id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
+	Version     int64     `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -22,9 +23,10 @@ type Post struct {
 	ID        int64     `json:"id"`
 	TopicID   int64     `json:"topic_id"`
 	AuthorID  *int64    `json:"author_id"`
-	Username  string    `json:"username"`
+	Author    *Author   `json:"author"`
 	Content   string    `json:"content"`
 	ReplyTo   *int64    `json:"reply_to"`
+	Version   int64     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -34,11 +36,68 @@ type Topic struct {
 	CategoryID int64     `json:"category_id"`
 	Title      string    `json:"title"`
 	AuthorID   *int64    `json:"author_id"`
-	Username   string    `json:"username"`
+	Author     *Author   `json:"author"`
+	Locked     bool      `json:"locked"`
+	Version    int64     `json:"version"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// Author is the compact profile of the user who wrote a topic or post. The author of content
+// whose account was deleted has a zero ID and only a placeholder username.
+type Author struct {
+	ID          int64     `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Role        string    `json:"role"`
+	Signature   string    `json:"signature"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// CollectionVersion identifies the state of a list the API serves. Version goes up whenever an item is
+// added to, changed in or removed from the list, at UpdatedAt.
+type CollectionVersion struct {
+	Version   int64
+	UpdatedAt time.Time
+}
+
+// Tables a ForumChange can name. ForumChangeReset means changes may have been missed and every cached
+// category and topic is stale.
+const (
+	ForumChangeCategories = "categories"
+	ForumChangeTopics     = "topics"
+	ForumChangeReset      = "reset"
+)
+
+// ForumChange tells forum service nodes that a category or topic was created, edited or deleted.
+type ForumChange struct {
+	Table string `json:"table"`
+	ID    int64  `json:"id"`
+}
+
+// CacheStats counts the lookups a read cache answered itself and the ones it passed to the database.
+type CacheStats struct {
+	Name    string `json:"name"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Entries int    `json:"entries"`
+}
+
+// IdempotencyRecord is a request sent with an Idempotency-Key and, once it is answered, its reply.
+// StatusCode is zero while the request is still running. LockToken names the request holding the key;
+// only that request can complete or release it.
+type IdempotencyRecord struct {
+	UserID      int64
+	Key         string
+	RequestHash string
+	LockToken   string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
 type WsMessage struct {
 	Type    string      `json:"type"`
 	Payload interface{} `json:"payload,omitempty"`
@@ -0,0 +1,256 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// AdminRepository backs the admin user-management API. Every mutation writes its audit entry in the same statement.
+type AdminRepository interface {
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int64, error)
+	GetUserDetails(ctx context.Context, id int64) (*entity.UserDetails, error)
+	ChangeRole(ctx context.Context, actorID, userID int64, role string) (previous string, err error)
+	ForceLogout(ctx context.Context, actorID, userID int64) (revoked int64, err error)
+	Ban(ctx context.Context, actorID int64, ban *entity.Ban) (*entity.Ban, error)
+	Unban(ctx context.Context, actorID, userID int64) (lifted int64, err error)
+	RecordAudit(ctx context.Context, entry *entity.AuditEntry) error
+	GetAuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+const (
+	listUsersOp      = "AdminRepository.ListUsers"
+	getUserDetailsOp = "AdminRepository.GetUserDetails"
+	changeRoleOp     = "AdminRepository.ChangeRole"
+	forceLogoutOp    = "AdminRepository.ForceLogout"
+	banOp            = "AdminRepository.Ban"
+	unbanOp          = "AdminRepository.Unban"
+	recordAuditOp    = "AdminRepository.RecordAudit"
+	getAuditLogOp    = "AdminRepository.GetAuditLog"
+)
+
+type adminRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewAdminRepository(pg *postgres.Postgres, log *zerolog.Logger) AdminRepository {
+	return &adminRepository{pg, log}
+}
+
+// ListUsers returns one page of users matching the filter together with the total number of matches.
+func (r *adminRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int64, error) {
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT id, username, role, created_at, count(*) OVER()
+	FROM users
+	WHERE ($1 = '' OR username ILIKE '%' || $1 || '%')
+		AND ($2 = '' OR role = $2)
+	ORDER BY id
+	LIMIT $3 OFFSET $4`,
+		filter.Query, filter.Role, filter.Limit, filter.Offset)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", listUsersOp).Msg("Failed to query users")
+		return nil, 0, fmt.Errorf("AdminRepository - ListUsers - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	var total int64
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(&u.ID, &u.Username, &u.Role, &u.CreatedAt, &total); err != nil {
+			return nil, 0, fmt.Errorf("AdminRepository - ListUsers - rows.Scan(): %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("AdminRepository - ListUsers - rows.Err(): %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (r *adminRepository) GetUserDetails(ctx context.Context, id int64) (*entity.UserDetails, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	SELECT u.id, u.username, u.role, u.created_at, u.last_login_at,
+		(SELECT count(*) FROM refresh_tokens WHERE user_id = u.id)
+	FROM users u
+	WHERE u.id = $1`, id)
+
+	var d entity.UserDetails
+	if err := row.Scan(&d.ID, &d.Username, &d.Role, &d.CreatedAt, &d.LastLoginAt, &d.SessionCount); err != nil {
+		r.log.Error().Err(err).Str("op", getUserDetailsOp).Int64("id", id).Msg("Failed to scan user details")
+		return nil, fmt.Errorf("AdminRepository - GetUserDetails - row.Scan(): %w", err)
+	}
+
+	return &d, nil
+}
+
+// ChangeRole sets the user's role, revokes their refresh tokens and records the change.
+// It returns pgx.ErrNoRows for an unknown user and ErrUnknownRole when role is not in the roles table.
+func (r *adminRepository) ChangeRole(ctx context.Context, actorID, userID int64, role string) (string, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	WITH old AS (
+		SELECT id, role FROM users WHERE id = $2 FOR UPDATE
+	), upd AS (
+		UPDATE users u SET role = $3 FROM old WHERE u.id = old.id RETURNING u.id
+	), revoked AS (
+		DELETE FROM refresh_tokens WHERE user_id IN (SELECT id FROM upd)
+	), audit AS (
+		INSERT INTO admin_audit_log (actor_id, target_id, action, before, after)
+		SELECT $1, old.id, $4, jsonb_build_object('role', old.role), jsonb_build_object('role', $3::text)
+		FROM old JOIN upd ON upd.id = old.id
+	)
+	SELECT role FROM old`,
+		actorID, userID, role, entity.AuditRoleChange)
+
+	var previous string
+	if err := row.Scan(&previous); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "users_role_fkey" {
+			return "", ErrUnknownRole
+		}
+		r.log.Error().Err(err).Str("op", changeRoleOp).Int64("user_id", userID).Str("role", role).Msg("Failed to change role")
+		return "", fmt.Errorf("AdminRepository - ChangeRole - row.Scan(): %w", err)
+	}
+
+	return previous, nil
+}
+
+// ForceLogout revokes all refresh tokens of the user and records how many were revoked.
+// It returns pgx.ErrNoRows for an unknown user.
+func (r *adminRepository) ForceLogout(ctx context.Context, actorID, userID int64) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	WITH target AS (
+		SELECT id FROM users WHERE id = $2
+	), revoked AS (
+		DELETE FROM refresh_tokens WHERE user_id IN (SELECT id FROM target) RETURNING token
+	), audit AS (
+		INSERT INTO admin_audit_log (actor_id, target_id, action, before, after)
+		SELECT $1, target.id, $3, jsonb_build_object('sessions', (SELECT count(*) FROM revoked)), jsonb_build_object('sessions', 0)
+		FROM target
+	)
+	SELECT (SELECT count(*) FROM revoked) FROM target`,
+		actorID, userID, entity.AuditForceLogout)
+
+	var revoked int64
+	if err := row.Scan(&revoked); err != nil {
+		r.log.Error().Err(err).Str("op", forceLogoutOp).Int64("user_id", userID).Msg("Failed to force logout")
+		return 0, fmt.Errorf("AdminRepository - ForceLogout - row.Scan(): %w", err)
+	}
+
+	return revoked, nil
+}
+
+// Ban stores a ban for ban.UserID, revokes the user's refresh tokens and records the ban.
+// It returns pgx.ErrNoRows for an unknown user.
+func (r *adminRepository) Ban(ctx context.Context, actorID int64, ban *entity.Ban) (*entity.Ban, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	WITH target AS (
+		SELECT id FROM users WHERE id = $2
+	), ban AS (
+		INSERT INTO user_bans (user_id, banned_by, reason, expires_at)
+		SELECT id, $1, $3, $4 FROM target
+		RETURNING id, user_id, banned_by, reason, expires_at, created_at
+	), revoked AS (
+		DELETE FROM refresh_tokens WHERE user_id IN (SELECT id FROM target)
+	), audit AS (
+		INSERT INTO admin_audit_log (actor_id, target_id, action, after)
+		SELECT $1, ban.user_id, $5, jsonb_build_object('reason', ban.reason, 'expires_at', ban.expires_at)
+		FROM ban
+	)
+	SELECT id, user_id, COALESCE(banned_by, 0), reason, expires_at, created_at FROM ban`,
+		actorID, ban.UserID, ban.Reason, ban.ExpiresAt, entity.AuditBan)
+
+	var created entity.Ban
+	if err := row.Scan(&created.ID, &created.UserID, &created.BannedBy, &created.Reason, &created.ExpiresAt, &created.CreatedAt); err != nil {
+		r.log.Error().Err(err).Str("op", banOp).Int64("user_id", ban.UserID).Msg("Failed to ban user")
+		return nil, fmt.Errorf("AdminRepository - Ban - row.Scan(): %w", err)
+	}
+
+	return &created, nil
+}
+
+// Unban lifts every active ban of the user and records the lifted bans. It returns how many bans were lifted.
+func (r *adminRepository) Unban(ctx context.Context, actorID, userID int64) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	WITH lifted AS (
+		UPDATE user_bans SET lifted_at = now()
+		WHERE user_id = $2 AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+		RETURNING reason, expires_at
+	), audit AS (
+		INSERT INTO admin_audit_log (actor_id, target_id, action, before)
+		SELECT $1, $2, $3, jsonb_build_object('bans', jsonb_agg(jsonb_build_object('reason', reason, 'expires_at', expires_at)))
+		FROM lifted
+		HAVING count(*) > 0
+	)
+	SELECT count(*) FROM lifted`,
+		actorID, userID, entity.AuditUnban)
+
+	var lifted int64
+	if err := row.Scan(&lifted); err != nil {
+		r.log.Error().Err(err).Str("op", unbanOp).Int64("user_id", userID).Msg("Failed to unban user")
+		return 0, fmt.Errorf("AdminRepository - Unban - row.Scan(): %w", err)
+	}
+
+	return lifted, nil
+}
+
+func (r *adminRepository) RecordAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	before, after := entry.Before, entry.After
+	if before == nil {
+		before = []byte("{}")
+	}
+	if after == nil {
+		after = []byte("{}")
+	}
+
+	_, err := r.pg.Pool.Exec(ctx,
+		"INSERT INTO admin_audit_log (actor_id, target_id, action, before, after) VALUES($1, $2, $3, $4, $5)",
+		entry.ActorID, entry.TargetID, entry.Action, before, after)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", recordAuditOp).Str("action", entry.Action).Int64("target_id", entry.TargetID).Msg("Failed to record audit entry")
+		return fmt.Errorf("AdminRepository - RecordAudit - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns audit entries, newest first. Zero ActorID or TargetID in the filter matches any user.
+func (r *adminRepository) GetAuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT id, COALESCE(actor_id, 0), COALESCE(target_id, 0), action, before, after, created_at
+	FROM admin_audit_log
+	WHERE ($1 = 0 OR actor_id = $1)
+		AND ($2 = 0 OR target_id = $2)
+	ORDER BY id DESC
+	LIMIT $3 OFFSET $4`,
+		filter.ActorID, filter.TargetID, filter.Limit, filter.Offset)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", getAuditLogOp).Msg("Failed to query audit log")
+		return nil, fmt.Errorf("AdminRepository - GetAuditLog - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	entries := []entity.AuditEntry{}
+	for rows.Next() {
+		var e entity.AuditEntry
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.TargetID, &e.Action, &e.Before, &e.After, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("AdminRepository - GetAuditLog - rows.Scan(): %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("AdminRepository - GetAuditLog - rows.Err(): %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,205 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminRepository_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	filter := entity.UserFilter{Query: "al", Role: "user", Limit: 20, Offset: 0}
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows([]string{"id", "username", "role", "created_at", "count"}).
+			AddRow(int64(1), "alice", "user", now, int64(2)).
+			AddRow(int64(4), "alfred", "user", now, int64(2))
+		mockPool.ExpectQuery("FROM users").WithArgs(filter.Query, filter.Role, filter.Limit, filter.Offset).WillReturnRows(rows)
+
+		users, total, err := repo.ListUsers(ctx, filter)
+		assert.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "alfred", users[1].Username)
+		assert.Equal(t, int64(2), total)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectQuery("FROM users").WithArgs(filter.Query, filter.Role, filter.Limit, filter.Offset).WillReturnError(dbErr)
+
+		_, _, err := repo.ListUsers(ctx, filter)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAdminRepository_ChangeRole(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	actorID, userID := int64(1), int64(7)
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"role"}).AddRow("user")
+		mockPool.ExpectQuery("UPDATE users u SET role").WithArgs(actorID, userID, "moderator", entity.AuditRoleChange).WillReturnRows(rows)
+
+		previous, err := repo.ChangeRole(ctx, actorID, userID, "moderator")
+		assert.NoError(t, err)
+		assert.Equal(t, "user", previous)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		fkErr := &pgconn.PgError{Code: "23503", ConstraintName: "users_role_fkey"}
+		mockPool.ExpectQuery("UPDATE users u SET role").WithArgs(actorID, userID, "wizard", entity.AuditRoleChange).WillReturnError(fkErr)
+
+		_, err := repo.ChangeRole(ctx, actorID, userID, "wizard")
+		assert.ErrorIs(t, err, ErrUnknownRole)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockPool.ExpectQuery("UPDATE users u SET role").WithArgs(actorID, userID, "moderator", entity.AuditRoleChange).WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.ChangeRole(ctx, actorID, userID, "moderator")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAdminRepository_ForceLogout(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(3))
+		mockPool.ExpectQuery("DELETE FROM refresh_tokens").WithArgs(int64(1), int64(7), entity.AuditForceLogout).WillReturnRows(rows)
+
+		revoked, err := repo.ForceLogout(ctx, 1, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), revoked)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAdminRepository_Ban(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	expiresAt := time.Now().Add(time.Hour)
+	ban := &entity.Ban{UserID: 7, Reason: "spam", ExpiresAt: &expiresAt}
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows([]string{"id", "user_id", "banned_by", "reason", "expires_at", "created_at"}).
+			AddRow(int64(3), int64(7), int64(1), "spam", &expiresAt, now)
+		mockPool.ExpectQuery("INSERT INTO user_bans").WithArgs(int64(1), int64(7), "spam", &expiresAt, entity.AuditBan).WillReturnRows(rows)
+
+		created, err := repo.Ban(ctx, 1, ban)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), created.ID)
+		assert.Equal(t, int64(1), created.BannedBy)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockPool.ExpectQuery("INSERT INTO user_bans").WithArgs(int64(1), int64(7), "spam", &expiresAt, entity.AuditBan).WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.Ban(ctx, 1, ban)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAdminRepository_Unban(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"count"}).AddRow(int64(1))
+		mockPool.ExpectQuery("UPDATE user_bans SET lifted_at").WithArgs(int64(1), int64(7), entity.AuditUnban).WillReturnRows(rows)
+
+		lifted, err := repo.Unban(ctx, 1, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), lifted)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAdminRepository_RecordAudit(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewAdminRepository(pg, &logger)
+
+	t.Run("Empty before and after default to objects", func(t *testing.T) {
+		mockPool.ExpectExec("INSERT INTO admin_audit_log").
+			WithArgs(int64(1), int64(7), entity.AuditUserView, json.RawMessage("{}"), json.RawMessage("{}")).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err := repo.RecordAudit(ctx, &entity.AuditEntry{ActorID: 1, TargetID: 7, Action: entity.AuditUserView})
+		assert.NoError(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
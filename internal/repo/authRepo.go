@@ -2,10 +2,12 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog"
 )
 
@@ -16,6 +18,9 @@ type (
 		GetByUsername(ctx context.Context, username string) (*entity.User, error)
 		GetByID(ctx context.Context, id int64) (*entity.User, error)
 		GetRole(ctx context.Context, id int64) (string, error)
+		GetPasswordHash(ctx context.Context, id int64) (string, error)
+		UpdateLastLogin(ctx context.Context, id int64) error
+		UpdatePasswordHash(ctx context.Context, id int64, hash string) error
 	}
 
 	RefreshTokenRepository interface {
@@ -31,6 +36,9 @@ const (
 	getByUsernameOp = "UserRepository.GetByUsername"
 	getByIDOp       = "UserRepository.GetByID"
 	getRoleOp       = "UserRepository.GetRole"
+	getPasswordOp   = "UserRepository.GetPasswordHash"
+	lastLoginOp     = "UserRepository.UpdateLastLogin"
+	updatePassOp    = "UserRepository.UpdatePasswordHash"
 )
 
 const (
@@ -58,13 +66,18 @@ func NewRefreshTokenRepository(pg *postgres.Postgres, log *zerolog.Logger) Refre
 	return &refreshTokenRepository{pg, log}
 }
 
+// Create returns ErrUsernameTaken when the username is in use.
 func (r *userRepository) Create(ctx context.Context, user *entity.User) (int64, error) {
 	row := r.pg.Pool.QueryRow(ctx,
-		"INSERT INTO users (username, password_hash) VALUES($1, $2) RETURNING id",
-		user.Username, string(user.PasswordHash))
+		"INSERT INTO users (username, password_hash, role) VALUES($1, $2, COALESCE(NULLIF($3, ''), 'user')) RETURNING id",
+		user.Username, string(user.PasswordHash), user.Role)
 
 	var id int64
 	if err := row.Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "users_username_key" {
+			return 0, ErrUsernameTaken
+		}
 		r.log.Error().Err(err).Str("op", createOp).Str("username", user.Username).Msg("Failed to scan user ID after insert")
 		return 0, fmt.Errorf("UserRepository - Create - row.Scan(): %w", err)
 	}
@@ -116,6 +129,34 @@ func (r *userRepository) GetRole(ctx context.Context, id int64) (string, error)
 	return role, nil
 }
 
+func (r *userRepository) GetPasswordHash(ctx context.Context, id int64) (string, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT password_hash FROM users WHERE id = $1", id)
+
+	var hash string
+	if err := row.Scan(&hash); err != nil {
+		r.log.Error().Err(err).Str("op", getPasswordOp).Int64("id", id).Msg("Failed to get password hash")
+		return "", fmt.Errorf("UserRepository - GetPasswordHash - row.Scan(): %w", err)
+	}
+
+	return hash, nil
+}
+
+func (r *userRepository) UpdateLastLogin(ctx context.Context, id int64) error {
+	if _, err := r.pg.Pool.Exec(ctx, "UPDATE users SET last_login_at = now() WHERE id = $1", id); err != nil {
+		r.log.Error().Err(err).Str("op", lastLoginOp).Int64("id", id).Msg("Failed to update last login")
+		return fmt.Errorf("UserRepository - UpdateLastLogin - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) UpdatePasswordHash(ctx context.Context, id int64, hash string) error {
+	if _, err := r.pg.Pool.Exec(ctx, "UPDATE users SET password_hash = $2 WHERE id = $1", id, hash); err != nil {
+		r.log.Error().Err(err).Str("op", updatePassOp).Int64("id", id).Msg("Failed to update password hash")
+		return fmt.Errorf("UserRepository - UpdatePasswordHash - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
 func (r *refreshTokenRepository) Save(ctx context.Context, token string, userID int64) error {
 	if _, err := r.pg.Pool.Exec(ctx, "INSERT INTO refresh_tokens (token, user_id) VALUES($1, $2)", token, userID); err != nil {
 		r.log.Error().Err(err).Str("op", saveOp).Str("token", token).Int64("userID", userID).Msg("Failed to save refresh token")
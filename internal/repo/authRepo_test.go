@@ -9,6 +9,7 @@ import (
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -149,12 +150,12 @@ func TestUserPostgres_Create(t *testing.T) {
 	pg := postgres.NewWithPool(mockPool)
 	repo := NewUserRepository(pg, &logger)
 
-	testUser := &entity.User{Username: "test", PasswordHash: "testpasswordhash"}
+	testUser := &entity.User{Username: "test", Role: "moderator", PasswordHash: "testpasswordhash"}
 	expectedId := int64(1)
 
 	t.Run("Success", func(t *testing.T) {
 		row := pgxmock.NewRows([]string{"id"}).AddRow(expectedId)
-		mockPool.ExpectQuery("INSERT INTO users").WithArgs(testUser.Username, testUser.PasswordHash).WillReturnRows(row)
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs(testUser.Username, testUser.PasswordHash, testUser.Role).WillReturnRows(row)
 
 		id, err := repo.Create(ctx, testUser)
 		assert.NoError(t, err)
@@ -164,7 +165,7 @@ func TestUserPostgres_Create(t *testing.T) {
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("INSERT INTO users").WithArgs(testUser.Username, testUser.PasswordHash).WillReturnError(dbErr)
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs(testUser.Username, testUser.PasswordHash, testUser.Role).WillReturnError(dbErr)
 
 		_, err := repo.Create(ctx, testUser)
 
@@ -173,6 +174,16 @@ func TestUserPostgres_Create(t *testing.T) {
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
+
+	t.Run("Username taken", func(t *testing.T) {
+		dbErr := &pgconn.PgError{Code: "23505", ConstraintName: "users_username_key"}
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs(testUser.Username, testUser.PasswordHash, testUser.Role).WillReturnError(dbErr)
+
+		_, err := repo.Create(ctx, testUser)
+
+		assert.ErrorIs(t, err, ErrUsernameTaken)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
 }
 
 func TestUserPostgres_Delete(t *testing.T) {
@@ -319,3 +330,37 @@ func TestUserPostgres_GetRole(t *testing.T) {
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
 }
+
+func TestUserPostgres_UpdatePasswordHash(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+	repo := NewUserRepository(pg, &logger)
+
+	testID := int64(1)
+	hash := "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5"
+
+	t.Run("Success", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE users SET password_hash").WithArgs(testID, hash).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err := repo.UpdatePasswordHash(ctx, testID, hash)
+		assert.NoError(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("database update error")
+		mockPool.ExpectExec("UPDATE users SET password_hash").WithArgs(testID, hash).WillReturnError(dbErr)
+
+		err := repo.UpdatePasswordHash(ctx, testID, hash)
+
+		assert.Contains(t, err.Error(), "UserRepository - UpdatePasswordHash - pg.Pool.Exec()")
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+type BanRepository interface {
+	GetActive(ctx context.Context, userID int64) (*entity.Ban, error)
+	GetActiveByUsers(ctx context.Context, userIDs []int64) (map[int64]entity.Ban, error)
+}
+
+const (
+	getActiveBanOp  = "BanRepository.GetActive"
+	getActiveBansOp = "BanRepository.GetActiveByUsers"
+)
+
+type banRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewBanRepository(pg *postgres.Postgres, log *zerolog.Logger) BanRepository {
+	return &banRepository{pg, log}
+}
+
+// GetActive returns the ban that currently applies to the user, preferring a permanent one over the longest timed one.
+// It returns pgx.ErrNoRows when the user is not banned.
+func (r *banRepository) GetActive(ctx context.Context, userID int64) (*entity.Ban, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	SELECT id, user_id, COALESCE(banned_by, 0), reason, expires_at, created_at
+	FROM user_bans
+	WHERE user_id = $1
+		AND lifted_at IS NULL
+		AND (expires_at IS NULL OR expires_at > now())
+	ORDER BY expires_at DESC NULLS FIRST
+	LIMIT 1`, userID)
+
+	var ban entity.Ban
+	if err := row.Scan(&ban.ID, &ban.UserID, &ban.BannedBy, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt); err != nil {
+		return nil, fmt.Errorf("BanRepository - GetActive - row.Scan(): %w", err)
+	}
+
+	return &ban, nil
+}
+
+// GetActiveByUsers returns the ban that currently applies to each of the users, chosen like GetActive.
+// Users that are not banned are left out of the map.
+func (r *banRepository) GetActiveByUsers(ctx context.Context, userIDs []int64) (map[int64]entity.Ban, error) {
+	bans := make(map[int64]entity.Ban, len(userIDs))
+	if len(userIDs) == 0 {
+		return bans, nil
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT DISTINCT ON (user_id) id, user_id, COALESCE(banned_by, 0), reason, expires_at, created_at
+	FROM user_bans
+	WHERE user_id = ANY($1)
+		AND lifted_at IS NULL
+		AND (expires_at IS NULL OR expires_at > now())
+	ORDER BY user_id, expires_at DESC NULLS FIRST`, userIDs)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", getActiveBansOp).Msg("Failed to get active bans")
+		return nil, fmt.Errorf("BanRepository - GetActiveByUsers - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ban entity.Ban
+		if err := rows.Scan(&ban.ID, &ban.UserID, &ban.BannedBy, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt); err != nil {
+			r.log.Error().Err(err).Str("op", getActiveBansOp).Msg("Failed to scan ban")
+			return nil, fmt.Errorf("BanRepository - GetActiveByUsers - rows.Scan(): %w", err)
+		}
+		bans[ban.UserID] = ban
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("BanRepository - GetActiveByUsers - rows.Err(): %w", err)
+	}
+
+	return bans, nil
+}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBanRepository_GetActive(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewBanRepository(pg, &logger)
+
+	t.Run("Banned", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows([]string{"id", "user_id", "banned_by", "reason", "expires_at", "created_at"}).
+			AddRow(int64(3), int64(7), int64(1), "spam", (*time.Time)(nil), now)
+		mockPool.ExpectQuery("FROM user_bans").WithArgs(int64(7)).WillReturnRows(rows)
+
+		ban, err := repo.GetActive(ctx, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, "spam", ban.Reason)
+		assert.Nil(t, ban.ExpiresAt)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not banned", func(t *testing.T) {
+		mockPool.ExpectQuery("FROM user_bans").WithArgs(int64(7)).WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetActive(ctx, 7)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestBanRepository_GetActiveByUsers(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewBanRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		until := now.Add(time.Hour)
+		rows := pgxmock.NewRows([]string{"id", "user_id", "banned_by", "reason", "expires_at", "created_at"}).
+			AddRow(int64(3), int64(7), int64(1), "spam", (*time.Time)(nil), now).
+			AddRow(int64(4), int64(9), int64(1), "flood", &until, now)
+		mockPool.ExpectQuery("FROM user_bans").WithArgs([]int64{7, 8, 9}).WillReturnRows(rows)
+
+		bans, err := repo.GetActiveByUsers(ctx, []int64{7, 8, 9})
+		assert.NoError(t, err)
+		assert.Len(t, bans, 2)
+		assert.Equal(t, "spam", bans[7].Reason)
+		assert.Equal(t, &until, bans[9].ExpiresAt)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Empty ids", func(t *testing.T) {
+		bans, err := repo.GetActiveByUsers(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, bans)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
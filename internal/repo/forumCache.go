@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/cache"
+)
+
+// CacheConfig bounds each read cache of the forum repositories.
+type CacheConfig struct {
+	Size int
+	TTL  time.Duration
+}
+
+// ReadCache is implemented by the repositories that serve reads from memory.
+type ReadCache interface {
+	// Invalidate drops what change made stale. Writes through the repository do not invalidate by
+	// themselves: the usecase making a write applies it, and the change listener applies the ones made
+	// by other nodes.
+	Invalidate(change entity.ForumChange)
+	Stats() entity.CacheStats
+}
+
+type (
+	// CachedCategoryRepository is a CategoryRepository that keeps categories, their list and its version in memory.
+	CachedCategoryRepository interface {
+		CategoryRepository
+		ReadCache
+	}
+
+	// CachedTopicRepository is a TopicRepository that keeps topics read by ID in memory. Lists of topics
+	// are paged and always read from the database.
+	CachedTopicRepository interface {
+		TopicRepository
+		ReadCache
+	}
+)
+
+// categoryListKey is the only key of the caches of the category list and its version.
+type categoryListKey struct{}
+
+type cachedCategoryRepository struct {
+	next       CategoryRepository
+	categories *cache.Loader[int64, entity.Category]
+	list       *cache.Loader[categoryListKey, []entity.Category]
+	version    *cache.Loader[categoryListKey, entity.CollectionVersion]
+}
+
+func NewCachedCategoryRepository(next CategoryRepository, cfg CacheConfig) CachedCategoryRepository {
+	return &cachedCategoryRepository{
+		next:       next,
+		categories: cache.NewLoader[int64, entity.Category](cfg.Size, cfg.TTL),
+		list:       cache.NewLoader[categoryListKey, []entity.Category](cfg.Size, cfg.TTL),
+		version:    cache.NewLoader[categoryListKey, entity.CollectionVersion](cfg.Size, cfg.TTL),
+	}
+}
+
+func (r *cachedCategoryRepository) Create(ctx context.Context, category entity.Category) (int64, error) {
+	return r.next.Create(ctx, category)
+}
+
+func (r *cachedCategoryRepository) GetByID(ctx context.Context, id int64) (*entity.Category, error) {
+	category, err := r.categories.Get(ctx, id, func(ctx context.Context) (entity.Category, error) {
+		category, err := r.next.GetByID(ctx, id)
+		if err != nil {
+			return entity.Category{}, err
+		}
+		return *category, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *cachedCategoryRepository) GetAll(ctx context.Context) ([]entity.Category, error) {
+	categories, err := r.list.Get(ctx, categoryListKey{}, r.next.GetAll)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(categories), nil
+}
+
+func (r *cachedCategoryRepository) GetAllVersion(ctx context.Context) (entity.CollectionVersion, error) {
+	return r.version.Get(ctx, categoryListKey{}, r.next.GetAllVersion)
+}
+
+func (r *cachedCategoryRepository) Update(ctx context.Context, id int64, title, description string, version int64) (int64, error) {
+	return r.next.Update(ctx, id, title, description, version)
+}
+
+func (r *cachedCategoryRepository) Delete(ctx context.Context, id int64) error {
+	return r.next.Delete(ctx, id)
+}
+
+func (r *cachedCategoryRepository) Invalidate(change entity.ForumChange) {
+	switch change.Table {
+	case entity.ForumChangeCategories:
+		r.invalidateCategory(change.ID)
+	case entity.ForumChangeReset:
+		r.categories.Purge()
+		r.invalidateList()
+	}
+}
+
+func (r *cachedCategoryRepository) Stats() entity.CacheStats {
+	stats := entity.CacheStats{Name: "categories"}
+	addStats(&stats, r.categories.Stats())
+	addStats(&stats, r.list.Stats())
+	addStats(&stats, r.version.Stats())
+	return stats
+}
+
+func (r *cachedCategoryRepository) invalidateCategory(id int64) {
+	r.categories.Invalidate(id)
+	r.invalidateList()
+}
+
+func (r *cachedCategoryRepository) invalidateList() {
+	r.list.Purge()
+	r.version.Purge()
+}
+
+type cachedTopicRepository struct {
+	next   TopicRepository
+	topics *cache.Loader[int64, entity.Topic]
+}
+
+func NewCachedTopicRepository(next TopicRepository, cfg CacheConfig) CachedTopicRepository {
+	return &cachedTopicRepository{
+		next:   next,
+		topics: cache.NewLoader[int64, entity.Topic](cfg.Size, cfg.TTL),
+	}
+}
+
+func (r *cachedTopicRepository) Create(ctx context.Context, topic entity.Topic) (int64, error) {
+	return r.next.Create(ctx, topic)
+}
+
+// GetByID returns a copy of the cached topic, which callers may fill in with its author.
+func (r *cachedTopicRepository) GetByID(ctx context.Context, id int64) (*entity.Topic, error) {
+	topic, err := r.topics.Get(ctx, id, func(ctx context.Context) (entity.Topic, error) {
+		topic, err := r.next.GetByID(ctx, id)
+		if err != nil {
+			return entity.Topic{}, err
+		}
+		return *topic, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if topic.AuthorID != nil {
+		authorID := *topic.AuthorID
+		topic.AuthorID = &authorID
+	}
+	return &topic, nil
+}
+
+func (r *cachedTopicRepository) GetByCategory(ctx context.Context, categoryID int64) ([]entity.Topic, error) {
+	return r.next.GetByCategory(ctx, categoryID)
+}
+
+func (r *cachedTopicRepository) Update(ctx context.Context, id int64, title string, version int64) (int64, error) {
+	return r.next.Update(ctx, id, title, version)
+}
+
+func (r *cachedTopicRepository) SetLocked(ctx context.Context, id int64, locked bool) error {
+	return r.next.SetLocked(ctx, id, locked)
+}
+
+func (r *cachedTopicRepository) Delete(ctx context.Context, id int64) error {
+	return r.next.Delete(ctx, id)
+}
+
+// Invalidate relies on the topics of a deleted category being reported one by one.
+func (r *cachedTopicRepository) Invalidate(change entity.ForumChange) {
+	switch change.Table {
+	case entity.ForumChangeTopics:
+		r.topics.Invalidate(change.ID)
+	case entity.ForumChangeReset:
+		r.topics.Purge()
+	}
+}
+
+func (r *cachedTopicRepository) Stats() entity.CacheStats {
+	stats := entity.CacheStats{Name: "topics"}
+	addStats(&stats, r.topics.Stats())
+	return stats
+}
+
+// addStats adds the counts of one cache to stats.
+func addStats(stats *entity.CacheStats, s cache.Stats) {
+	stats.Hits += s.Hits
+	stats.Misses += s.Misses
+	stats.Entries += s.Entries
+}
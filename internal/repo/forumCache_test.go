@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testCacheConfig = CacheConfig{Size: 10, TTL: time.Minute}
+
+func TestCachedCategoryRepository_CachesReads(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewCategoryRepository(t)
+	r := NewCachedCategoryRepository(next, testCacheConfig)
+
+	categories := []entity.Category{{ID: 1, Title: "News"}, {ID: 2, Title: "Help"}}
+	next.On("GetAll", mock.Anything).Return(categories, nil).Once()
+	next.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 3}, nil).Once()
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1, Title: "News"}, nil).Once()
+
+	for range 3 {
+		got, err := r.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, categories, got)
+
+		version, err := r.GetAllVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), version.Version)
+
+		category, err := r.GetByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "News", category.Title)
+	}
+
+	assert.Equal(t, entity.CacheStats{Name: "categories", Hits: 6, Misses: 3, Entries: 3}, r.Stats())
+}
+
+func TestCachedCategoryRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewCategoryRepository(t)
+	r := NewCachedCategoryRepository(next, testCacheConfig)
+
+	next.On("GetAll", mock.Anything).Return([]entity.Category{{ID: 1, Title: "News"}}, nil).Once()
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1, Title: "News"}, nil).Once()
+
+	list, err := r.GetAll(ctx)
+	require.NoError(t, err)
+	list[0].Title = "changed"
+	category, err := r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	category.Title = "changed"
+
+	list, err = r.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "News", list[0].Title)
+	category, err = r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "News", category.Title)
+}
+
+// Writes leave the cache to the usecase that makes them, which invalidates once it knows the outcome.
+func TestCachedCategoryRepository_WritesDoNotInvalidate(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewCategoryRepository(t)
+	r := NewCachedCategoryRepository(next, testCacheConfig)
+
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1, Title: "News", Version: 1}, nil).Once()
+	_, err := r.GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	next.On("Update", mock.Anything, int64(1), "World", "", int64(1)).Return(int64(2), nil).Once()
+	_, err = r.Update(ctx, 1, "World", "", 1)
+	require.NoError(t, err)
+
+	category, err := r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "News", category.Title)
+
+	r.Invalidate(entity.ForumChange{Table: entity.ForumChangeCategories, ID: 1})
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1, Title: "World", Version: 2}, nil).Once()
+	category, err = r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "World", category.Title)
+}
+
+func TestCachedCategoryRepository_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewCategoryRepository(t)
+	r := NewCachedCategoryRepository(next, testCacheConfig)
+
+	next.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 3}, nil).Once()
+	_, err := r.GetAllVersion(ctx)
+	require.NoError(t, err)
+
+	// Topic changes leave categories alone.
+	r.Invalidate(entity.ForumChange{Table: entity.ForumChangeTopics, ID: 1})
+	_, err = r.GetAllVersion(ctx)
+	require.NoError(t, err)
+
+	r.Invalidate(entity.ForumChange{Table: entity.ForumChangeCategories, ID: 5})
+	next.On("GetAllVersion", mock.Anything).Return(entity.CollectionVersion{Version: 4}, nil).Once()
+	version, err := r.GetAllVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), version.Version)
+}
+
+func TestCachedCategoryRepository_DoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewCategoryRepository(t)
+	r := NewCachedCategoryRepository(next, testCacheConfig)
+
+	next.On("GetByID", mock.Anything, int64(1)).Return(nil, pgx.ErrNoRows).Once()
+	_, err := r.GetByID(ctx, 1)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1}, nil).Once()
+	category, err := r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), category.ID)
+}
+
+func TestCachedTopicRepository_CachesAndInvalidates(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewTopicRepository(t)
+	r := NewCachedTopicRepository(next, testCacheConfig)
+
+	authorID := int64(7)
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Topic{ID: 1, AuthorID: &authorID}, nil).Once()
+
+	topic, err := r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	topic.Author = &entity.Author{ID: 7}
+	*topic.AuthorID = 8
+
+	topic, err = r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, topic.Author)
+	assert.Equal(t, int64(7), *topic.AuthorID)
+
+	r.Invalidate(entity.ForumChange{Table: entity.ForumChangeTopics, ID: 1})
+
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Topic{ID: 1, Locked: true}, nil).Once()
+	topic, err = r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, topic.Locked)
+
+	r.Invalidate(entity.ForumChange{Table: entity.ForumChangeReset})
+	next.On("GetByID", mock.Anything, int64(1)).Return(&entity.Topic{ID: 1}, nil).Once()
+	topic, err = r.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, topic.Locked)
+
+	assert.Equal(t, entity.CacheStats{Name: "topics", Hits: 1, Misses: 3, Entries: 1}, r.Stats())
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// forumChangesChannel is the NOTIFY channel the categories and topics table triggers publish to.
+const forumChangesChannel = "forum_changes"
+
+const listenForumChangesOp = "ForumChangeListener.Listen"
+
+// ForumChangeListener receives category and topic change notifications from the forum database, so
+// every node can drop what other nodes changed from its read caches. Like UserChangeListener it keeps
+// its own connection.
+type ForumChangeListener struct {
+	dsn string
+	log *zerolog.Logger
+}
+
+func NewForumChangeListener(dsn string, log *zerolog.Logger) *ForumChangeListener {
+	return &ForumChangeListener{dsn: dsn, log: log}
+}
+
+// Listen passes every change to handle until ctx is done, reconnecting with backoff when the connection drops.
+// Notifications sent while disconnected are lost, so a ForumChangeReset is handed over after every (re)connect.
+func (l *ForumChangeListener) Listen(ctx context.Context, handle func(entity.ForumChange)) {
+	log := l.log.With().Str("op", listenForumChangesOp).Logger()
+	backoff := listenBackoffMin
+
+	for {
+		connected, err := l.listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = listenBackoffMin
+		}
+		log.Warn().Err(err).Dur("retry_in", backoff).Msg("Forum change listener disconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenBackoffMax)
+	}
+}
+
+func (l *ForumChangeListener) listen(ctx context.Context, handle func(entity.ForumChange)) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return false, fmt.Errorf("ForumChangeListener - listen - pgx.Connect(): %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+forumChangesChannel); err != nil {
+		return false, fmt.Errorf("ForumChangeListener - listen - conn.Exec(): %w", err)
+	}
+	handle(entity.ForumChange{Table: entity.ForumChangeReset})
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("ForumChangeListener - listen - conn.WaitForNotification(): %w", err)
+		}
+
+		var change entity.ForumChange
+		if err := json.Unmarshal([]byte(n.Payload), &change); err != nil {
+			l.log.Error().Err(err).Str("op", listenForumChangesOp).Str("payload", n.Payload).Msg("Malformed forum change notification")
+			continue
+		}
+		handle(change)
+	}
+}
@@ -2,19 +2,30 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog"
 )
 
+// ErrUnknownAuthor is returned when a topic or post names an author missing from the users table, which
+// is filled from the user service and can lag behind a new account.
+var ErrUnknownAuthor = errors.New("unknown author")
+
 type (
 	CategoryRepository interface {
 		Create(context.Context, entity.Category) (int64, error)
 		GetByID(context.Context, int64) (*entity.Category, error)
 		GetAll(context.Context) ([]entity.Category, error)
-		Update(ctx context.Context, id int64, title, description string) error
+		// GetAllVersion returns the version of the list GetAll returns, which is zero before the first change.
+		GetAllVersion(ctx context.Context) (entity.CollectionVersion, error)
+		// Update changes the category only while it is still at version and returns its new version.
+		// It fails with pgx.ErrNoRows when the category is gone or was changed since.
+		Update(ctx context.Context, id int64, title, description string, version int64) (int64, error)
 		Delete(ctx context.Context, id int64) error
 	}
 
@@ -22,7 +33,10 @@ type (
 		Create(context.Context, entity.Topic) (int64, error)
 		GetByID(context.Context, int64) (*entity.Topic, error)
 		GetByCategory(ct context.Context, categoryID int64) ([]entity.Topic, error)
-		Update(ctx context.Context, id int64, title string) error
+		// Update changes the title only while the topic is still at version and returns its new version.
+		// It fails with pgx.ErrNoRows when the topic is gone or was changed since.
+		Update(ctx context.Context, id int64, title string, version int64) (int64, error)
+		SetLocked(ctx context.Context, id int64, locked bool) error
 		Delete(ctx context.Context, id int64) error
 	}
 
@@ -30,7 +44,12 @@ type (
 		Create(context.Context, entity.Post) (int64, error)
 		GetByID(context.Context, int64) (*entity.Post, error)
 		GetByTopic(ctx context.Context, topicID int64) ([]entity.Post, error)
-		Update(ctx context.Context, id int64, content string) error
+		// GetByTopicVersion returns the version of the list GetByTopic returns, which is zero with the time
+		// the topic was created before the first change. It fails with pgx.ErrNoRows when the topic is gone.
+		GetByTopicVersion(ctx context.Context, topicID int64) (entity.CollectionVersion, error)
+		// Update changes the content only while the post is still at version and returns its new version.
+		// It fails with pgx.ErrNoRows when the post is gone or was changed since.
+		Update(ctx context.Context, id int64, content string, version int64) (int64, error)
 		Delete(ctx context.Context, id int64) error
 	}
 
@@ -44,16 +63,18 @@ const (
 	createOpCategory  = "CategoryRepository.Create"
 	getByIdOpCategory = "CategoryRepository.GetById"
 	getAllOpCategory  = "CategoryRepository.GetAll"
+	getAllVersionOp   = "CategoryRepository.GetAllVersion"
 	deleteOpCategory  = "CategoryRepository.Delete"
 	updateOpCategory  = "CategoryRepository.Update"
 )
 
 const (
-	createPostOp  = "PoptRepository.Create"
-	getByIdPostOp = "PostRepository.GetById"
-	getByTopicOp  = "PostRepository.GetAll"
-	deletePostOp  = "PostRepository.Delete"
-	updatePostOp  = "PostRepository.Update"
+	createPostOp        = "PoptRepository.Create"
+	getByIdPostOp       = "PostRepository.GetById"
+	getByTopicOp        = "PostRepository.GetAll"
+	getByTopicVersionOp = "PostRepository.GetByTopicVersion"
+	deletePostOp        = "PostRepository.Delete"
+	updatePostOp        = "PostRepository.Update"
 )
 
 const (
@@ -62,9 +83,13 @@ const (
 	getByCategoryOp = "TopicRepository.GetAll"
 	deleteTopicOp   = "TopicRepository.Delete"
 	updateTopicOp   = "TopicRepository.Update"
+	setLockedOp     = "TopicRepository.SetLocked"
 	countTopicOp    = "TopicRepository.CountByCategory"
 )
 
+// categoriesCollection names the list of categories in collection_versions.
+const categoriesCollection = "categories"
+
 type topicRepository struct {
 	pg  *postgres.Postgres
 	log *zerolog.Logger
@@ -114,10 +139,10 @@ func (r *categoryRepository) Create(ctx context.Context, category entity.Categor
 }
 
 func (r *categoryRepository) GetByID(ctx context.Context, id int64) (*entity.Category, error) {
-	row := r.pg.Pool.QueryRow(ctx, "SELECT id, title, description, created_at, updated_at FROM categories WHERE id = $1", id)
+	row := r.pg.Pool.QueryRow(ctx, "SELECT id, title, description, version, created_at, updated_at FROM categories WHERE id = $1", id)
 
 	var c entity.Category
-	if err := row.Scan(&c.ID, &c.Title, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	if err := row.Scan(&c.ID, &c.Title, &c.Description, &c.Version, &c.CreatedAt, &c.UpdatedAt); err != nil {
 		r.log.Error().Err(err).Str("op", getByIdOpCategory).Int64("id", id).Msg("Failed to get category")
 		return nil, fmt.Errorf("CategoryRepository - GetByID - row.Scan(): %w", err)
 	}
@@ -126,7 +151,7 @@ func (r *categoryRepository) GetByID(ctx context.Context, id int64) (*entity.Cat
 }
 
 func (r *categoryRepository) GetAll(ctx context.Context) ([]entity.Category, error) {
-	rows, err := r.pg.Pool.Query(ctx, "SELECT id, title, description, created_at, updated_at FROM categories ORDER BY id")
+	rows, err := r.pg.Pool.Query(ctx, "SELECT id, title, description, version, created_at, updated_at FROM categories ORDER BY id")
 	if err != nil {
 		r.log.Error().Err(err).Str("op", getAllOpCategory).Msg("Failed to get categories")
 		return nil, fmt.Errorf("CategoryRepository - GetCategories - pg.Pool.Query: %w", err)
@@ -136,7 +161,7 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]entity.Category, err
 	var categories []entity.Category
 	var c entity.Category
 	for rows.Next() {
-		err := rows.Scan(&c.ID, &c.Title, &c.Description, &c.CreatedAt, &c.UpdatedAt)
+		err := rows.Scan(&c.ID, &c.Title, &c.Description, &c.Version, &c.CreatedAt, &c.UpdatedAt)
 		if err != nil {
 			r.log.Error().Err(err).Str("op", getAllOpCategory).Msg("Failed to scan category")
 			return nil, fmt.Errorf("CategoryRepository - GetCategories - rows.Next() - rows.Scan(): %w", err)
@@ -147,22 +172,40 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]entity.Category, err
 	return categories, nil
 }
 
-func (r *categoryRepository) Update(ctx context.Context, id int64, title, description string) error {
-	_, err := r.pg.Pool.Exec(ctx, `
+func (r *categoryRepository) GetAllVersion(ctx context.Context) (entity.CollectionVersion, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT version, updated_at FROM collection_versions WHERE name = $1", categoriesCollection)
+
+	var v entity.CollectionVersion
+	if err := row.Scan(&v.Version, &v.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.CollectionVersion{}, nil
+		}
+		r.log.Error().Err(err).Str("op", getAllVersionOp).Msg("Failed to get categories version")
+		return entity.CollectionVersion{}, fmt.Errorf("CategoryRepository - GetAllVersion - row.Scan(): %w", err)
+	}
+
+	return v, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, id int64, title, description string, version int64) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
 	UPDATE categories
 	SET
 		title = COALESCE($1, title),
 		description = COALESCE($2, description),
+		version = version + 1,
 		updated_at = now()
-	WHERE id = $3
-	`, title, description, id)
+	WHERE id = $3 AND version = $4
+	RETURNING version
+	`, title, description, id, version)
 
-	if err != nil {
+	var newVersion int64
+	if err := row.Scan(&newVersion); err != nil {
 		r.log.Error().Err(err).Str("op", updateOpCategory).Msg("Failed to update category")
-		return fmt.Errorf("CategoryRepository - Update - Exec: %w", err)
+		return 0, fmt.Errorf("CategoryRepository - Update - row.Scan(): %w", err)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id int64) error {
@@ -211,6 +254,10 @@ func (r *postRepository) Create(ctx context.Context, post entity.Post) (int64, e
 
 	var id int64
 	if err := row.Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "posts_author_id_fkey" {
+			return 0, ErrUnknownAuthor
+		}
 		r.log.Error().Err(err).Str("op", createPostOp).Any("post", post).Msg("Failed to insert post")
 		return 0, fmt.Errorf("PostRepository - Create - row.Scan(): %w", err)
 	}
@@ -219,10 +266,10 @@ func (r *postRepository) Create(ctx context.Context, post entity.Post) (int64, e
 }
 
 func (r *postRepository) GetByID(ctx context.Context, id int64) (*entity.Post, error) {
-	row := r.pg.Pool.QueryRow(ctx, "SELECT id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE id = $1", id)
+	row := r.pg.Pool.QueryRow(ctx, "SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE id = $1", id)
 
 	var p entity.Post
-	if err := row.Scan(&p.ID, &p.Content, &p.AuthorID, &p.ReplyTo, &p.CreatedAt, &p.UpdatedAt); err != nil {
+	if err := row.Scan(&p.ID, &p.TopicID, &p.Content, &p.AuthorID, &p.ReplyTo, &p.Version, &p.CreatedAt, &p.UpdatedAt); err != nil {
 		r.log.Error().Err(err).Str("op", getByIdPostOp).Int64("id", id).Msg("Failed to get post")
 		return nil, fmt.Errorf("PostRepository - GetByID - row.Scan(): %w", err)
 	}
@@ -231,7 +278,7 @@ func (r *postRepository) GetByID(ctx context.Context, id int64) (*entity.Post, e
 }
 
 func (r *postRepository) GetByTopic(ctx context.Context, topicID int64) ([]entity.Post, error) {
-	rows, err := r.pg.Pool.Query(ctx, "SELECT id, topic_id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE topic_id = $1 ORDER BY created_at", topicID)
+	rows, err := r.pg.Pool.Query(ctx, "SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE topic_id = $1 ORDER BY created_at", topicID)
 	if err != nil {
 		r.log.Error().Err(err).Str("op", getByTopicOp).Int64("topic_id", topicID).Msg("Failed to get posts")
 		return nil, fmt.Errorf("PostRepository - GetByTopic - pg.Pool.Query: %w", err)
@@ -241,7 +288,7 @@ func (r *postRepository) GetByTopic(ctx context.Context, topicID int64) ([]entit
 	var posts []entity.Post
 	var p entity.Post
 	for rows.Next() {
-		err := rows.Scan(&p.ID, &p.TopicID, &p.Content, &p.AuthorID, &p.ReplyTo, &p.CreatedAt, &p.UpdatedAt)
+		err := rows.Scan(&p.ID, &p.TopicID, &p.Content, &p.AuthorID, &p.ReplyTo, &p.Version, &p.CreatedAt, &p.UpdatedAt)
 		if err != nil {
 			r.log.Error().Err(err).Str("op", getByTopicOp).Int64("topic_id", topicID).Msg("Failed to scan post")
 			return nil, fmt.Errorf("PostRepository - GetByTopic - rows.Next() - rows.Scan(): %w", err)
@@ -252,12 +299,31 @@ func (r *postRepository) GetByTopic(ctx context.Context, topicID int64) ([]entit
 	return posts, nil
 }
 
-func (r *postRepository) Update(ctx context.Context, id int64, content string) error {
-	if _, err := r.pg.Pool.Exec(ctx, "UPDATE posts SET content = $1, updated_at = now() WHERE id = $2", content, id); err != nil {
-		r.log.Error().Err(err).Str("op", getByTopicOp).Int64("id", id).Msg("Failed to update post")
-		return fmt.Errorf("PostRepository - Update - Exec: %w", err)
+func (r *postRepository) GetByTopicVersion(ctx context.Context, topicID int64) (entity.CollectionVersion, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	SELECT COALESCE(v.version, 0), COALESCE(v.updated_at, t.created_at)
+	FROM topics t
+	LEFT JOIN collection_versions v ON v.name = 'topic_posts:' || t.id
+	WHERE t.id = $1`, topicID)
+
+	var v entity.CollectionVersion
+	if err := row.Scan(&v.Version, &v.UpdatedAt); err != nil {
+		r.log.Error().Err(err).Str("op", getByTopicVersionOp).Int64("topic_id", topicID).Msg("Failed to get posts version")
+		return entity.CollectionVersion{}, fmt.Errorf("PostRepository - GetByTopicVersion - row.Scan(): %w", err)
 	}
-	return nil
+
+	return v, nil
+}
+
+func (r *postRepository) Update(ctx context.Context, id int64, content string, version int64) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, "UPDATE posts SET content = $1, version = version + 1, updated_at = now() WHERE id = $2 AND version = $3 RETURNING version", content, id, version)
+
+	var newVersion int64
+	if err := row.Scan(&newVersion); err != nil {
+		r.log.Error().Err(err).Str("op", updatePostOp).Int64("id", id).Msg("Failed to update post")
+		return 0, fmt.Errorf("PostRepository - Update - row.Scan(): %w", err)
+	}
+	return newVersion, nil
 }
 
 func (r *postRepository) Delete(ctx context.Context, id int64) error {
@@ -271,6 +337,10 @@ func (r *topicRepository) Create(ctx context.Context, topic entity.Topic) (int64
 	row := r.pg.Pool.QueryRow(ctx, "INSERT INTO topics (category_id, title, author_id) VALUES($1, $2, $3) RETURNING id", topic.CategoryID, topic.Title, topic.AuthorID)
 	var id int64
 	if err := row.Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "topics_author_id_fkey" {
+			return 0, ErrUnknownAuthor
+		}
 		r.log.Error().Err(err).Str("op", createTopicOp).Any("topic", topic).Msg("Failed to insert topic")
 		return 0, fmt.Errorf("TopicRepository - Create - row.Scan(): %w", err)
 	}
@@ -279,10 +349,10 @@ func (r *topicRepository) Create(ctx context.Context, topic entity.Topic) (int64
 }
 
 func (r *topicRepository) GetByID(ctx context.Context, id int64) (*entity.Topic, error) {
-	row := r.pg.Pool.QueryRow(ctx, "SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE id = $1", id)
+	row := r.pg.Pool.QueryRow(ctx, "SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE id = $1", id)
 
 	var t entity.Topic
-	if err := row.Scan(&t.ID, &t.CategoryID, &t.Title, &t.AuthorID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+	if err := row.Scan(&t.ID, &t.CategoryID, &t.Title, &t.AuthorID, &t.Locked, &t.Version, &t.CreatedAt, &t.UpdatedAt); err != nil {
 		r.log.Error().Err(err).Str("op", getByIdTopicOp).Int64("id", id).Msg("Failed to get topic")
 		return nil, fmt.Errorf("TopicRepository - GetByID - row.Scan(): %w", err)
 	}
@@ -291,7 +361,7 @@ func (r *topicRepository) GetByID(ctx context.Context, id int64) (*entity.Topic,
 }
 
 func (r *topicRepository) GetByCategory(ctx context.Context, categoryID int64) ([]entity.Topic, error) {
-	rows, err := r.pg.Pool.Query(ctx, "SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE category_id = $1 ORDER BY created_at DESC", categoryID)
+	rows, err := r.pg.Pool.Query(ctx, "SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE category_id = $1 ORDER BY created_at DESC", categoryID)
 	if err != nil {
 		r.log.Error().Err(err).Str("op", getByCategoryOp).Int64("category_id", categoryID).Msg("Failed to get topics")
 		return nil, fmt.Errorf("TopicRepository - GetByCategory - pg.Pool.Query: %w", err)
@@ -301,7 +371,7 @@ func (r *topicRepository) GetByCategory(ctx context.Context, categoryID int64) (
 	var topics []entity.Topic
 	var t entity.Topic
 	for rows.Next() {
-		err := rows.Scan(&t.ID, &t.CategoryID, &t.Title, &t.AuthorID, &t.CreatedAt, &t.UpdatedAt)
+		err := rows.Scan(&t.ID, &t.CategoryID, &t.Title, &t.AuthorID, &t.Locked, &t.Version, &t.CreatedAt, &t.UpdatedAt)
 		if err != nil {
 			r.log.Error().Err(err).Str("op", getByCategoryOp).Int64("category_id", categoryID).Msg("Failed to scan topic")
 			return nil, fmt.Errorf("TopicRepository - GetByCategory - rows.Next() - rows.Scan(): %w", err)
@@ -312,10 +382,21 @@ func (r *topicRepository) GetByCategory(ctx context.Context, categoryID int64) (
 	return topics, nil
 }
 
-func (r *topicRepository) Update(ctx context.Context, id int64, title string) error {
-	if _, err := r.pg.Pool.Exec(ctx, "UPDATE topics SET title = $1, updated_at = now() WHERE id = $2", title, id); err != nil {
+func (r *topicRepository) Update(ctx context.Context, id int64, title string, version int64) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, "UPDATE topics SET title = $1, version = version + 1, updated_at = now() WHERE id = $2 AND version = $3 RETURNING version", title, id, version)
+
+	var newVersion int64
+	if err := row.Scan(&newVersion); err != nil {
 		r.log.Error().Err(err).Str("op", updateTopicOp).Int64("id", id).Msg("Failed to update topic")
-		return fmt.Errorf("TopicRepository - Update - Exec: %w", err)
+		return 0, fmt.Errorf("TopicRepository - Update - row.Scan(): %w", err)
+	}
+	return newVersion, nil
+}
+
+func (r *topicRepository) SetLocked(ctx context.Context, id int64, locked bool) error {
+	if _, err := r.pg.Pool.Exec(ctx, "UPDATE topics SET locked = $1, version = version + 1, updated_at = now() WHERE id = $2", locked, id); err != nil {
+		r.log.Error().Err(err).Str("op", setLockedOp).Int64("id", id).Bool("locked", locked).Msg("Failed to set topic lock")
+		return fmt.Errorf("TopicRepository - SetLocked - Exec: %w", err)
 	}
 	return nil
 }
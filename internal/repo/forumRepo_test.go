@@ -8,6 +8,8 @@ import (
 
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -62,11 +64,11 @@ func TestCategoryRepository_GetByID(t *testing.T) {
 	repo := NewCategoryRepository(pg, &logger)
 
 	id := int64(1)
-	expectedCategory := &entity.Category{ID: id, Title: "test", Description: "test", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	expectedCategory := &entity.Category{ID: id, Title: "test", Description: "test", Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 
 	t.Run("Success", func(t *testing.T) {
-		row := pgxmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).AddRow(expectedCategory.ID, expectedCategory.Title, expectedCategory.Description, expectedCategory.CreatedAt, expectedCategory.UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, title, description, created_at, updated_at FROM categories WHERE id").WithArgs(id).WillReturnRows(row)
+		row := pgxmock.NewRows([]string{"id", "title", "description", "version", "created_at", "updated_at"}).AddRow(expectedCategory.ID, expectedCategory.Title, expectedCategory.Description, expectedCategory.Version, expectedCategory.CreatedAt, expectedCategory.UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, title, description, version, created_at, updated_at FROM categories WHERE id").WithArgs(id).WillReturnRows(row)
 
 		category, err := repo.GetByID(ctx, id)
 		assert.NoError(t, err)
@@ -76,7 +78,7 @@ func TestCategoryRepository_GetByID(t *testing.T) {
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("SELECT id, title, description, created_at, updated_at FROM categories WHERE id").WithArgs(id).WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, title, description, version, created_at, updated_at FROM categories WHERE id").WithArgs(id).WillReturnError(dbErr)
 
 		_, err := repo.GetByID(ctx, id)
 		assert.Error(t, err)
@@ -98,14 +100,14 @@ func TestCategoryRepository_GetAll(t *testing.T) {
 	repo := NewCategoryRepository(pg, &logger)
 
 	expectedCategories := []entity.Category{
-		{ID: 1, Title: "test1", Description: "test1", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: 2, Title: "test2", Description: "test2", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 1, Title: "test1", Description: "test1", Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 2, Title: "test2", Description: "test2", Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).AddRow(expectedCategories[0].ID, expectedCategories[0].Title, expectedCategories[0].Description, expectedCategories[0].CreatedAt, expectedCategories[0].UpdatedAt).
-			AddRow(expectedCategories[1].ID, expectedCategories[1].Title, expectedCategories[1].Description, expectedCategories[1].CreatedAt, expectedCategories[1].UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, title, description, created_at, updated_at FROM categories ORDER BY id").WillReturnRows(rows)
+		rows := pgxmock.NewRows([]string{"id", "title", "description", "version", "created_at", "updated_at"}).AddRow(expectedCategories[0].ID, expectedCategories[0].Title, expectedCategories[0].Description, expectedCategories[0].Version, expectedCategories[0].CreatedAt, expectedCategories[0].UpdatedAt).
+			AddRow(expectedCategories[1].ID, expectedCategories[1].Title, expectedCategories[1].Description, expectedCategories[1].Version, expectedCategories[1].CreatedAt, expectedCategories[1].UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, title, description, version, created_at, updated_at FROM categories ORDER BY id").WillReturnRows(rows)
 
 		categories, err := repo.GetAll(ctx)
 		assert.NoError(t, err)
@@ -115,7 +117,7 @@ func TestCategoryRepository_GetAll(t *testing.T) {
 
 	t.Run("Query error", func(t *testing.T) {
 		dbErr := errors.New("query db error")
-		mockPool.ExpectQuery("SELECT id, title, description, created_at, updated_at FROM categories ORDER BY id").WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, title, description, version, created_at, updated_at FROM categories ORDER BY id").WillReturnError(dbErr)
 
 		_, err := repo.GetAll(ctx)
 		assert.Error(t, err)
@@ -126,10 +128,10 @@ func TestCategoryRepository_GetAll(t *testing.T) {
 
 	t.Run("Scan error", func(t *testing.T) {
 		dbErr := errors.New("scan error")
-		rows := pgxmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).AddRow(1, "test1", "test1", time.Now(), time.Now()).
+		rows := pgxmock.NewRows([]string{"id", "title", "description", "version", "created_at", "updated_at"}).AddRow(1, "test1", "test1", int64(1), time.Now(), time.Now()).
 			RowError(0, dbErr)
 
-		mockPool.ExpectQuery("SELECT id, title, description, created_at, updated_at FROM categories ORDER BY id").WillReturnRows(rows)
+		mockPool.ExpectQuery("SELECT id, title, description, version, created_at, updated_at FROM categories ORDER BY id").WillReturnRows(rows)
 
 		_, err := repo.GetAll(ctx)
 		assert.Error(t, err)
@@ -139,6 +141,49 @@ func TestCategoryRepository_GetAll(t *testing.T) {
 	})
 }
 
+func TestCategoryRepository_GetAllVersion(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+	repo := NewCategoryRepository(pg, &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		updatedAt := time.Now()
+		mockPool.ExpectQuery("SELECT version, updated_at FROM collection_versions WHERE name = \\$1").WithArgs(categoriesCollection).
+			WillReturnRows(pgxmock.NewRows([]string{"version", "updated_at"}).AddRow(int64(5), updatedAt))
+
+		version, err := repo.GetAllVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CollectionVersion{Version: 5, UpdatedAt: updatedAt}, version)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Never changed", func(t *testing.T) {
+		mockPool.ExpectQuery("SELECT version, updated_at FROM collection_versions WHERE name = \\$1").WithArgs(categoriesCollection).
+			WillReturnError(pgx.ErrNoRows)
+
+		version, err := repo.GetAllVersion(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, version)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockPool.ExpectQuery("SELECT version, updated_at FROM collection_versions WHERE name = \\$1").WithArgs(categoriesCollection).
+			WillReturnError(dbErr)
+
+		_, err := repo.GetAllVersion(ctx)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
 func TestCategoryRepository_Update(t *testing.T) {
 	ctx := context.Background()
 	logger := zerolog.Nop()
@@ -150,27 +195,37 @@ func TestCategoryRepository_Update(t *testing.T) {
 	pg := postgres.NewWithPool(mockPool)
 	repo := NewCategoryRepository(pg, &logger)
 
-	expectedSql := "UPDATE categories SET title = COALESCE\\(\\$1, title\\), description = COALESCE\\(\\$2, description\\), updated_at = now\\(\\) WHERE id = \\$3"
+	expectedSql := "UPDATE categories SET title = COALESCE\\(\\$1, title\\), description = COALESCE\\(\\$2, description\\), version = version \\+ 1, updated_at = now\\(\\) WHERE id = \\$3 AND version = \\$4 RETURNING version"
 
 	id := int64(1)
 	title := "updated title"
 	description := "updated description"
+	version := int64(3)
 
 	t.Run("Success", func(t *testing.T) {
-		mockPool.ExpectExec(expectedSql).WithArgs(title, description, id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, description, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(version + 1))
 
-		err := repo.Update(ctx, id, title, description)
+		newVersion, err := repo.Update(ctx, id, title, description, version)
 		assert.NoError(t, err)
+		assert.Equal(t, version+1, newVersion)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, description, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}))
+
+		_, err := repo.Update(ctx, id, title, description, version)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectExec(expectedSql).WithArgs(title, description, id).WillReturnError(dbErr)
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, description, id, version).WillReturnError(dbErr)
 
-		err := repo.Update(ctx, id, title, description)
+		_, err := repo.Update(ctx, id, title, description, version)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "CategoryRepository - Update - Exec")
+		assert.Contains(t, err.Error(), "CategoryRepository - Update - row.Scan()")
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
@@ -343,6 +398,15 @@ func TestPostRepository_Create(t *testing.T) {
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
+
+	t.Run("Unknown author", func(t *testing.T) {
+		fkErr := &pgconn.PgError{Code: "23503", ConstraintName: "posts_author_id_fkey"}
+		mockPool.ExpectQuery("INSERT INTO posts").WithArgs(testPost.TopicID, testPost.AuthorID, testPost.Content, testPost.ReplyTo).WillReturnError(fkErr)
+
+		_, err := repo.Create(ctx, testPost)
+		assert.ErrorIs(t, err, ErrUnknownAuthor)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
 }
 
 func TestPostRepository_GetByID(t *testing.T) {
@@ -359,10 +423,10 @@ func TestPostRepository_GetByID(t *testing.T) {
 	id := int64(1)
 	authorID := int64(1)
 
-	expectedPost := &entity.Post{ID: 1, AuthorID: &authorID, Content: "test", ReplyTo: nil, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	expectedPost := &entity.Post{ID: 1, TopicID: 2, AuthorID: &authorID, Content: "test", ReplyTo: nil, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 	t.Run("Success", func(t *testing.T) {
-		row := pgxmock.NewRows([]string{"id", "content", "author_id", "reply_to", "created_at", "updated_at"}).AddRow(expectedPost.ID, expectedPost.Content, expectedPost.AuthorID, expectedPost.ReplyTo, expectedPost.CreatedAt, expectedPost.UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE id").WithArgs(id).WillReturnRows(row)
+		row := pgxmock.NewRows([]string{"id", "topic_id", "content", "author_id", "reply_to", "version", "created_at", "updated_at"}).AddRow(expectedPost.ID, expectedPost.TopicID, expectedPost.Content, expectedPost.AuthorID, expectedPost.ReplyTo, expectedPost.Version, expectedPost.CreatedAt, expectedPost.UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE id").WithArgs(id).WillReturnRows(row)
 
 		post, err := repo.GetByID(ctx, id)
 		assert.NoError(t, err)
@@ -372,7 +436,7 @@ func TestPostRepository_GetByID(t *testing.T) {
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("SELECT id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE id").WithArgs(id).WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE id").WithArgs(id).WillReturnError(dbErr)
 
 		_, err := repo.GetByID(ctx, id)
 		assert.Error(t, err)
@@ -396,14 +460,14 @@ func TestPostRepository_GetByTopic(t *testing.T) {
 	topicID := int64(1)
 	authorID := int64(1)
 	expectedPosts := []entity.Post{
-		{ID: 1, TopicID: topicID, Content: "test", AuthorID: &authorID, ReplyTo: nil, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: 2, TopicID: topicID, Content: "test2", AuthorID: &authorID, ReplyTo: nil, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 1, TopicID: topicID, Content: "test", AuthorID: &authorID, ReplyTo: nil, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 2, TopicID: topicID, Content: "test2", AuthorID: &authorID, ReplyTo: nil, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "topic_id", "content", "author_id", "reply_to", "created_at", "updated_at"}).AddRow(expectedPosts[0].ID, expectedPosts[0].TopicID, expectedPosts[0].Content, expectedPosts[0].AuthorID, expectedPosts[0].ReplyTo, expectedPosts[0].CreatedAt, expectedPosts[0].UpdatedAt).
-			AddRow(expectedPosts[1].ID, expectedPosts[1].TopicID, expectedPosts[1].Content, expectedPosts[1].AuthorID, expectedPosts[1].ReplyTo, expectedPosts[1].CreatedAt, expectedPosts[1].UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnRows(rows)
+		rows := pgxmock.NewRows([]string{"id", "topic_id", "content", "author_id", "reply_to", "version", "created_at", "updated_at"}).AddRow(expectedPosts[0].ID, expectedPosts[0].TopicID, expectedPosts[0].Content, expectedPosts[0].AuthorID, expectedPosts[0].ReplyTo, expectedPosts[0].Version, expectedPosts[0].CreatedAt, expectedPosts[0].UpdatedAt).
+			AddRow(expectedPosts[1].ID, expectedPosts[1].TopicID, expectedPosts[1].Content, expectedPosts[1].AuthorID, expectedPosts[1].ReplyTo, expectedPosts[1].Version, expectedPosts[1].CreatedAt, expectedPosts[1].UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnRows(rows)
 
 		posts, err := repo.GetByTopic(ctx, topicID)
 		assert.NoError(t, err)
@@ -413,7 +477,7 @@ func TestPostRepository_GetByTopic(t *testing.T) {
 
 	t.Run("Query error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnError(dbErr)
 
 		_, err := repo.GetByTopic(ctx, topicID)
 		assert.Error(t, err)
@@ -424,9 +488,9 @@ func TestPostRepository_GetByTopic(t *testing.T) {
 
 	t.Run("Scan error", func(t *testing.T) {
 		dbErr := errors.New("scan db error")
-		rows := pgxmock.NewRows([]string{"id", "topic_id", "content", "author_id", "reply_to", "created_at", "updated_at"}).AddRow(expectedPosts[0].ID, expectedPosts[0].TopicID, expectedPosts[0].Content, expectedPosts[0].AuthorID, expectedPosts[0].ReplyTo, expectedPosts[0].CreatedAt, expectedPosts[0].UpdatedAt).
+		rows := pgxmock.NewRows([]string{"id", "topic_id", "content", "author_id", "reply_to", "version", "created_at", "updated_at"}).AddRow(expectedPosts[0].ID, expectedPosts[0].TopicID, expectedPosts[0].Content, expectedPosts[0].AuthorID, expectedPosts[0].ReplyTo, expectedPosts[0].Version, expectedPosts[0].CreatedAt, expectedPosts[0].UpdatedAt).
 			RowError(0, dbErr)
-		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnRows(rows)
+		mockPool.ExpectQuery("SELECT id, topic_id, content, author_id, reply_to, version, created_at, updated_at FROM posts WHERE topic_id = \\$1 ORDER BY created_at").WithArgs(topicID).WillReturnRows(rows)
 
 		_, err := repo.GetByTopic(ctx, topicID)
 		assert.Error(t, err)
@@ -436,6 +500,40 @@ func TestPostRepository_GetByTopic(t *testing.T) {
 	})
 }
 
+func TestPostRepository_GetByTopicVersion(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+	repo := NewPostRepository(pg, &logger)
+
+	topicID := int64(1)
+
+	t.Run("Success", func(t *testing.T) {
+		updatedAt := time.Now()
+		mockPool.ExpectQuery("SELECT (.+) FROM topics t LEFT JOIN collection_versions").WithArgs(topicID).
+			WillReturnRows(pgxmock.NewRows([]string{"version", "updated_at"}).AddRow(int64(3), updatedAt))
+
+		version, err := repo.GetByTopicVersion(ctx, topicID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CollectionVersion{Version: 3, UpdatedAt: updatedAt}, version)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Topic not found", func(t *testing.T) {
+		mockPool.ExpectQuery("SELECT (.+) FROM topics t LEFT JOIN collection_versions").WithArgs(topicID).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetByTopicVersion(ctx, topicID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
 func TestPostRepository_Update(t *testing.T) {
 	ctx := context.Background()
 	logger := zerolog.Nop()
@@ -447,26 +545,36 @@ func TestPostRepository_Update(t *testing.T) {
 	pg := postgres.NewWithPool(mockPool)
 	repo := NewPostRepository(pg, &logger)
 
-	expectedSql := "UPDATE posts SET content = \\$1, updated_at = now\\(\\) WHERE id = \\$2"
+	expectedSql := "UPDATE posts SET content = \\$1, version = version \\+ 1, updated_at = now\\(\\) WHERE id = \\$2 AND version = \\$3 RETURNING version"
 
 	id := int64(1)
 	content := "updated content"
+	version := int64(3)
 
 	t.Run("Success", func(t *testing.T) {
-		mockPool.ExpectExec(expectedSql).WithArgs(content, id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectQuery(expectedSql).WithArgs(content, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(version + 1))
 
-		err := repo.Update(ctx, id, content)
+		newVersion, err := repo.Update(ctx, id, content, version)
 		assert.NoError(t, err)
+		assert.Equal(t, version+1, newVersion)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockPool.ExpectQuery(expectedSql).WithArgs(content, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}))
+
+		_, err := repo.Update(ctx, id, content, version)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectExec(expectedSql).WithArgs(content, id).WillReturnError(dbErr)
+		mockPool.ExpectQuery(expectedSql).WithArgs(content, id, version).WillReturnError(dbErr)
 
-		err := repo.Update(ctx, id, content)
+		_, err := repo.Update(ctx, id, content, version)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "PostRepository - Update - Exec")
+		assert.Contains(t, err.Error(), "PostRepository - Update - row.Scan()")
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
@@ -540,6 +648,15 @@ func TestTopicRepository_Create(t *testing.T) {
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
+
+	t.Run("Unknown author", func(t *testing.T) {
+		fkErr := &pgconn.PgError{Code: "23503", ConstraintName: "topics_author_id_fkey"}
+		mockPool.ExpectQuery("INSERT INTO topics").WithArgs(testTopic.CategoryID, testTopic.Title, testTopic.AuthorID).WillReturnError(fkErr)
+
+		_, err := repo.Create(ctx, testTopic)
+		assert.ErrorIs(t, err, ErrUnknownAuthor)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
 }
 
 func TestTopicRepository_GetByID(t *testing.T) {
@@ -556,11 +673,11 @@ func TestTopicRepository_GetByID(t *testing.T) {
 	id := int64(1)
 	authorID := int64(1)
 
-	expectedTopic := &entity.Topic{ID: id, CategoryID: 1, Title: "test", AuthorID: &authorID, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	expectedTopic := &entity.Topic{ID: id, CategoryID: 1, Title: "test", AuthorID: &authorID, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 
 	t.Run("Success", func(t *testing.T) {
-		row := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "created_at", "updated_at"}).AddRow(expectedTopic.ID, expectedTopic.CategoryID, expectedTopic.Title, expectedTopic.AuthorID, expectedTopic.CreatedAt, expectedTopic.UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE id").WithArgs(id).WillReturnRows(row)
+		row := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "locked", "version", "created_at", "updated_at"}).AddRow(expectedTopic.ID, expectedTopic.CategoryID, expectedTopic.Title, expectedTopic.AuthorID, expectedTopic.Locked, expectedTopic.Version, expectedTopic.CreatedAt, expectedTopic.UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE id").WithArgs(id).WillReturnRows(row)
 
 		topic, err := repo.GetByID(ctx, id)
 		assert.NoError(t, err)
@@ -570,7 +687,7 @@ func TestTopicRepository_GetByID(t *testing.T) {
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE id").WithArgs(id).WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE id").WithArgs(id).WillReturnError(dbErr)
 
 		_, err := repo.GetByID(ctx, id)
 		assert.Error(t, err)
@@ -594,14 +711,14 @@ func TestTopicRepository_GetByCategory(t *testing.T) {
 	categoryID := int64(1)
 	authorID := int64(1)
 	expectedTopics := []entity.Topic{
-		{ID: 1, CategoryID: categoryID, Title: "test", AuthorID: &authorID, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: 2, CategoryID: categoryID, Title: "test2", AuthorID: &authorID, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 1, CategoryID: categoryID, Title: "test", AuthorID: &authorID, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: 2, CategoryID: categoryID, Title: "test2", AuthorID: &authorID, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "created_at", "updated_at"}).AddRow(expectedTopics[0].ID, expectedTopics[0].CategoryID, expectedTopics[0].Title, expectedTopics[0].AuthorID, expectedTopics[0].CreatedAt, expectedTopics[0].UpdatedAt).
-			AddRow(expectedTopics[1].ID, expectedTopics[1].CategoryID, expectedTopics[1].Title, expectedTopics[1].AuthorID, expectedTopics[1].CreatedAt, expectedTopics[1].UpdatedAt)
-		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnRows(rows)
+		rows := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "locked", "version", "created_at", "updated_at"}).AddRow(expectedTopics[0].ID, expectedTopics[0].CategoryID, expectedTopics[0].Title, expectedTopics[0].AuthorID, expectedTopics[0].Locked, expectedTopics[0].Version, expectedTopics[0].CreatedAt, expectedTopics[0].UpdatedAt).
+			AddRow(expectedTopics[1].ID, expectedTopics[1].CategoryID, expectedTopics[1].Title, expectedTopics[1].AuthorID, expectedTopics[1].Locked, expectedTopics[1].Version, expectedTopics[1].CreatedAt, expectedTopics[1].UpdatedAt)
+		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnRows(rows)
 
 		topics, err := repo.GetByCategory(ctx, categoryID)
 		assert.NoError(t, err)
@@ -611,7 +728,7 @@ func TestTopicRepository_GetByCategory(t *testing.T) {
 
 	t.Run("Query error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnError(dbErr)
+		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnError(dbErr)
 
 		_, err := repo.GetByCategory(ctx, categoryID)
 		assert.Error(t, err)
@@ -623,9 +740,9 @@ func TestTopicRepository_GetByCategory(t *testing.T) {
 
 	t.Run("Scan error", func(t *testing.T) {
 		dbErr := errors.New("scan db error")
-		rows := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "created_at", "updated_at"}).AddRow(expectedTopics[0].ID, expectedTopics[0].CategoryID, expectedTopics[0].Title, expectedTopics[0].AuthorID, expectedTopics[0].CreatedAt, expectedTopics[0].UpdatedAt).
+		rows := pgxmock.NewRows([]string{"id", "category_id", "title", "author_id", "locked", "version", "created_at", "updated_at"}).AddRow(expectedTopics[0].ID, expectedTopics[0].CategoryID, expectedTopics[0].Title, expectedTopics[0].AuthorID, expectedTopics[0].Locked, expectedTopics[0].Version, expectedTopics[0].CreatedAt, expectedTopics[0].UpdatedAt).
 			RowError(0, dbErr)
-		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnRows(rows)
+		mockPool.ExpectQuery("SELECT id, category_id, title, author_id, locked, version, created_at, updated_at FROM topics WHERE category_id").WithArgs(categoryID).WillReturnRows(rows)
 
 		_, err := repo.GetByCategory(ctx, categoryID)
 		assert.Error(t, err)
@@ -646,26 +763,36 @@ func TestTopicRepository_Update(t *testing.T) {
 	pg := postgres.NewWithPool(mockPool)
 	repo := NewTopicRepository(pg, &logger)
 
-	expectedSql := "UPDATE topics SET title = \\$1, updated_at = now\\(\\) WHERE id = \\$2"
+	expectedSql := "UPDATE topics SET title = \\$1, version = version \\+ 1, updated_at = now\\(\\) WHERE id = \\$2 AND version = \\$3 RETURNING version"
 
 	id := int64(1)
 	title := "updated title"
+	version := int64(3)
 
 	t.Run("Success", func(t *testing.T) {
-		mockPool.ExpectExec(expectedSql).WithArgs(title, id).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(version + 1))
 
-		err := repo.Update(ctx, id, title)
+		newVersion, err := repo.Update(ctx, id, title, version)
 		assert.NoError(t, err)
+		assert.Equal(t, version+1, newVersion)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, id, version).WillReturnRows(pgxmock.NewRows([]string{"version"}))
+
+		_, err := repo.Update(ctx, id, title, version)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
 
 	t.Run("DB error", func(t *testing.T) {
 		dbErr := errors.New("some db error")
-		mockPool.ExpectExec(expectedSql).WithArgs(title, id).WillReturnError(dbErr)
+		mockPool.ExpectQuery(expectedSql).WithArgs(title, id, version).WillReturnError(dbErr)
 
-		err := repo.Update(ctx, id, title)
+		_, err := repo.Update(ctx, id, title, version)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "TopicRepository - Update - Exec")
+		assert.Contains(t, err.Error(), "TopicRepository - Update - row.Scan()")
 		assert.ErrorIs(t, err, dbErr)
 		assert.NoError(t, mockPool.ExpectationsWereMet())
 	})
@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// IdempotencyRepository keeps the requests sent with an Idempotency-Key and their replies until they
+// expire. Keys belong to the user who sent them.
+type IdempotencyRepository interface {
+	// Acquire locks the record's key for the request until record.ExpiresAt and sets record.LockToken.
+	// When the key is already held or answered it returns the stored record and false instead. Expired
+	// records do not hold keys.
+	Acquire(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error)
+	// Complete stores the reply to the request holding the key and keeps it until record.ExpiresAt. It
+	// returns ErrIdempotencyLockLost when the lock expired and another request took the key over.
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+	// Release gives up a key whose request was not answered, so it can be retried. A lock taken over by
+	// another request is left alone.
+	Release(ctx context.Context, record *entity.IdempotencyRecord) error
+}
+
+var ErrIdempotencyLockLost = errors.New("idempotency key lock was taken over by another request")
+
+// acquireAttempts bounds how often Acquire retries a key that is released while it looks it up.
+const acquireAttempts = 3
+
+const (
+	acquireIdempotencyKeyOp  = "IdempotencyRepository.Acquire"
+	completeIdempotencyKeyOp = "IdempotencyRepository.Complete"
+)
+
+type idempotencyRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewIdempotencyRepository(pg *postgres.Postgres, log *zerolog.Logger) IdempotencyRepository {
+	return &idempotencyRepository{pg, log}
+}
+
+// Acquire inserts the lock row, taking over an expired one. The unique key makes concurrent requests
+// with the same key wait for each other, so exactly one of them gets the lock.
+func (r *idempotencyRepository) Acquire(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, false, fmt.Errorf("IdempotencyRepository - Acquire - newLockToken(): %w", err)
+	}
+
+	for range acquireAttempts {
+		tag, err := r.pg.Pool.Exec(ctx, `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, lock_token, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, lock_token = EXCLUDED.lock_token, status_code = NULL,
+			content_type = '', body = NULL, created_at = now(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()`,
+			record.UserID, record.Key, record.RequestHash, token, record.ExpiresAt)
+		if err != nil {
+			return nil, false, fmt.Errorf("IdempotencyRepository - Acquire - pg.Pool.Exec(): %w", err)
+		}
+		if tag.RowsAffected() == 1 {
+			record.LockToken = token
+			return nil, true, nil
+		}
+
+		row := r.pg.Pool.QueryRow(ctx, `
+		SELECT user_id, idempotency_key, request_hash, COALESCE(status_code, 0), content_type, body, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2`, record.UserID, record.Key)
+
+		var stored entity.IdempotencyRecord
+		err = row.Scan(&stored.UserID, &stored.Key, &stored.RequestHash, &stored.StatusCode, &stored.ContentType, &stored.Body, &stored.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The holder released the key in between.
+			r.log.Debug().Str("op", acquireIdempotencyKeyOp).Int64("user_id", record.UserID).Msg("Idempotency key released during acquire, retrying")
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("IdempotencyRepository - Acquire - row.Scan(): %w", err)
+		}
+		return &stored, false, nil
+	}
+
+	return nil, false, fmt.Errorf("IdempotencyRepository - Acquire: key changed hands %d times", acquireAttempts)
+}
+
+// Complete stores the reply if the record still holds the lock. Expired records left behind are cleaned
+// up on the way.
+func (r *idempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	tag, err := r.pg.Pool.Exec(ctx, `
+	UPDATE idempotency_keys
+	SET status_code = $4, content_type = $5, body = $6, expires_at = $7
+	WHERE user_id = $1 AND idempotency_key = $2 AND lock_token = $3 AND status_code IS NULL`,
+		record.UserID, record.Key, record.LockToken, record.StatusCode, record.ContentType, record.Body, record.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("IdempotencyRepository - Complete - pg.Pool.Exec(): %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("IdempotencyRepository - Complete: %w", ErrIdempotencyLockLost)
+	}
+
+	if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at < now()"); err != nil {
+		r.log.Warn().Err(err).Str("op", completeIdempotencyKeyOp).Msg("Failed to delete expired idempotency keys")
+	}
+
+	return nil
+}
+
+// Release deletes the lock row if the record still holds it. A key that was answered meanwhile is left alone.
+func (r *idempotencyRepository) Release(ctx context.Context, record *entity.IdempotencyRecord) error {
+	_, err := r.pg.Pool.Exec(ctx, `
+	DELETE FROM idempotency_keys
+	WHERE user_id = $1 AND idempotency_key = $2 AND lock_token = $3 AND status_code IS NULL`,
+		record.UserID, record.Key, record.LockToken)
+	if err != nil {
+		return fmt.Errorf("IdempotencyRepository - Release - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+// newLockToken returns a random 16-byte hex token for one Acquire.
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository_Acquire(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewIdempotencyRepository(postgres.NewWithPool(mockPool), &logger)
+
+	expiresAt := time.Now().Add(time.Minute)
+	record := &entity.IdempotencyRecord{UserID: 1, Key: "key-1", RequestHash: "hash", ExpiresAt: expiresAt}
+	columns := []string{"user_id", "idempotency_key", "request_hash", "status_code", "content_type", "body", "expires_at"}
+
+	t.Run("Acquired", func(t *testing.T) {
+		mockPool.ExpectExec("INSERT INTO idempotency_keys").WithArgs(record.UserID, record.Key, record.RequestHash, pgxmock.AnyArg(), expiresAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		stored, acquired, err := repo.Acquire(ctx, record)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		assert.Nil(t, stored)
+		assert.Len(t, record.LockToken, 32)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Answered", func(t *testing.T) {
+		mockPool.ExpectExec("INSERT INTO idempotency_keys").WithArgs(record.UserID, record.Key, record.RequestHash, pgxmock.AnyArg(), expiresAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 0))
+		mockPool.ExpectQuery("SELECT (.+) FROM idempotency_keys").WithArgs(record.UserID, record.Key).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(record.UserID, record.Key, "hash", http.StatusCreated, "application/json", []byte(`{"id":7}`), expiresAt))
+
+		stored, acquired, err := repo.Acquire(ctx, record)
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+		require.NotNil(t, stored)
+		assert.Equal(t, http.StatusCreated, stored.StatusCode)
+		assert.Equal(t, []byte(`{"id":7}`), stored.Body)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Released in between", func(t *testing.T) {
+		mockPool.ExpectExec("INSERT INTO idempotency_keys").WithArgs(record.UserID, record.Key, record.RequestHash, pgxmock.AnyArg(), expiresAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 0))
+		mockPool.ExpectQuery("SELECT (.+) FROM idempotency_keys").WithArgs(record.UserID, record.Key).
+			WillReturnError(pgx.ErrNoRows)
+		mockPool.ExpectExec("INSERT INTO idempotency_keys").WithArgs(record.UserID, record.Key, record.RequestHash, pgxmock.AnyArg(), expiresAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		_, acquired, err := repo.Acquire(ctx, record)
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockPool.ExpectExec("INSERT INTO idempotency_keys").WithArgs(record.UserID, record.Key, record.RequestHash, pgxmock.AnyArg(), expiresAt).
+			WillReturnError(dbErr)
+
+		_, acquired, err := repo.Acquire(ctx, record)
+		assert.ErrorIs(t, err, dbErr)
+		assert.False(t, acquired)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestIdempotencyRepository_Complete(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewIdempotencyRepository(postgres.NewWithPool(mockPool), &logger)
+
+	record := &entity.IdempotencyRecord{
+		UserID:      1,
+		Key:         "key-1",
+		LockToken:   "token",
+		StatusCode:  http.StatusCreated,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":7}`),
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE idempotency_keys").
+			WithArgs(record.UserID, record.Key, record.LockToken, record.StatusCode, record.ContentType, record.Body, record.ExpiresAt).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectExec("DELETE FROM idempotency_keys WHERE expires_at").
+			WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+		assert.NoError(t, repo.Complete(ctx, record))
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Cleanup failure is ignored", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE idempotency_keys").
+			WithArgs(record.UserID, record.Key, record.LockToken, record.StatusCode, record.ContentType, record.Body, record.ExpiresAt).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectExec("DELETE FROM idempotency_keys WHERE expires_at").
+			WillReturnError(errors.New("db error"))
+
+		assert.NoError(t, repo.Complete(ctx, record))
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Lock taken over", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE idempotency_keys").
+			WithArgs(record.UserID, record.Key, record.LockToken, record.StatusCode, record.ContentType, record.Body, record.ExpiresAt).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		assert.ErrorIs(t, repo.Complete(ctx, record), ErrIdempotencyLockLost)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestIdempotencyRepository_Release(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewIdempotencyRepository(postgres.NewWithPool(mockPool), &logger)
+
+	mockPool.ExpectExec("DELETE FROM idempotency_keys").WithArgs(int64(1), "key-1", "token").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	assert.NoError(t, repo.Release(ctx, &entity.IdempotencyRecord{UserID: 1, Key: "key-1", LockToken: "token"}))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
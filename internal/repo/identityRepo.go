@@ -0,0 +1,164 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+var ErrUsernameTaken = errors.New("username already taken")
+
+type IdentityRepository interface {
+	SaveState(ctx context.Context, state *entity.OIDCState) error
+	ConsumeState(ctx context.Context, state string) (*entity.OIDCState, error)
+	GetUserID(ctx context.Context, provider, subject string) (int64, error)
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	CreateWithUser(ctx context.Context, username string, identity *entity.UserIdentity) (int64, error)
+	GetByUserID(ctx context.Context, userID int64) ([]entity.UserIdentity, error)
+	Delete(ctx context.Context, userID int64, provider string) error
+}
+
+const (
+	saveStateOp      = "IdentityRepository.SaveState"
+	consumeStateOp   = "IdentityRepository.ConsumeState"
+	createIdentityOp = "IdentityRepository.Create"
+	createWithUserOp = "IdentityRepository.CreateWithUser"
+	getIdentitiesOp  = "IdentityRepository.GetByUserID"
+	deleteIdentityOp = "IdentityRepository.Delete"
+)
+
+type identityRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewIdentityRepository(pg *postgres.Postgres, log *zerolog.Logger) IdentityRepository {
+	return &identityRepository{pg, log}
+}
+
+func (r *identityRepository) SaveState(ctx context.Context, state *entity.OIDCState) error {
+	var linkUserID *int64
+	if state.LinkUserID != 0 {
+		linkUserID = &state.LinkUserID
+	}
+
+	_, err := r.pg.Pool.Exec(ctx,
+		"INSERT INTO oidc_states (state, provider, code_verifier, nonce, browser_hash, link_user_id, expires_at) VALUES($1, $2, $3, $4, $5, $6, $7)",
+		state.State, state.Provider, state.CodeVerifier, state.Nonce, state.BrowserHash, linkUserID, state.ExpiresAt)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", saveStateOp).Str("provider", state.Provider).Msg("Failed to save oidc state")
+		return fmt.Errorf("IdentityRepository - SaveState - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+// ConsumeState deletes the state and returns it, so every state can be redeemed only once.
+// Expired states left behind by abandoned logins are cleaned up on the way.
+func (r *identityRepository) ConsumeState(ctx context.Context, state string) (*entity.OIDCState, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	DELETE FROM oidc_states WHERE state = $1
+	RETURNING state, provider, code_verifier, nonce, browser_hash, link_user_id, expires_at
+	`, state)
+
+	var s entity.OIDCState
+	var linkUserID *int64
+	if err := row.Scan(&s.State, &s.Provider, &s.CodeVerifier, &s.Nonce, &s.BrowserHash, &linkUserID, &s.ExpiresAt); err != nil {
+		return nil, fmt.Errorf("IdentityRepository - ConsumeState - row.Scan(): %w", err)
+	}
+	if linkUserID != nil {
+		s.LinkUserID = *linkUserID
+	}
+
+	if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM oidc_states WHERE expires_at < now()"); err != nil {
+		r.log.Warn().Err(err).Str("op", consumeStateOp).Msg("Failed to delete expired oidc states")
+	}
+
+	return &s, nil
+}
+
+func (r *identityRepository) GetUserID(ctx context.Context, provider, subject string) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT user_id FROM user_identities WHERE provider = $1 AND subject = $2", provider, subject)
+
+	var userID int64
+	if err := row.Scan(&userID); err != nil {
+		return 0, fmt.Errorf("IdentityRepository - GetUserID - row.Scan(): %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *identityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	_, err := r.pg.Pool.Exec(ctx,
+		"INSERT INTO user_identities (user_id, provider, subject, email) VALUES($1, $2, $3, $4)",
+		identity.UserID, identity.Provider, identity.Subject, identity.Email)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", createIdentityOp).Int64("user_id", identity.UserID).Str("provider", identity.Provider).Msg("Failed to create identity")
+		return fmt.Errorf("IdentityRepository - Create - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+// CreateWithUser registers a new user without a local password together with its first identity.
+// It returns ErrUsernameTaken when the username is in use so the caller can try another one.
+func (r *identityRepository) CreateWithUser(ctx context.Context, username string, identity *entity.UserIdentity) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	WITH u AS (
+		INSERT INTO users (username, password_hash) VALUES($1, '') RETURNING id
+	)
+	INSERT INTO user_identities (user_id, provider, subject, email)
+	SELECT id, $2, $3, $4 FROM u
+	RETURNING user_id
+	`, username, identity.Provider, identity.Subject, identity.Email)
+
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "users_username_key" {
+			return 0, ErrUsernameTaken
+		}
+		r.log.Error().Err(err).Str("op", createWithUserOp).Str("username", username).Str("provider", identity.Provider).Msg("Failed to create user with identity")
+		return 0, fmt.Errorf("IdentityRepository - CreateWithUser - row.Scan(): %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *identityRepository) GetByUserID(ctx context.Context, userID int64) ([]entity.UserIdentity, error) {
+	rows, err := r.pg.Pool.Query(ctx,
+		"SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE user_id = $1 ORDER BY created_at",
+		userID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", getIdentitiesOp).Int64("user_id", userID).Msg("Failed to query identities")
+		return nil, fmt.Errorf("IdentityRepository - GetByUserID - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var identities []entity.UserIdentity
+	for rows.Next() {
+		var i entity.UserIdentity
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt); err != nil {
+			r.log.Error().Err(err).Str("op", getIdentitiesOp).Int64("user_id", userID).Msg("Failed to scan identity")
+			return nil, fmt.Errorf("IdentityRepository - GetByUserID - rows.Scan(): %w", err)
+		}
+		identities = append(identities, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("IdentityRepository - GetByUserID - rows.Err(): %w", err)
+	}
+
+	return identities, nil
+}
+
+func (r *identityRepository) Delete(ctx context.Context, userID int64, provider string) error {
+	if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM user_identities WHERE user_id = $1 AND provider = $2", userID, provider); err != nil {
+		r.log.Error().Err(err).Str("op", deleteIdentityOp).Int64("user_id", userID).Str("provider", provider).Msg("Failed to delete identity")
+		return fmt.Errorf("IdentityRepository - Delete - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityRepository_ConsumeState(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewIdentityRepository(pg, &logger)
+
+	expiresAt := time.Now().Add(time.Minute)
+
+	t.Run("Success", func(t *testing.T) {
+		linkUserID := int64(3)
+		rows := pgxmock.NewRows([]string{"state", "provider", "code_verifier", "nonce", "browser_hash", "link_user_id", "expires_at"}).
+			AddRow("state", "google", "verifier", "nonce", "hash", &linkUserID, expiresAt)
+		mockPool.ExpectQuery("DELETE FROM oidc_states WHERE state").WithArgs("state").WillReturnRows(rows)
+		mockPool.ExpectExec("DELETE FROM oidc_states WHERE expires_at").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		st, err := repo.ConsumeState(ctx, "state")
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.OIDCState{
+			State: "state", Provider: "google", CodeVerifier: "verifier", Nonce: "nonce", BrowserHash: "hash", LinkUserID: 3, ExpiresAt: expiresAt,
+		}, st)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockPool.ExpectQuery("DELETE FROM oidc_states WHERE state").WithArgs("missing").WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.ConsumeState(ctx, "missing")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestIdentityRepository_CreateWithUser(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewIdentityRepository(pg, &logger)
+
+	identity := &entity.UserIdentity{Provider: "google", Subject: "sub", Email: "user@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"user_id"}).AddRow(int64(5))
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs("user", identity.Provider, identity.Subject, identity.Email).WillReturnRows(rows)
+
+		id, err := repo.CreateWithUser(ctx, "user", identity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), id)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Username Taken", func(t *testing.T) {
+		dbErr := &pgconn.PgError{Code: "23505", ConstraintName: "users_username_key"}
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs("user", identity.Provider, identity.Subject, identity.Email).WillReturnError(dbErr)
+
+		_, err := repo.CreateWithUser(ctx, "user", identity)
+		assert.ErrorIs(t, err, ErrUsernameTaken)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB Error", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockPool.ExpectQuery("INSERT INTO users").WithArgs("user", identity.Provider, identity.Subject, identity.Email).WillReturnError(dbErr)
+
+		_, err := repo.CreateWithUser(ctx, "user", identity)
+		assert.Contains(t, err.Error(), "IdentityRepository - CreateWithUser - row.Scan()")
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+type MFARepository interface {
+	SaveSecret(ctx context.Context, userID int64, secret string) error
+	GetSecret(ctx context.Context, userID int64) (secret string, enabled bool, err error)
+	Enable(ctx context.Context, userID int64, recoveryCodeHashes []string) error
+	Disable(ctx context.Context, userID int64) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	// UseTOTPStep records step as the last one a TOTP code was accepted for and reports false, recording
+	// nothing, when that step or a later one was already used.
+	UseTOTPStep(ctx context.Context, userID int64, step int64) (bool, error)
+	// SaveChallenge records an mfa challenge, so the attempts made with its token can be counted.
+	SaveChallenge(ctx context.Context, id string, userID int64, expiresAt time.Time) error
+	// UseChallengeAttempt counts one attempt at the challenge of the user. It reports false, counting
+	// nothing, once maxAttempts were made or the challenge expired or was deleted.
+	UseChallengeAttempt(ctx context.Context, id string, userID int64, maxAttempts int) (bool, error)
+	// DeleteChallenge ends the challenge and reports whether it was still there.
+	DeleteChallenge(ctx context.Context, id string) (bool, error)
+}
+
+const (
+	saveSecretOp      = "MFARepository.SaveSecret"
+	getSecretOp       = "MFARepository.GetSecret"
+	enableOp          = "MFARepository.Enable"
+	disableOp         = "MFARepository.Disable"
+	useRecoveryCodeOp = "MFARepository.UseRecoveryCode"
+	useTOTPStepOp     = "MFARepository.UseTOTPStep"
+	saveChallengeOp   = "MFARepository.SaveChallenge"
+	useAttemptOp      = "MFARepository.UseChallengeAttempt"
+	deleteChallengeOp = "MFARepository.DeleteChallenge"
+)
+
+type mfaRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewMFARepository(pg *postgres.Postgres, log *zerolog.Logger) MFARepository {
+	return &mfaRepository{pg, log}
+}
+
+// SaveSecret stores a pending secret. An already confirmed secret is left untouched.
+func (r *mfaRepository) SaveSecret(ctx context.Context, userID int64, secret string) error {
+	_, err := r.pg.Pool.Exec(ctx, `
+	INSERT INTO user_totp (user_id, secret) VALUES($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = now()
+	WHERE user_totp.enabled = false
+	`, userID, secret)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", saveSecretOp).Int64("user_id", userID).Msg("Failed to save totp secret")
+		return fmt.Errorf("MFARepository - SaveSecret - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepository) GetSecret(ctx context.Context, userID int64) (string, bool, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT secret, enabled FROM user_totp WHERE user_id = $1", userID)
+
+	var secret string
+	var enabled bool
+	if err := row.Scan(&secret, &enabled); err != nil {
+		return "", false, fmt.Errorf("MFARepository - GetSecret - row.Scan(): %w", err)
+	}
+
+	return secret, enabled, nil
+}
+
+// Enable confirms the pending secret and replaces the user's recovery codes in one statement.
+func (r *mfaRepository) Enable(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+	_, err := r.pg.Pool.Exec(ctx, `
+	WITH enabled AS (
+		UPDATE user_totp SET enabled = true, confirmed_at = now() WHERE user_id = $1 RETURNING user_id
+	), deleted AS (
+		DELETE FROM recovery_codes WHERE user_id = $1
+	)
+	INSERT INTO recovery_codes (user_id, code_hash)
+	SELECT enabled.user_id, code FROM enabled, unnest($2::text[]) AS code
+	`, userID, recoveryCodeHashes)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", enableOp).Int64("user_id", userID).Msg("Failed to enable totp")
+		return fmt.Errorf("MFARepository - Enable - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepository) Disable(ctx context.Context, userID int64) error {
+	if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM user_totp WHERE user_id = $1", userID); err != nil {
+		r.log.Error().Err(err).Str("op", disableOp).Int64("user_id", userID).Msg("Failed to disable totp")
+		return fmt.Errorf("MFARepository - Disable - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode marks a matching unused code as used and reports whether one was found.
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	tag, err := r.pg.Pool.Exec(ctx,
+		"UPDATE recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+		userID, codeHash)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", useRecoveryCodeOp).Int64("user_id", userID).Msg("Failed to use recovery code")
+		return false, fmt.Errorf("MFARepository - UseRecoveryCode - pg.Pool.Exec(): %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepository) UseTOTPStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	tag, err := r.pg.Pool.Exec(ctx,
+		"UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2",
+		userID, step)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", useTOTPStepOp).Int64("user_id", userID).Msg("Failed to record totp step")
+		return false, fmt.Errorf("MFARepository - UseTOTPStep - pg.Pool.Exec(): %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SaveChallenge also deletes the challenges that expired, since abandoned logins leave them behind.
+func (r *mfaRepository) SaveChallenge(ctx context.Context, id string, userID int64, expiresAt time.Time) error {
+	_, err := r.pg.Pool.Exec(ctx, "INSERT INTO mfa_challenges (id, user_id, expires_at) VALUES($1, $2, $3)", id, userID, expiresAt)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", saveChallengeOp).Int64("user_id", userID).Msg("Failed to save mfa challenge")
+		return fmt.Errorf("MFARepository - SaveChallenge - pg.Pool.Exec(): %w", err)
+	}
+
+	if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM mfa_challenges WHERE expires_at < now()"); err != nil {
+		r.log.Warn().Err(err).Str("op", saveChallengeOp).Msg("Failed to delete expired mfa challenges")
+	}
+	return nil
+}
+
+func (r *mfaRepository) UseChallengeAttempt(ctx context.Context, id string, userID int64, maxAttempts int) (bool, error) {
+	tag, err := r.pg.Pool.Exec(ctx, `
+	UPDATE mfa_challenges SET attempts = attempts + 1
+	WHERE id = $1 AND user_id = $2 AND attempts < $3 AND expires_at > now()
+	`, id, userID, maxAttempts)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", useAttemptOp).Int64("user_id", userID).Msg("Failed to count mfa attempt")
+		return false, fmt.Errorf("MFARepository - UseChallengeAttempt - pg.Pool.Exec(): %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepository) DeleteChallenge(ctx context.Context, id string) (bool, error) {
+	tag, err := r.pg.Pool.Exec(ctx, "DELETE FROM mfa_challenges WHERE id = $1", id)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", deleteChallengeOp).Msg("Failed to delete mfa challenge")
+		return false, fmt.Errorf("MFARepository - DeleteChallenge - pg.Pool.Exec(): %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,236 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFARepository_GetSecret(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewMFARepository(pg, &logger)
+
+	userID := int64(1)
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"secret", "enabled"}).AddRow("JBSWY3DPEHPK3PXP", true)
+		mockPool.ExpectQuery("SELECT secret, enabled FROM user_totp WHERE user_id").WithArgs(userID).WillReturnRows(rows)
+
+		secret, enabled, err := repo.GetSecret(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+		assert.True(t, enabled)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockPool.ExpectQuery("SELECT secret, enabled FROM user_totp WHERE user_id").WithArgs(userID).WillReturnError(pgx.ErrNoRows)
+
+		_, _, err := repo.GetSecret(ctx, userID)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestMFARepository_Enable(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewMFARepository(pg, &logger)
+
+	userID := int64(1)
+	hashes := []string{"hash1", "hash2"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE user_totp SET enabled = true").WithArgs(userID, hashes).WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+		err := repo.Enable(ctx, userID, hashes)
+		assert.NoError(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB Error", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockPool.ExpectExec("UPDATE user_totp SET enabled = true").WithArgs(userID, hashes).WillReturnError(dbErr)
+
+		err := repo.Enable(ctx, userID, hashes)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "MFARepository - Enable - pg.Pool.Exec()")
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestMFARepository_UseRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewMFARepository(pg, &logger)
+
+	userID := int64(1)
+	codeHash := "hash"
+
+	t.Run("Used", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE recovery_codes SET used_at").WithArgs(userID, codeHash).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		ok, err := repo.UseRecoveryCode(ctx, userID, codeHash)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Code", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE recovery_codes SET used_at").WithArgs(userID, codeHash).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		ok, err := repo.UseRecoveryCode(ctx, userID, codeHash)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestMFARepository_UseTOTPStep(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewMFARepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("New step", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE user_totp SET last_used_step").WithArgs(int64(1), int64(100)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		fresh, err := repo.UseTOTPStep(ctx, 1, 100)
+		assert.NoError(t, err)
+		assert.True(t, fresh)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Used step", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE user_totp SET last_used_step").WithArgs(int64(1), int64(100)).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		fresh, err := repo.UseTOTPStep(ctx, 1, 100)
+		assert.NoError(t, err)
+		assert.False(t, fresh)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockPool.ExpectExec("UPDATE user_totp").WithArgs(int64(1), int64(100)).WillReturnError(dbErr)
+
+		_, err := repo.UseTOTPStep(ctx, 1, 100)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestMFARepository_SaveChallenge(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewMFARepository(postgres.NewWithPool(mockPool), &logger)
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	mockPool.ExpectExec("INSERT INTO mfa_challenges").WithArgs("challenge", int64(1), expiresAt).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockPool.ExpectExec("DELETE FROM mfa_challenges WHERE expires_at").WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	assert.NoError(t, repo.SaveChallenge(ctx, "challenge", 1, expiresAt))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestMFARepository_UseChallengeAttempt(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewMFARepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Counted", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE mfa_challenges SET attempts = attempts \\+ 1").WithArgs("challenge", int64(1), 5).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		allowed, err := repo.UseChallengeAttempt(ctx, "challenge", 1, 5)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Used up", func(t *testing.T) {
+		mockPool.ExpectExec("UPDATE mfa_challenges").WithArgs("challenge", int64(1), 5).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		allowed, err := repo.UseChallengeAttempt(ctx, "challenge", 1, 5)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("db error")
+		mockPool.ExpectExec("UPDATE mfa_challenges").WithArgs("challenge", int64(1), 5).WillReturnError(dbErr)
+
+		_, err := repo.UseChallengeAttempt(ctx, "challenge", 1, 5)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestMFARepository_DeleteChallenge(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewMFARepository(postgres.NewWithPool(mockPool), &logger)
+
+	mockPool.ExpectExec("DELETE FROM mfa_challenges WHERE id").WithArgs("challenge").WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mockPool.ExpectExec("DELETE FROM mfa_challenges WHERE id").WithArgs("challenge").WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	deleted, err := repo.DeleteChallenge(ctx, "challenge")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = repo.DeleteChallenge(ctx, "challenge")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+type PersonalTokenRepository interface {
+	Create(ctx context.Context, token *entity.PersonalToken, hash string) (*entity.PersonalToken, error)
+	List(ctx context.Context, userID int64) ([]entity.PersonalToken, error)
+	Delete(ctx context.Context, userID, id int64) (bool, error)
+	Authenticate(ctx context.Context, hash string) (*entity.TokenIdentity, error)
+}
+
+const (
+	createTokenOp       = "PersonalTokenRepository.Create"
+	listTokensOp        = "PersonalTokenRepository.List"
+	deletePersonalOp    = "PersonalTokenRepository.Delete"
+	authenticateTokenOp = "PersonalTokenRepository.Authenticate"
+)
+
+type personalTokenRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewPersonalTokenRepository(pg *postgres.Postgres, log *zerolog.Logger) PersonalTokenRepository {
+	return &personalTokenRepository{pg, log}
+}
+
+func (r *personalTokenRepository) Create(ctx context.Context, token *entity.PersonalToken, hash string) (*entity.PersonalToken, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	INSERT INTO personal_access_tokens (user_id, name, prefix, token_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at`,
+		token.UserID, token.Name, token.Prefix, hash, token.Scopes, token.ExpiresAt)
+
+	var t entity.PersonalToken
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.Prefix, &t.Scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt); err != nil {
+		r.log.Error().Err(err).Str("op", createTokenOp).Int64("user_id", token.UserID).Msg("Failed to create personal token")
+		return nil, fmt.Errorf("PersonalTokenRepository - Create - row.Scan(): %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *personalTokenRepository) List(ctx context.Context, userID int64) ([]entity.PersonalToken, error) {
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at
+	FROM personal_access_tokens
+	WHERE user_id = $1
+	ORDER BY created_at DESC`, userID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", listTokensOp).Int64("user_id", userID).Msg("Failed to list personal tokens")
+		return nil, fmt.Errorf("PersonalTokenRepository - List - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []entity.PersonalToken{}
+	for rows.Next() {
+		var t entity.PersonalToken
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Prefix, &t.Scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt); err != nil {
+			r.log.Error().Err(err).Str("op", listTokensOp).Int64("user_id", userID).Msg("Failed to scan personal token")
+			return nil, fmt.Errorf("PersonalTokenRepository - List - rows.Scan(): %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PersonalTokenRepository - List - rows.Err(): %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Delete removes the token only if it belongs to userID and reports whether it did.
+func (r *personalTokenRepository) Delete(ctx context.Context, userID, id int64) (bool, error) {
+	tag, err := r.pg.Pool.Exec(ctx, "DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", deletePersonalOp).Int64("user_id", userID).Int64("id", id).Msg("Failed to delete personal token")
+		return false, fmt.Errorf("PersonalTokenRepository - Delete - pg.Pool.Exec(): %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Authenticate looks up an unexpired token by hash and records the use. It returns pgx.ErrNoRows for an unknown or expired token.
+func (r *personalTokenRepository) Authenticate(ctx context.Context, hash string) (*entity.TokenIdentity, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	UPDATE personal_access_tokens t SET last_used_at = now()
+	FROM users u
+	WHERE t.token_hash = $1
+		AND u.id = t.user_id
+		AND (t.expires_at IS NULL OR t.expires_at > now())
+	RETURNING t.id, t.user_id, u.role, t.scopes`, hash)
+
+	var identity entity.TokenIdentity
+	if err := row.Scan(&identity.TokenID, &identity.UserID, &identity.Role, &identity.Scopes); err != nil {
+		return nil, fmt.Errorf("PersonalTokenRepository - Authenticate - row.Scan(): %w", err)
+	}
+
+	return &identity, nil
+}
@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalTokenRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewPersonalTokenRepository(postgres.NewWithPool(mockPool), &logger)
+
+	now := time.Now()
+	token := &entity.PersonalToken{UserID: 7, Name: "bot", Prefix: "fgo_abcdefgh", Scopes: []string{entity.ScopePostsWrite}}
+	rows := pgxmock.NewRows([]string{"id", "user_id", "name", "prefix", "scopes", "expires_at", "last_used_at", "created_at"}).
+		AddRow(int64(1), int64(7), "bot", "fgo_abcdefgh", []string{entity.ScopePostsWrite}, (*time.Time)(nil), (*time.Time)(nil), now)
+	mockPool.ExpectQuery("INSERT INTO personal_access_tokens").
+		WithArgs(int64(7), "bot", "fgo_abcdefgh", "hash", []string{entity.ScopePostsWrite}, (*time.Time)(nil)).
+		WillReturnRows(rows)
+
+	created, err := repo.Create(ctx, token, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+	assert.Equal(t, []string{entity.ScopePostsWrite}, created.Scopes)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestPersonalTokenRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewPersonalTokenRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Deleted", func(t *testing.T) {
+		mockPool.ExpectExec("DELETE FROM personal_access_tokens").WithArgs(int64(3), int64(7)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+		deleted, err := repo.Delete(ctx, 7, 3)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Other user's token", func(t *testing.T) {
+		mockPool.ExpectExec("DELETE FROM personal_access_tokens").WithArgs(int64(3), int64(8)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		deleted, err := repo.Delete(ctx, 8, 3)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestPersonalTokenRepository_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewPersonalTokenRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Valid", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"id", "user_id", "role", "scopes"}).
+			AddRow(int64(3), int64(7), entity.RoleUser, []string{entity.ScopeChatWrite})
+		mockPool.ExpectQuery("UPDATE personal_access_tokens").WithArgs("hash").WillReturnRows(rows)
+
+		identity, err := repo.Authenticate(ctx, "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.TokenIdentity{TokenID: 3, UserID: 7, Role: entity.RoleUser, Scopes: []string{entity.ScopeChatWrite}}, identity)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown or expired", func(t *testing.T) {
+		mockPool.ExpectQuery("UPDATE personal_access_tokens").WithArgs("hash").WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.Authenticate(ctx, "hash")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+type ProfileRepository interface {
+	GetByID(ctx context.Context, id int64) (*entity.UserProfile, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]entity.UserProfile, error)
+	Update(ctx context.Context, id int64, update entity.ProfileUpdate) (*entity.UserProfile, error)
+}
+
+const (
+	getProfilesOp   = "ProfileRepository.GetByIDs"
+	updateProfileOp = "ProfileRepository.Update"
+)
+
+const profileColumns = "id, username, display_name, bio, avatar_url, role, signature, created_at"
+
+type profileRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewProfileRepository(pg *postgres.Postgres, log *zerolog.Logger) ProfileRepository {
+	return &profileRepository{pg, log}
+}
+
+// GetByID returns pgx.ErrNoRows for an unknown user.
+func (r *profileRepository) GetByID(ctx context.Context, id int64) (*entity.UserProfile, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT "+profileColumns+" FROM users WHERE id = $1", id)
+
+	var p entity.UserProfile
+	if err := row.Scan(&p.UserID, &p.Username, &p.DisplayName, &p.Bio, &p.AvatarURL, &p.Role, &p.Signature, &p.JoinedAt); err != nil {
+		return nil, fmt.Errorf("ProfileRepository - GetByID - row.Scan(): %w", err)
+	}
+
+	return &p, nil
+}
+
+// GetByIDs returns the profiles of the users that exist; unknown IDs are left out of the map.
+func (r *profileRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]entity.UserProfile, error) {
+	profiles := make(map[int64]entity.UserProfile, len(ids))
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, "SELECT "+profileColumns+" FROM users WHERE id = ANY($1)", ids)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", getProfilesOp).Msg("Failed to get profiles")
+		return nil, fmt.Errorf("ProfileRepository - GetByIDs - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p entity.UserProfile
+		if err := rows.Scan(&p.UserID, &p.Username, &p.DisplayName, &p.Bio, &p.AvatarURL, &p.Role, &p.Signature, &p.JoinedAt); err != nil {
+			r.log.Error().Err(err).Str("op", getProfilesOp).Msg("Failed to scan profile")
+			return nil, fmt.Errorf("ProfileRepository - GetByIDs - rows.Scan(): %w", err)
+		}
+		profiles[p.UserID] = p
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ProfileRepository - GetByIDs - rows.Err(): %w", err)
+	}
+
+	return profiles, nil
+}
+
+// Update changes the non-nil fields of update and returns the resulting profile, or pgx.ErrNoRows for an unknown user.
+func (r *profileRepository) Update(ctx context.Context, id int64, update entity.ProfileUpdate) (*entity.UserProfile, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	UPDATE users SET
+		display_name = COALESCE($2, display_name),
+		bio = COALESCE($3, bio),
+		avatar_url = COALESCE($4, avatar_url),
+		signature = COALESCE($5, signature)
+	WHERE id = $1
+	RETURNING `+profileColumns,
+		id, update.DisplayName, update.Bio, update.AvatarURL, update.Signature)
+
+	var p entity.UserProfile
+	if err := row.Scan(&p.UserID, &p.Username, &p.DisplayName, &p.Bio, &p.AvatarURL, &p.Role, &p.Signature, &p.JoinedAt); err != nil {
+		r.log.Error().Err(err).Str("op", updateProfileOp).Int64("user_id", id).Msg("Failed to update profile")
+		return nil, fmt.Errorf("ProfileRepository - Update - row.Scan(): %w", err)
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var profileRowColumns = []string{"id", "username", "display_name", "bio", "avatar_url", "role", "signature", "created_at"}
+
+func TestProfileRepository_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewProfileRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows(profileRowColumns).
+			AddRow(int64(1), "alice", "Alice", "", "", entity.RoleAdmin, "", now).
+			AddRow(int64(2), "bob", "", "hi", "https://example.com/b.png", entity.RoleUser, "-- bob", now)
+		mockPool.ExpectQuery("FROM users WHERE id = ANY").WithArgs([]int64{1, 2, 3}).WillReturnRows(rows)
+
+		profiles, err := repo.GetByIDs(ctx, []int64{1, 2, 3})
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 2)
+		assert.Equal(t, "Alice", profiles[1].DisplayName)
+		assert.Equal(t, "-- bob", profiles[2].Signature)
+		assert.Equal(t, now, profiles[2].JoinedAt)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Empty ids", func(t *testing.T) {
+		profiles, err := repo.GetByIDs(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestProfileRepository_Update(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewProfileRepository(postgres.NewWithPool(mockPool), &logger)
+
+	bio := "hello"
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows(profileRowColumns).
+			AddRow(int64(7), "bob", "", bio, "", entity.RoleUser, "", time.Now())
+		mockPool.ExpectQuery("UPDATE users SET").
+			WithArgs(int64(7), (*string)(nil), &bio, (*string)(nil), (*string)(nil)).
+			WillReturnRows(rows)
+
+		profile, err := repo.Update(ctx, 7, entity.ProfileUpdate{Bio: &bio})
+		assert.NoError(t, err)
+		assert.Equal(t, bio, profile.Bio)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockPool.ExpectQuery("UPDATE users SET").
+			WithArgs(int64(7), (*string)(nil), &bio, (*string)(nil), (*string)(nil)).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.Update(ctx, 7, entity.ProfileUpdate{Bio: &bio})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
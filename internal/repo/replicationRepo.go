@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+// UserReplicationRepository reads account changes for services that mirror users, in transaction order.
+type UserReplicationRepository interface {
+	Horizon(ctx context.Context) (int64, error)
+	ListChanges(ctx context.Context, since int64, after entity.UserRecord, limit int) ([]entity.UserRecord, error)
+}
+
+const listUserChangesOp = "UserReplicationRepository.ListChanges"
+
+type userReplicationRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewUserReplicationRepository(pg *postgres.Postgres, log *zerolog.Logger) UserReplicationRepository {
+	return &userReplicationRepository{pg, log}
+}
+
+// Horizon returns the oldest transaction still running. Every change made by older transactions is visible,
+// so a reader that has seen them all can resume from here without missing a late commit.
+func (r *userReplicationRepository) Horizon(ctx context.Context) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint")
+
+	var horizon int64
+	if err := row.Scan(&horizon); err != nil {
+		return 0, fmt.Errorf("UserReplicationRepository - Horizon - row.Scan(): %w", err)
+	}
+
+	return horizon, nil
+}
+
+// ListChanges returns up to limit accounts and deletions made by transaction since or later, ordered by
+// version and ID and starting after the given record, so callers can page with the last record they got.
+func (r *userReplicationRepository) ListChanges(ctx context.Context, since int64, after entity.UserRecord, limit int) ([]entity.UserRecord, error) {
+	const query = `
+		SELECT id, username, role, deleted, version FROM (
+			(SELECT id::bigint AS id, username::text AS username, role, false AS deleted, change_xid::text::bigint AS version
+			FROM users
+			WHERE change_xid >= $1::xid8 AND (change_xid, id) > ($2::xid8, $3)
+			ORDER BY change_xid, id
+			LIMIT $4)
+			UNION ALL
+			(SELECT user_id, '', '', true, change_xid::text::bigint
+			FROM user_tombstones
+			WHERE change_xid >= $1::xid8 AND (change_xid, user_id) > ($2::xid8, $3)
+			ORDER BY change_xid, user_id
+			LIMIT $4)
+		) changes
+		ORDER BY version, id
+		LIMIT $4`
+
+	rows, err := r.pg.Pool.Query(ctx, query, strconv.FormatInt(since, 10), strconv.FormatInt(after.Version, 10), after.ID, limit)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", listUserChangesOp).Msg("Failed to list user changes")
+		return nil, fmt.Errorf("UserReplicationRepository - ListChanges - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	var records []entity.UserRecord
+	for rows.Next() {
+		var rec entity.UserRecord
+		if err := rows.Scan(&rec.ID, &rec.Username, &rec.Role, &rec.Deleted, &rec.Version); err != nil {
+			r.log.Error().Err(err).Str("op", listUserChangesOp).Msg("Failed to scan user change")
+			return nil, fmt.Errorf("UserReplicationRepository - ListChanges - rows.Scan(): %w", err)
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("UserReplicationRepository - ListChanges - rows.Err(): %w", err)
+	}
+
+	return records, nil
+}
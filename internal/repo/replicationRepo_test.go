@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserReplicationRepository_Horizon(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewUserReplicationRepository(postgres.NewWithPool(mockPool), &logger)
+
+	mockPool.ExpectQuery("pg_snapshot_xmin").WillReturnRows(pgxmock.NewRows([]string{"xmin"}).AddRow(int64(900)))
+
+	horizon, err := repo.Horizon(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(900), horizon)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUserReplicationRepository_ListChanges(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewUserReplicationRepository(postgres.NewWithPool(mockPool), &logger)
+	columns := []string{"id", "username", "role", "deleted", "version"}
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows(columns).
+			AddRow(int64(1), "alice", entity.RoleUser, false, int64(901)).
+			AddRow(int64(2), "", "", true, int64(905))
+		mockPool.ExpectQuery("FROM user_tombstones").WithArgs("900", "900", int64(7), 500).WillReturnRows(rows)
+
+		records, err := repo.ListChanges(ctx, 900, entity.UserRecord{ID: 7, Version: 900}, 500)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.UserRecord{
+			{ID: 1, Username: "alice", Role: entity.RoleUser, Version: 901},
+			{ID: 2, Deleted: true, Version: 905},
+		}, records)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectQuery("FROM user_tombstones").WithArgs("0", "0", int64(0), 500).WillReturnError(dbErr)
+
+		_, err := repo.ListChanges(ctx, 0, entity.UserRecord{}, 500)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
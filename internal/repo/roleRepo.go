@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/rs/zerolog"
+)
+
+type RoleRepository interface {
+	GetAccess(ctx context.Context, userID int64, role string) (permissions []string, moderatedCategories []int64, err error)
+	GetModerators(ctx context.Context, categoryID int64) ([]entity.User, error)
+	AddModerator(ctx context.Context, categoryID int64, userID int64) error
+	RemoveModerator(ctx context.Context, categoryID int64, userID int64) error
+}
+
+const (
+	getAccessOp       = "RoleRepository.GetAccess"
+	getModeratorsOp   = "RoleRepository.GetModerators"
+	addModeratorOp    = "RoleRepository.AddModerator"
+	removeModeratorOp = "RoleRepository.RemoveModerator"
+)
+
+type roleRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewRoleRepository(pg *postgres.Postgres, log *zerolog.Logger) RoleRepository {
+	return &roleRepository{pg, log}
+}
+
+// GetAccess returns the permissions granted to role and the categories the user moderates.
+func (r *roleRepository) GetAccess(ctx context.Context, userID int64, role string) ([]string, []int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, `
+	SELECT
+		COALESCE((SELECT array_agg(permission ORDER BY permission) FROM role_permissions WHERE role = $1), '{}'),
+		COALESCE((SELECT array_agg(category_id ORDER BY category_id) FROM category_moderators WHERE user_id = $2), '{}')
+	`, role, userID)
+
+	var permissions []string
+	var categories []int64
+	if err := row.Scan(&permissions, &categories); err != nil {
+		r.log.Error().Err(err).Str("op", getAccessOp).Int64("user_id", userID).Str("role", role).Msg("Failed to get access")
+		return nil, nil, fmt.Errorf("RoleRepository - GetAccess - row.Scan(): %w", err)
+	}
+
+	return permissions, categories, nil
+}
+
+func (r *roleRepository) GetModerators(ctx context.Context, categoryID int64) ([]entity.User, error) {
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT u.id, u.username, u.role, u.created_at
+	FROM category_moderators cm
+	JOIN users u ON u.id = cm.user_id
+	WHERE cm.category_id = $1
+	ORDER BY cm.created_at`, categoryID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", getModeratorsOp).Int64("category_id", categoryID).Msg("Failed to query moderators")
+		return nil, fmt.Errorf("RoleRepository - GetModerators - pg.Pool.Query(): %w", err)
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		var u entity.User
+		if err := rows.Scan(&u.ID, &u.Username, &u.Role, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("RoleRepository - GetModerators - rows.Scan(): %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RoleRepository - GetModerators - rows.Err(): %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *roleRepository) AddModerator(ctx context.Context, categoryID int64, userID int64) error {
+	_, err := r.pg.Pool.Exec(ctx,
+		"INSERT INTO category_moderators (user_id, category_id) VALUES($1, $2) ON CONFLICT DO NOTHING",
+		userID, categoryID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", addModeratorOp).Int64("category_id", categoryID).Int64("user_id", userID).Msg("Failed to add moderator")
+		return fmt.Errorf("RoleRepository - AddModerator - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
+
+func (r *roleRepository) RemoveModerator(ctx context.Context, categoryID int64, userID int64) error {
+	_, err := r.pg.Pool.Exec(ctx,
+		"DELETE FROM category_moderators WHERE user_id = $1 AND category_id = $2",
+		userID, categoryID)
+	if err != nil {
+		r.log.Error().Err(err).Str("op", removeModeratorOp).Int64("category_id", categoryID).Int64("user_id", userID).Msg("Failed to remove moderator")
+		return fmt.Errorf("RoleRepository - RemoveModerator - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleRepository_GetAccess(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewRoleRepository(pg, &logger)
+
+	userID := int64(1)
+	role := "moderator"
+
+	t.Run("Success", func(t *testing.T) {
+		rows := pgxmock.NewRows([]string{"permissions", "categories"}).
+			AddRow([]string{"post.delete.any", "topic.lock"}, []int64{2, 5})
+		mockPool.ExpectQuery("FROM role_permissions WHERE role").WithArgs(role, userID).WillReturnRows(rows)
+
+		permissions, categories, err := repo.GetAccess(ctx, userID, role)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"post.delete.any", "topic.lock"}, permissions)
+		assert.Equal(t, []int64{2, 5}, categories)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectQuery("FROM role_permissions WHERE role").WithArgs(role, userID).WillReturnError(dbErr)
+
+		_, _, err := repo.GetAccess(ctx, userID, role)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "RoleRepository - GetAccess - row.Scan()")
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepository_GetModerators(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewRoleRepository(pg, &logger)
+
+	categoryID := int64(3)
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows([]string{"id", "username", "role", "created_at"}).
+			AddRow(int64(1), "alice", "user", now).
+			AddRow(int64(2), "bob", "moderator", now)
+		mockPool.ExpectQuery("FROM category_moderators cm").WithArgs(categoryID).WillReturnRows(rows)
+
+		users, err := repo.GetModerators(ctx, categoryID)
+		assert.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "alice", users[0].Username)
+		assert.Equal(t, int64(2), users[1].ID)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectQuery("FROM category_moderators cm").WithArgs(categoryID).WillReturnError(dbErr)
+
+		_, err := repo.GetModerators(ctx, categoryID)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepository_AddModerator(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewRoleRepository(pg, &logger)
+
+	t.Run("Success", func(t *testing.T) {
+		mockPool.ExpectExec("INSERT INTO category_moderators").WithArgs(int64(7), int64(3)).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err := repo.AddModerator(ctx, 3, 7)
+		assert.NoError(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectExec("INSERT INTO category_moderators").WithArgs(int64(7), int64(3)).WillReturnError(dbErr)
+
+		err := repo.AddModerator(ctx, 3, 7)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "RoleRepository - AddModerator - pg.Pool.Exec()")
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepository_RemoveModerator(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	pg := postgres.NewWithPool(mockPool)
+
+	repo := NewRoleRepository(pg, &logger)
+
+	mockPool.ExpectExec("DELETE FROM category_moderators").WithArgs(int64(7), int64(3)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	err = repo.RemoveModerator(ctx, 3, 7)
+	assert.NoError(t, err)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// userChangesChannel is the NOTIFY channel the users table trigger publishes to.
+const userChangesChannel = "user_changes"
+
+const (
+	listenBackoffMin = time.Second
+	listenBackoffMax = 30 * time.Second
+)
+
+const listenUserChangesOp = "UserChangeListener.Listen"
+
+// UserChangeListener receives account change notifications from the auth database. It keeps its own
+// connection, since LISTEN is bound to a session and the pool hands out connections per query.
+type UserChangeListener struct {
+	dsn string
+	log *zerolog.Logger
+}
+
+func NewUserChangeListener(dsn string, log *zerolog.Logger) *UserChangeListener {
+	return &UserChangeListener{dsn: dsn, log: log}
+}
+
+// Listen passes every change to handle until ctx is done, reconnecting with backoff when the connection drops.
+// Notifications sent while disconnected are lost, so a UserChangeReset is handed over after every (re)connect.
+func (l *UserChangeListener) Listen(ctx context.Context, handle func(entity.UserChange)) {
+	log := l.log.With().Str("op", listenUserChangesOp).Logger()
+	backoff := listenBackoffMin
+
+	for {
+		connected, err := l.listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = listenBackoffMin
+		}
+		log.Warn().Err(err).Dur("retry_in", backoff).Msg("User change listener disconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenBackoffMax)
+	}
+}
+
+func (l *UserChangeListener) listen(ctx context.Context, handle func(entity.UserChange)) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return false, fmt.Errorf("UserChangeListener - listen - pgx.Connect(): %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+userChangesChannel); err != nil {
+		return false, fmt.Errorf("UserChangeListener - listen - conn.Exec(): %w", err)
+	}
+	handle(entity.UserChange{Type: entity.UserChangeReset})
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("UserChangeListener - listen - conn.WaitForNotification(): %w", err)
+		}
+
+		var change entity.UserChange
+		if err := json.Unmarshal([]byte(n.Payload), &change); err != nil {
+			l.log.Error().Err(err).Str("op", listenUserChangesOp).Str("payload", n.Payload).Msg("Malformed user change notification")
+			continue
+		}
+		handle(change)
+	}
+}
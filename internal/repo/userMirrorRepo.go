@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// UserMirrorRepository keeps the forum's read-only copy of the auth service's users, which posts and
+// topics reference, and the checkpoint the copy is up to date with.
+type UserMirrorRepository interface {
+	Checkpoint(ctx context.Context) (int64, error)
+	Apply(ctx context.Context, records []entity.UserRecord) error
+	SaveCheckpoint(ctx context.Context, position int64) error
+}
+
+// usersCheckpoint names the users mirror in replication_checkpoints.
+const usersCheckpoint = "users"
+
+const (
+	applyUsersOp          = "UserMirrorRepository.Apply"
+	saveUsersCheckpointOp = "UserMirrorRepository.SaveCheckpoint"
+)
+
+type userMirrorRepository struct {
+	pg  *postgres.Postgres
+	log *zerolog.Logger
+}
+
+func NewUserMirrorRepository(pg *postgres.Postgres, log *zerolog.Logger) UserMirrorRepository {
+	return &userMirrorRepository{pg, log}
+}
+
+// Checkpoint returns where the last completed sync stopped, or 0 if the mirror was never synced.
+func (r *userMirrorRepository) Checkpoint(ctx context.Context) (int64, error) {
+	row := r.pg.Pool.QueryRow(ctx, "SELECT position FROM replication_checkpoints WHERE name = $1", usersCheckpoint)
+
+	var position int64
+	if err := row.Scan(&position); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("UserMirrorRepository - Checkpoint - row.Scan(): %w", err)
+	}
+
+	return position, nil
+}
+
+// Apply upserts the records and removes the users marked deleted. Records come in change order, so when a
+// user appears twice the later record wins. Applying the same records again changes nothing.
+func (r *userMirrorRepository) Apply(ctx context.Context, records []entity.UserRecord) error {
+	latest := make(map[int64]entity.UserRecord, len(records))
+	for _, rec := range records {
+		latest[rec.ID] = rec
+	}
+
+	var ids, deleted []int64
+	var usernames, roles []string
+	for _, id := range slices.Sorted(maps.Keys(latest)) {
+		rec := latest[id]
+		if rec.Deleted {
+			deleted = append(deleted, id)
+			continue
+		}
+		ids = append(ids, id)
+		usernames = append(usernames, rec.Username)
+		roles = append(roles, rec.Role)
+	}
+
+	if len(ids) > 0 {
+		const query = `
+			INSERT INTO users (id, username, role, synced_at)
+			SELECT id, username, role, now() FROM unnest($1::int[], $2::text[], $3::text[]) AS u(id, username, role)
+			ON CONFLICT (id) DO UPDATE SET username = EXCLUDED.username, role = EXCLUDED.role, synced_at = now()`
+		if _, err := r.pg.Pool.Exec(ctx, query, ids, usernames, roles); err != nil {
+			r.log.Error().Err(err).Str("op", applyUsersOp).Int("count", len(ids)).Msg("Failed to upsert users")
+			return fmt.Errorf("UserMirrorRepository - Apply - upsert pg.Pool.Exec(): %w", err)
+		}
+	}
+
+	if len(deleted) > 0 {
+		if _, err := r.pg.Pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1::int[])", deleted); err != nil {
+			r.log.Error().Err(err).Str("op", applyUsersOp).Int("count", len(deleted)).Msg("Failed to delete users")
+			return fmt.Errorf("UserMirrorRepository - Apply - delete pg.Pool.Exec(): %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveCheckpoint records that every change before position has been applied.
+func (r *userMirrorRepository) SaveCheckpoint(ctx context.Context, position int64) error {
+	const query = `
+		INSERT INTO replication_checkpoints (name, position, updated_at) VALUES ($1, $2, now())
+		ON CONFLICT (name) DO UPDATE SET position = EXCLUDED.position, updated_at = now()`
+	if _, err := r.pg.Pool.Exec(ctx, query, usersCheckpoint, position); err != nil {
+		r.log.Error().Err(err).Str("op", saveUsersCheckpointOp).Int64("position", position).Msg("Failed to save users checkpoint")
+		return fmt.Errorf("UserMirrorRepository - SaveCheckpoint - pg.Pool.Exec(): %w", err)
+	}
+	return nil
+}
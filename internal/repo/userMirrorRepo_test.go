@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserMirrorRepository_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewUserMirrorRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Stored", func(t *testing.T) {
+		mockPool.ExpectQuery("SELECT position FROM replication_checkpoints").WithArgs(usersCheckpoint).
+			WillReturnRows(pgxmock.NewRows([]string{"position"}).AddRow(int64(42)))
+
+		position, err := repo.Checkpoint(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), position)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Never synced", func(t *testing.T) {
+		mockPool.ExpectQuery("SELECT position FROM replication_checkpoints").WithArgs(usersCheckpoint).
+			WillReturnError(pgx.ErrNoRows)
+
+		position, err := repo.Checkpoint(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, position)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestUserMirrorRepository_Apply(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewUserMirrorRepository(postgres.NewWithPool(mockPool), &logger)
+
+	t.Run("Later record wins", func(t *testing.T) {
+		records := []entity.UserRecord{
+			{ID: 2, Username: "bob", Role: entity.RoleUser},
+			{ID: 1, Username: "alice", Role: entity.RoleUser},
+			{ID: 3, Username: "carol", Role: entity.RoleUser},
+			{ID: 1, Username: "alice2", Role: entity.RoleAdmin},
+			{ID: 3, Deleted: true},
+		}
+		mockPool.ExpectExec("INSERT INTO users").
+			WithArgs([]int64{1, 2}, []string{"alice2", "bob"}, []string{entity.RoleAdmin, entity.RoleUser}).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		mockPool.ExpectExec("DELETE FROM users").WithArgs([]int64{3}).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+		assert.NoError(t, repo.Apply(ctx, records))
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("Empty page", func(t *testing.T) {
+		assert.NoError(t, repo.Apply(ctx, nil))
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		dbErr := errors.New("some db error")
+		mockPool.ExpectExec("INSERT INTO users").WithArgs([]int64{1}, []string{"alice"}, []string{""}).WillReturnError(dbErr)
+
+		err := repo.Apply(ctx, []entity.UserRecord{{ID: 1, Username: "alice"}})
+		assert.ErrorIs(t, err, dbErr)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestUserMirrorRepository_SaveCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewUserMirrorRepository(postgres.NewWithPool(mockPool), &logger)
+
+	mockPool.ExpectExec("INSERT INTO replication_checkpoints").WithArgs(usersCheckpoint, int64(42)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	assert.NoError(t, repo.SaveCheckpoint(ctx, 42))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
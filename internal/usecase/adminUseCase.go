@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+type AdminUsecase interface {
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int64, error)
+	GetUser(ctx context.Context, actorID, userID int64) (*entity.UserDetails, error)
+	ChangeRole(ctx context.Context, actorID, userID int64, role string) error
+	ForceLogout(ctx context.Context, actorID, userID int64) error
+	Ban(ctx context.Context, actorID, userID int64, reason string, expiresAt *time.Time) (*entity.Ban, error)
+	Unban(ctx context.Context, actorID, userID int64) error
+	AuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type adminUsecase struct {
+	adminRepo repo.AdminRepository
+	log       *zerolog.Logger
+}
+
+var (
+	ErrUnknownRole    = entity.NewError(entity.KindInvalid, "unknown_role", "unknown role")
+	ErrSelfRoleChange = entity.NewError(entity.KindInvalid, "self_role_change", "cannot change your own role")
+	ErrSelfBan        = entity.NewError(entity.KindInvalid, "self_ban", "cannot ban yourself")
+	ErrBanExpired     = entity.NewError(entity.KindInvalid, "ban_expired", "ban expiry must be in the future")
+	ErrNotBanned      = entity.NewError(entity.KindNotFound, "not_banned", "user is not banned")
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+const (
+	listUsersOp   = "AdminUsecase.ListUsers"
+	getUserOp     = "AdminUsecase.GetUser"
+	changeRoleOp  = "AdminUsecase.ChangeRole"
+	forceLogoutOp = "AdminUsecase.ForceLogout"
+	banOp         = "AdminUsecase.Ban"
+	unbanOp       = "AdminUsecase.Unban"
+	auditLogOp    = "AdminUsecase.AuditLog"
+)
+
+func NewAdminUsecase(adminRepo repo.AdminRepository, log *zerolog.Logger) AdminUsecase {
+	return &adminUsecase{adminRepo: adminRepo, log: log}
+}
+
+func (u *adminUsecase) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int64, error) {
+	filter.Limit, filter.Offset = pageBounds(filter.Limit, filter.Offset)
+
+	users, total, err := u.adminRepo.ListUsers(ctx, filter)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", listUsersOp).Str("query", filter.Query).Msg("Failed to list users")
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, total, nil
+}
+
+// GetUser returns the admin view of a user. Looking at an account is itself recorded in the audit log.
+func (u *adminUsecase) GetUser(ctx context.Context, actorID, userID int64) (*entity.UserDetails, error) {
+	log := u.log.With().Str("op", getUserOp).Int64("actor_id", actorID).Int64("user_id", userID).Logger()
+
+	details, err := u.adminRepo.GetUserDetails(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("Failed to get user details")
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := u.adminRepo.RecordAudit(ctx, &entity.AuditEntry{ActorID: actorID, TargetID: userID, Action: entity.AuditUserView}); err != nil {
+		log.Error().Err(err).Msg("Failed to record audit entry")
+		return nil, fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	return details, nil
+}
+
+// ChangeRole changes the user's role and revokes their refresh tokens, so the next token refresh fails and the
+// user has to log in again to get an access token with the new role.
+func (u *adminUsecase) ChangeRole(ctx context.Context, actorID, userID int64, role string) error {
+	log := u.log.With().Str("op", changeRoleOp).Int64("actor_id", actorID).Int64("user_id", userID).Str("role", role).Logger()
+
+	if actorID == userID {
+		return ErrSelfRoleChange
+	}
+
+	previous, err := u.adminRepo.ChangeRole(ctx, actorID, userID, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ErrUserNotFound
+		case errors.Is(err, repo.ErrUnknownRole):
+			return ErrUnknownRole
+		}
+		log.Error().Err(err).Msg("Failed to change role")
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+
+	log.Info().Str("previous_role", previous).Msg("Role changed")
+	return nil
+}
+
+// ForceLogout revokes every refresh token of the user. Access tokens already issued stay valid until they expire.
+func (u *adminUsecase) ForceLogout(ctx context.Context, actorID, userID int64) error {
+	log := u.log.With().Str("op", forceLogoutOp).Int64("actor_id", actorID).Int64("user_id", userID).Logger()
+
+	revoked, err := u.adminRepo.ForceLogout(ctx, actorID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("Failed to force logout")
+		return fmt.Errorf("failed to force logout: %w", err)
+	}
+
+	log.Info().Int64("revoked_sessions", revoked).Msg("User logged out by admin")
+	return nil
+}
+
+// Ban bans the user until expiresAt, or permanently when expiresAt is nil. The user's refresh tokens are revoked,
+// so they are logged out once their access token expires; the forum checks the ban on every write.
+func (u *adminUsecase) Ban(ctx context.Context, actorID, userID int64, reason string, expiresAt *time.Time) (*entity.Ban, error) {
+	log := u.log.With().Str("op", banOp).Int64("actor_id", actorID).Int64("user_id", userID).Logger()
+
+	if actorID == userID {
+		return nil, ErrSelfBan
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrBanExpired
+	}
+
+	ban, err := u.adminRepo.Ban(ctx, actorID, &entity.Ban{UserID: userID, Reason: reason, ExpiresAt: expiresAt})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("Failed to ban user")
+		return nil, fmt.Errorf("failed to ban user: %w", err)
+	}
+
+	log.Info().Str("reason", reason).Msg("User banned")
+	return ban, nil
+}
+
+func (u *adminUsecase) Unban(ctx context.Context, actorID, userID int64) error {
+	log := u.log.With().Str("op", unbanOp).Int64("actor_id", actorID).Int64("user_id", userID).Logger()
+
+	lifted, err := u.adminRepo.Unban(ctx, actorID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to unban user")
+		return fmt.Errorf("failed to unban user: %w", err)
+	}
+	if lifted == 0 {
+		return ErrNotBanned
+	}
+
+	log.Info().Int64("lifted_bans", lifted).Msg("User unbanned")
+	return nil
+}
+
+func (u *adminUsecase) AuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	filter.Limit, filter.Offset = pageBounds(filter.Limit, filter.Offset)
+
+	entries, err := u.adminRepo.GetAuditLog(ctx, filter)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", auditLogOp).Msg("Failed to get audit log")
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	return entries, nil
+}
+
+func pageBounds(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
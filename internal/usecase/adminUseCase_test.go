@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminUsecaseSuite struct {
+	suite.Suite
+	usecase   AdminUsecase
+	adminRepo *mocks.AdminRepository
+}
+
+func (s *AdminUsecaseSuite) SetupTest() {
+	s.adminRepo = mocks.NewAdminRepository(s.T())
+	logger := zerolog.Nop()
+	s.usecase = NewAdminUsecase(s.adminRepo, &logger)
+}
+
+func TestAdminUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AdminUsecaseSuite))
+}
+
+func (s *AdminUsecaseSuite) TestListUsers_ClampsPageSize() {
+	ctx := context.Background()
+	users := []entity.User{{ID: 1, Username: "alice"}}
+
+	s.adminRepo.On("ListUsers", ctx, entity.UserFilter{Query: "al", Limit: maxPageSize, Offset: 0}).Return(users, int64(1), nil).Once()
+
+	got, total, err := s.usecase.ListUsers(ctx, entity.UserFilter{Query: "al", Limit: 1000, Offset: -5})
+
+	s.NoError(err)
+	s.Equal(users, got)
+	s.Equal(int64(1), total)
+}
+
+func (s *AdminUsecaseSuite) TestGetUser_RecordsView() {
+	ctx := context.Background()
+	details := &entity.UserDetails{User: entity.User{ID: 7, Username: "bob"}, SessionCount: 2}
+
+	s.adminRepo.On("GetUserDetails", ctx, int64(7)).Return(details, nil).Once()
+	s.adminRepo.On("RecordAudit", ctx, &entity.AuditEntry{ActorID: 1, TargetID: 7, Action: entity.AuditUserView}).Return(nil).Once()
+
+	got, err := s.usecase.GetUser(ctx, 1, 7)
+
+	s.NoError(err)
+	s.Equal(details, got)
+}
+
+func (s *AdminUsecaseSuite) TestGetUser_NotFound() {
+	ctx := context.Background()
+
+	s.adminRepo.On("GetUserDetails", ctx, int64(7)).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.GetUser(ctx, 1, 7)
+
+	s.ErrorIs(err, ErrUserNotFound)
+	s.adminRepo.AssertNotCalled(s.T(), "RecordAudit", mock.Anything, mock.Anything)
+}
+
+func (s *AdminUsecaseSuite) TestChangeRole_Success() {
+	ctx := context.Background()
+
+	s.adminRepo.On("ChangeRole", ctx, int64(1), int64(7), entity.RoleModerator).Return(entity.RoleUser, nil).Once()
+
+	err := s.usecase.ChangeRole(ctx, 1, 7, entity.RoleModerator)
+
+	s.NoError(err)
+}
+
+func (s *AdminUsecaseSuite) TestChangeRole_Self() {
+	err := s.usecase.ChangeRole(context.Background(), 1, 1, entity.RoleUser)
+
+	s.ErrorIs(err, ErrSelfRoleChange)
+	s.adminRepo.AssertNotCalled(s.T(), "ChangeRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AdminUsecaseSuite) TestChangeRole_UnknownRole() {
+	ctx := context.Background()
+
+	s.adminRepo.On("ChangeRole", ctx, int64(1), int64(7), "wizard").Return("", repo.ErrUnknownRole).Once()
+
+	err := s.usecase.ChangeRole(ctx, 1, 7, "wizard")
+
+	s.ErrorIs(err, ErrUnknownRole)
+}
+
+func (s *AdminUsecaseSuite) TestForceLogout_NotFound() {
+	ctx := context.Background()
+
+	s.adminRepo.On("ForceLogout", ctx, int64(1), int64(7)).Return(int64(0), pgx.ErrNoRows).Once()
+
+	err := s.usecase.ForceLogout(ctx, 1, 7)
+
+	s.ErrorIs(err, ErrUserNotFound)
+}
+
+func (s *AdminUsecaseSuite) TestBan_Success() {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(24 * time.Hour)
+	ban := &entity.Ban{ID: 3, UserID: 7, BannedBy: 1, Reason: "spam", ExpiresAt: &expiresAt}
+
+	s.adminRepo.On("Ban", ctx, int64(1), &entity.Ban{UserID: 7, Reason: "spam", ExpiresAt: &expiresAt}).Return(ban, nil).Once()
+
+	got, err := s.usecase.Ban(ctx, 1, 7, "spam", &expiresAt)
+
+	s.NoError(err)
+	s.Equal(ban, got)
+}
+
+func (s *AdminUsecaseSuite) TestBan_Self() {
+	_, err := s.usecase.Ban(context.Background(), 1, 1, "spam", nil)
+
+	s.ErrorIs(err, ErrSelfBan)
+	s.adminRepo.AssertNotCalled(s.T(), "Ban", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AdminUsecaseSuite) TestBan_ExpiryInPast() {
+	expiresAt := time.Now().Add(-time.Minute)
+
+	_, err := s.usecase.Ban(context.Background(), 1, 7, "spam", &expiresAt)
+
+	s.ErrorIs(err, ErrBanExpired)
+	s.adminRepo.AssertNotCalled(s.T(), "Ban", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AdminUsecaseSuite) TestBan_NotFound() {
+	ctx := context.Background()
+
+	s.adminRepo.On("Ban", ctx, int64(1), &entity.Ban{UserID: 7, Reason: "spam"}).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.Ban(ctx, 1, 7, "spam", nil)
+
+	s.ErrorIs(err, ErrUserNotFound)
+}
+
+func (s *AdminUsecaseSuite) TestUnban_NotBanned() {
+	ctx := context.Background()
+
+	s.adminRepo.On("Unban", ctx, int64(1), int64(7)).Return(int64(0), nil).Once()
+
+	err := s.usecase.Unban(ctx, 1, 7)
+
+	s.ErrorIs(err, ErrNotBanned)
+}
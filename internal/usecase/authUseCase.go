@@ -2,89 +2,171 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Van-programan/Forum_GO/internal/controller/response"
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/repo"
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/password"
+	"github.com/Van-programan/Forum_GO/pkg/totp"
 	"github.com/jackc/pgx/v5"
 	"github.com/mitchellh/mapstructure"
 	"github.com/rs/zerolog"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthUsecase interface {
-	Register(ctx context.Context, username string, role string, password string) (*response.RegisterResponse, error)
+	Register(ctx context.Context, username string, password string) (*response.RegisterResponse, error)
 	Login(ctx context.Context, username, password, refreshToken string) (*response.LoginResponse, error)
 	Refresh(ctx context.Context, refreshToken string) (*response.RefreshResponse, error)
 	Logout(ctx context.Context, refreshToken string) error
 	IsSessionActive(ctx context.Context, refreshToken string) (*response.IsSessionActiveResponse, error)
+	LoginMFA(ctx context.Context, mfaToken, code string) (*response.LoginResponse, error)
+	EnrollTOTP(ctx context.Context, userID int64) (*response.TOTPEnrollResponse, error)
+	ConfirmTOTP(ctx context.Context, userID int64, code string) (*response.TOTPConfirmResponse, error)
+	DisableTOTP(ctx context.Context, userID int64, password string) error
 }
 
 type authUsecase struct {
 	userRepo  repo.UserRepository
 	tokenRepo repo.RefreshTokenRepository
+	mfaRepo   repo.MFARepository
+	sessions  *sessionIssuer
+	hasher    password.Hasher
+	policy    *password.Policy
 	jwt       *jwt.JWT
+	issuer    string
 	log       *zerolog.Logger
 }
 
+// sessionIssuer hands out access/refresh token pairs. It is shared by the password and OIDC login flows.
+type sessionIssuer struct {
+	jwt       *jwt.JWT
+	userRepo  repo.UserRepository
+	tokenRepo repo.RefreshTokenRepository
+	mfaRepo   repo.MFARepository
+	roleRepo  repo.RoleRepository
+	banRepo   repo.BanRepository
+}
+
+var (
+	ErrInvalidCredentials = entity.NewError(entity.KindUnauthorized, "invalid_credentials", "invalid username or password")
+	ErrInvalidMFAToken    = entity.NewError(entity.KindUnauthorized, "invalid_mfa_token", "invalid or expired mfa token")
+	ErrInvalidMFACode     = entity.NewError(entity.KindInvalid, "invalid_mfa_code", "invalid mfa code")
+	ErrMFANotEnrolled     = entity.NewError(entity.KindInvalid, "mfa_not_enrolled", "totp is not enrolled")
+	ErrMFAAlreadyEnabled  = entity.NewError(entity.KindConflict, "mfa_already_enabled", "totp is already enabled")
+	ErrUserBanned         = entity.NewError(entity.KindForbidden, "user_banned", "user is banned")
+	ErrUsernameTaken      = entity.NewError(entity.KindConflict, "username_taken", "username is already taken")
+)
+
+// BanError is returned instead of tokens while a ban is active. It matches ErrUserBanned with errors.Is,
+// and unwraps to a copy of it carrying the reason and expiry for the client.
+type BanError struct {
+	Ban *entity.Ban
+}
+
+func (e *BanError) Error() string {
+	if e.Ban.ExpiresAt == nil {
+		return fmt.Sprintf("user is banned permanently: %s", e.Ban.Reason)
+	}
+	return fmt.Sprintf("user is banned until %s: %s", e.Ban.ExpiresAt.Format(time.RFC3339), e.Ban.Reason)
+}
+
+func (e *BanError) Unwrap() error {
+	return ErrUserBanned.WithDetails(map[string]any{"reason": e.Ban.Reason, "expires_at": e.Ban.ExpiresAt})
+}
+
+const recoveryCodesCount = 10
+
+// maxMFAAttempts is how many codes may be tried with one mfa challenge before the user has to log in again.
+const maxMFAAttempts = 5
+
 const (
 	registerOp        = "AuthUsecase.Register"
 	loginOp           = "AuthUsecase.Login"
 	refreshOp         = "AuthUsecase.Refresh"
 	logoutOp          = "AuthUsecase.Logout"
 	isSessionActiveOp = "AuthUsecase.IsSessionActive"
+	loginMFAOp        = "AuthUsecase.LoginMFA"
+	enrollTOTPOp      = "AuthUsecase.EnrollTOTP"
+	confirmTOTPOp     = "AuthUsecase.ConfirmTOTP"
+	disableTOTPOp     = "AuthUsecase.DisableTOTP"
 )
 
-func NewAuthUsecase(userRepo repo.UserRepository, tokenRepo repo.RefreshTokenRepository, jwt *jwt.JWT, log *zerolog.Logger) AuthUsecase {
-	return &authUsecase{userRepo: userRepo, tokenRepo: tokenRepo, jwt: jwt, log: log}
+// NewAuthUsecase creates the auth usecase. New passwords are checked against policy and hashed with hasher.
+// issuer is shown in authenticator apps next to the account name.
+func NewAuthUsecase(userRepo repo.UserRepository, tokenRepo repo.RefreshTokenRepository, mfaRepo repo.MFARepository, roleRepo repo.RoleRepository, banRepo repo.BanRepository, hasher password.Hasher, policy *password.Policy, jwt *jwt.JWT, issuer string, log *zerolog.Logger) AuthUsecase {
+	return &authUsecase{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		mfaRepo:   mfaRepo,
+		sessions:  &sessionIssuer{jwt: jwt, userRepo: userRepo, tokenRepo: tokenRepo, mfaRepo: mfaRepo, roleRepo: roleRepo, banRepo: banRepo},
+		hasher:    hasher,
+		policy:    policy,
+		jwt:       jwt,
+		issuer:    issuer,
+		log:       log,
+	}
 }
 
-func (u *authUsecase) Register(ctx context.Context, username string, role string, password string) (*response.RegisterResponse, error) {
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	user := &entity.User{Username: username, Role: role, PasswordHash: string(hashedPassword)}
-	id, err := u.userRepo.Create(ctx, user)
-	if err != nil {
-		u.log.Error().Err(err).Str("op", registerOp).Str("username", username).Msg("Failed to create user in repository")
+// Register creates a regular user. Roles are only changed through the admin API.
+// A password that breaks the policy is rejected with a *password.PolicyError.
+func (u *authUsecase) Register(ctx context.Context, username string, pass string) (*response.RegisterResponse, error) {
+	if err := u.policy.Validate(username, pass); err != nil {
+		u.log.Warn().Err(err).Str("op", registerOp).Str("username", username).Msg("Password rejected by policy")
 		return nil, err
 	}
-	u.log.Info().Str("op", registerOp).Str("username", username).Msg("User registered successfully")
 
-	access, err := u.jwt.GenerateAccessToken(id, user.Role)
+	hashedPassword, err := u.hasher.Hash(pass)
 	if err != nil {
-		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		u.log.Error().Err(err).Str("op", registerOp).Str("username", username).Msg("Failed to hash password")
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	refresh, err := u.jwt.GenerateRefreshToken(id)
-	if err != nil {
-		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", user.ID).Msg("Failed to generate refresh token")
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	user := &entity.User{Username: username, Role: entity.RoleUser, PasswordHash: hashedPassword}
+	id, err := u.userRepo.Create(ctx, user)
+	if errors.Is(err, repo.ErrUsernameTaken) {
+		u.log.Warn().Str("op", registerOp).Str("username", username).Msg("Username is already taken")
+		return nil, ErrUsernameTaken
 	}
-
-	err = u.tokenRepo.Save(ctx, refresh, id)
 	if err != nil {
-		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", user.ID).Msg("Failed to save refresh token")
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+		u.log.Error().Err(err).Str("op", registerOp).Str("username", username).Msg("Failed to create user in repository")
+		return nil, err
 	}
+	u.log.Info().Str("op", registerOp).Str("username", username).Msg("User registered successfully")
 
 	createdUser, err := u.userRepo.GetByID(ctx, id)
 	if err != nil {
-		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", user.ID).Msg("Failed to get user in repository")
+		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", id).Msg("Failed to get user in repository")
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &response.RegisterResponse{User: *createdUser, Tokens: response.Tokens{AccessToken: access, RefreshToken: refresh}}, nil
+	tokens, err := u.sessions.issue(ctx, createdUser.ID, createdUser.Role)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", registerOp).Int64("user_id", id).Msg("Failed to issue tokens")
+		return nil, err
+	}
+
+	return &response.RegisterResponse{User: *createdUser, Tokens: *tokens}, nil
 }
 
-func (u *authUsecase) Login(ctx context.Context, username, password, refreshToken string) (*response.LoginResponse, error) {
+func (u *authUsecase) Login(ctx context.Context, username, pass, refreshToken string) (*response.LoginResponse, error) {
 	log := u.log.With().Str("op", loginOp).Str("username", username).Logger()
 	user, err := u.userRepo.GetByUsername(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warn().Msg("User not found")
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to get user by username or user not found")
-		return nil, fmt.Errorf("invalid username or password: %w", err)
+		log.Error().Err(err).Msg("Failed to get user by username")
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	if refreshToken != "" {
@@ -94,31 +176,360 @@ func (u *authUsecase) Login(ctx context.Context, username, password, refreshToke
 		}
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+	if !u.checkPassword(ctx, user.ID, user.PasswordHash, pass) {
 		log.Warn().Msg("Invalid password attempt")
-		return nil, errors.New("invalid username or password")
+		return nil, ErrInvalidCredentials
+	}
+
+	res, err := u.sessions.start(ctx, user)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to start session")
+		return nil, err
 	}
 
-	access, err := u.jwt.GenerateAccessToken(user.ID, user.Role)
+	if res.MFARequired {
+		log.Info().Int64("user_id", user.ID).Msg("Password accepted, waiting for second factor")
+	} else {
+		log.Info().Int64("user_id", user.ID).Msg("User logged in successfully")
+	}
+	return res, nil
+}
+
+func (u *authUsecase) LoginMFA(ctx context.Context, mfaToken, code string) (*response.LoginResponse, error) {
+	log := u.log.With().Str("op", loginMFAOp).Logger()
+
+	claims, err := u.jwt.ParseToken(mfaToken)
 	if err != nil {
-		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		log.Warn().Err(err).Msg("Failed to parse mfa token")
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMFAToken, err)
+	}
+
+	var mfaClaims entity.MFAClaims
+	if err := mapstructure.Decode(claims, &mfaClaims); err != nil || mfaClaims.Type != jwt.TokenTypeMFA || mfaClaims.ID == "" {
+		log.Warn().Msg("Token is not an mfa challenge")
+		return nil, ErrInvalidMFAToken
 	}
+	log = log.With().Int64("user_id", mfaClaims.UserID).Logger()
 
-	refresh, err := u.jwt.GenerateRefreshToken(user.ID)
+	// The attempt is counted before the code is checked, so parallel guesses cannot exceed the limit.
+	allowed, err := u.mfaRepo.UseChallengeAttempt(ctx, mfaClaims.ID, mfaClaims.UserID, maxMFAAttempts)
 	if err != nil {
-		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to generate refresh token")
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		log.Error().Err(err).Msg("Failed to count mfa attempt")
+		return nil, fmt.Errorf("failed to count mfa attempt: %w", err)
+	}
+	if !allowed {
+		log.Warn().Msg("MFA challenge used up or expired")
+		return nil, ErrInvalidMFAToken
+	}
+
+	if err := u.verifySecondFactor(ctx, mfaClaims.UserID, code); err != nil {
+		log.Warn().Err(err).Msg("Second factor rejected")
+		return nil, err
+	}
+
+	// A challenge gets one session; a concurrent request that also passed has already taken it.
+	deleted, err := u.mfaRepo.DeleteChallenge(ctx, mfaClaims.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete mfa challenge")
+		return nil, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+	if !deleted {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := u.userRepo.GetByID(ctx, mfaClaims.UserID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", mfaClaims.UserID).Msg("Failed to get user by ID")
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	tokens, err := u.sessions.issue(ctx, user.ID, user.Role)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to issue tokens")
+		return nil, err
+	}
+
+	log.Info().Int64("user_id", user.ID).Msg("User logged in with second factor")
+	return &response.LoginResponse{User: *user, Tokens: *tokens}, nil
+}
+
+func (u *authUsecase) EnrollTOTP(ctx context.Context, userID int64) (*response.TOTPEnrollResponse, error) {
+	log := u.log.With().Str("op", enrollTOTPOp).Int64("user_id", userID).Logger()
+
+	_, enabled, err := u.mfaRepo.GetSecret(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).Msg("Failed to check totp status")
+		return nil, fmt.Errorf("failed to check totp status: %w", err)
+	}
+	if enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get user by ID")
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate totp secret")
+		return nil, err
+	}
+
+	if err := u.mfaRepo.SaveSecret(ctx, userID, secret); err != nil {
+		log.Error().Err(err).Msg("Failed to save totp secret")
+		return nil, fmt.Errorf("failed to save totp secret: %w", err)
+	}
+
+	log.Info().Msg("TOTP enrollment started")
+	return &response.TOTPEnrollResponse{Secret: secret, URI: totp.URI(u.issuer, user.Username, secret)}, nil
+}
+
+func (u *authUsecase) ConfirmTOTP(ctx context.Context, userID int64, code string) (*response.TOTPConfirmResponse, error) {
+	log := u.log.With().Str("op", confirmTOTPOp).Int64("user_id", userID).Logger()
+
+	secret, enabled, err := u.mfaRepo.GetSecret(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMFANotEnrolled
+		}
+		log.Error().Err(err).Msg("Failed to get totp secret")
+		return nil, fmt.Errorf("failed to get totp secret: %w", err)
+	}
+	if enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.ValidateStep(secret, code, time.Now())
+	if !ok {
+		log.Warn().Msg("Invalid confirmation code")
+		return nil, ErrInvalidMFACode
+	}
+	// The confirmation code must not log anyone in afterwards.
+	if _, err := u.mfaRepo.UseTOTPStep(ctx, userID, step); err != nil {
+		log.Error().Err(err).Msg("Failed to record totp step")
+		return nil, fmt.Errorf("failed to record totp step: %w", err)
+	}
+
+	codes, hashes, err := generateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate recovery codes")
+		return nil, err
+	}
+
+	if err := u.mfaRepo.Enable(ctx, userID, hashes); err != nil {
+		log.Error().Err(err).Msg("Failed to enable totp")
+		return nil, fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	log.Info().Msg("TOTP enabled")
+	return &response.TOTPConfirmResponse{RecoveryCodes: codes}, nil
+}
+
+func (u *authUsecase) DisableTOTP(ctx context.Context, userID int64, pass string) error {
+	log := u.log.With().Str("op", disableTOTPOp).Int64("user_id", userID).Logger()
+
+	hash, err := u.userRepo.GetPasswordHash(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get password hash")
+		return fmt.Errorf("failed to get password hash: %w", err)
+	}
+
+	if !u.checkPassword(ctx, userID, hash, pass) {
+		log.Warn().Msg("Invalid password attempt")
+		return ErrInvalidCredentials
+	}
+
+	if err := u.mfaRepo.Disable(ctx, userID); err != nil {
+		log.Error().Err(err).Msg("Failed to disable totp")
+		return fmt.Errorf("failed to disable totp: %w", err)
+	}
+
+	log.Info().Msg("TOTP disabled")
+	return nil
+}
+
+// checkPassword verifies pass against hash. After a successful check a hash made with bcrypt or outdated
+// Argon2id parameters is replaced, so old accounts migrate as their owners log in.
+func (u *authUsecase) checkPassword(ctx context.Context, userID int64, hash, pass string) bool {
+	log := u.log.With().Int64("user_id", userID).Logger()
+
+	ok, err := u.hasher.Verify(hash, pass)
+	if err != nil {
+		log.Error().Err(err).Msg("Stored password hash is malformed")
+		return false
+	}
+	if !ok || !u.hasher.NeedsRehash(hash) {
+		return ok
+	}
+
+	newHash, err := u.hasher.Hash(pass)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to rehash password")
+		return true
+	}
+	if err := u.userRepo.UpdatePasswordHash(ctx, userID, newHash); err != nil {
+		log.Warn().Err(err).Msg("Failed to store rehashed password")
+		return true
 	}
 
-	err = u.tokenRepo.Save(ctx, refresh, user.ID)
+	log.Info().Msg("Password rehashed")
+	return true
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code. A TOTP code is
+// accepted once: codes of the time step of the last accepted one, or an earlier step, are rejected.
+func (u *authUsecase) verifySecondFactor(ctx context.Context, userID int64, code string) error {
+	secret, enabled, err := u.mfaRepo.GetSecret(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMFANotEnrolled
+		}
+		return fmt.Errorf("failed to get totp secret: %w", err)
+	}
+	if !enabled {
+		return ErrMFANotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := totp.ValidateStep(secret, code, time.Now()); ok {
+		fresh, err := u.mfaRepo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return fmt.Errorf("failed to record totp step: %w", err)
+		}
+		if !fresh {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := u.mfaRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
 	if err != nil {
-		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to save refresh token")
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// start finishes a first-factor login: it returns an mfa challenge when the user has 2FA enabled
+// and a fresh token pair otherwise.
+func (s *sessionIssuer) start(ctx context.Context, user *entity.User) (*response.LoginResponse, error) {
+	_, mfaEnabled, err := s.mfaRepo.GetSecret(ctx, user.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check totp status: %w", err)
+	}
+
+	if mfaEnabled {
+		if err := s.checkBan(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		challengeID, err := newChallengeID()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.mfaRepo.SaveChallenge(ctx, challengeID, user.ID, time.Now().Add(jwt.MFATTL)); err != nil {
+			return nil, fmt.Errorf("failed to save mfa challenge: %w", err)
+		}
+		mfaToken, err := s.jwt.GenerateMFAToken(user.ID, challengeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+		}
+		return &response.LoginResponse{User: *user, MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	tokens, err := s.issue(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.LoginResponse{User: *user, Tokens: *tokens}, nil
+}
+
+func (s *sessionIssuer) issue(ctx context.Context, userID int64, role string) (*response.Tokens, error) {
+	if err := s.checkBan(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	access, err := s.accessToken(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := s.jwt.GenerateRefreshToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if err := s.tokenRepo.Save(ctx, refresh, userID); err != nil {
 		return nil, fmt.Errorf("failed to save refresh token: %w", err)
 	}
 
-	log.Info().Int64("user_id", user.ID).Msg("User logged in successfully")
-	return &response.LoginResponse{User: *user, Tokens: response.Tokens{AccessToken: access, RefreshToken: refresh}}, nil
+	if err := s.userRepo.UpdateLastLogin(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to record login: %w", err)
+	}
+
+	return &response.Tokens{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// checkBan returns a *BanError when the user has an active ban.
+func (s *sessionIssuer) checkBan(ctx context.Context, userID int64) error {
+	ban, err := s.banRepo.GetActive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to check ban: %w", err)
+	}
+	return &BanError{Ban: ban}
+}
+
+// accessToken resolves the permissions of role and the user's moderated categories and signs them into an access token.
+func (s *sessionIssuer) accessToken(ctx context.Context, userID int64, role string) (string, error) {
+	permissions, categories, err := s.roleRepo.GetAccess(ctx, userID, role)
+	if err != nil {
+		return "", fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	access, err := s.jwt.GenerateAccessToken(userID, role, permissions, categories)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return access, nil
+}
+
+func generateRecoveryCodes(n int) (codes []string, hashes []string, err error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := 0; i < n; i++ {
+		buf := make([]byte, 6)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		raw := strings.ToLower(encoding.EncodeToString(buf))
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func newChallengeID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate mfa challenge id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashRecoveryCode normalizes user input so "ABCDE FGHIJ" and "abcde-fghij" hash the same.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
 }
 
 func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*response.RefreshResponse, error) {
@@ -156,13 +567,18 @@ func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*respon
 		return nil, fmt.Errorf("failed to delete used refresh token: %w", err)
 	}
 
+	if err := u.sessions.checkBan(ctx, userID); err != nil {
+		log.Warn().Err(err).Int64("user_id", userID).Msg("Refresh rejected")
+		return nil, err
+	}
+
 	role, err := u.userRepo.GetRole(ctx, userID)
 	if err != nil {
 		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to get user role for refresh")
 		return nil, fmt.Errorf("failed to get user role for refresh: %w", err)
 	}
 
-	newAccess, err := u.jwt.GenerateAccessToken(userID, role)
+	newAccess, err := u.sessions.accessToken(ctx, userID, role)
 	if err != nil {
 		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to generate new access token")
 		return nil, fmt.Errorf("failed to generate new access token: %w", err)
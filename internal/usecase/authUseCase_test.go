@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
 	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
 	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/password"
+	"github.com/Van-programan/Forum_GO/pkg/totp"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +26,10 @@ type AuthUsecaseSuite struct {
 	usecase   AuthUsecase
 	userRepo  *mocks.UserRepository
 	tokenRepo *mocks.RefreshTokenRepository
+	mfaRepo   *mocks.MFARepository
+	roleRepo  *mocks.RoleRepository
+	banRepo   *mocks.BanRepository
+	hasher    password.Hasher
 	jwt       *jwt.JWT
 	log       *zerolog.Logger
 }
@@ -29,10 +37,15 @@ type AuthUsecaseSuite struct {
 func (s *AuthUsecaseSuite) SetupTest() {
 	s.userRepo = mocks.NewUserRepository(s.T())
 	s.tokenRepo = mocks.NewRefreshTokenRepository(s.T())
+	s.mfaRepo = mocks.NewMFARepository(s.T())
+	s.roleRepo = mocks.NewRoleRepository(s.T())
+	s.banRepo = mocks.NewBanRepository(s.T())
+	s.hasher = password.NewArgon2id(password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	policy := password.NewPolicy(8, 128, []string{"password", "qwerty123"})
 	s.jwt = jwt.New("secret", 1*time.Minute, 10*time.Minute)
 	logger := zerolog.Nop()
 	s.log = &logger
-	s.usecase = NewAuthUsecase(s.userRepo, s.tokenRepo, s.jwt, s.log)
+	s.usecase = NewAuthUsecase(s.userRepo, s.tokenRepo, s.mfaRepo, s.roleRepo, s.banRepo, s.hasher, policy, s.jwt, "Forum_go", s.log)
 }
 
 func TestAuthUsecaseSuite(t *testing.T) {
@@ -44,27 +57,31 @@ func (s *AuthUsecaseSuite) TestRegister_Success() {
 	ctx := context.Background()
 	username := "user"
 	role := "user"
-	password := "password"
+	password := "correct-horse-battery"
 	userID := int64(1)
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, _ := s.hasher.Hash(password)
 
 	expectedUser := &entity.User{
 		ID:           userID,
 		Username:     username,
 		Role:         role,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		CreatedAt:    time.Now(),
 	}
 
 	s.userRepo.On("Create", ctx, mock.MatchedBy(func(user *entity.User) bool {
-		return user.Username == username && user.Role == role && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+		ok, _ := s.hasher.Verify(user.PasswordHash, password)
+		return user.Username == username && user.Role == role && strings.HasPrefix(user.PasswordHash, "$argon2id$") && ok
 	})).Return(userID, nil)
 
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
 
 	s.userRepo.On("GetByID", ctx, userID).Return(expectedUser, nil).Once()
 
-	resp, err := s.usecase.Register(ctx, username, role, password)
+	resp, err := s.usecase.Register(ctx, username, password)
 
 	s.NoError(err)
 	s.NotNil(resp)
@@ -79,13 +96,12 @@ func (s *AuthUsecaseSuite) TestRegister_Success() {
 func (s *AuthUsecaseSuite) TestRegister_CreateUserError() {
 	ctx := context.Background()
 	username := "testuser"
-	role := "user"
-	password := "password123"
+	password := "correct-horse-battery"
 	expectedError := errors.New("db error on create")
 
 	s.userRepo.On("Create", ctx, mock.AnythingOfType("*entity.User")).Return(int64(0), expectedError).Once()
 
-	resp, err := s.usecase.Register(ctx, username, role, password)
+	resp, err := s.usecase.Register(ctx, username, password)
 
 	s.Error(err)
 	s.Nil(resp)
@@ -95,44 +111,82 @@ func (s *AuthUsecaseSuite) TestRegister_CreateUserError() {
 	s.userRepo.AssertNotCalled(s.T(), "GetByID")
 }
 
+func (s *AuthUsecaseSuite) TestRegister_UsernameTaken() {
+	ctx := context.Background()
+
+	s.userRepo.On("Create", ctx, mock.AnythingOfType("*entity.User")).Return(int64(0), repo.ErrUsernameTaken).Once()
+
+	resp, err := s.usecase.Register(ctx, "testuser", "correct-horse-battery")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrUsernameTaken)
+	s.userRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
 func (s *AuthUsecaseSuite) TestRegister_SaveTokenError() {
 	ctx := context.Background()
 	username := "testuser"
 	role := "user"
-	password := "password123"
+	password := "correct-horse-battery"
 	userID := int64(1)
 	expectedError := errors.New("db error on save token")
 
 	s.userRepo.On("Create", ctx, mock.AnythingOfType("*entity.User")).Return(userID, nil).Once()
+	s.userRepo.On("GetByID", ctx, userID).Return(&entity.User{ID: userID, Username: username, Role: role}, nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, role).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(expectedError).Once()
 
-	resp, err := s.usecase.Register(ctx, username, role, password)
+	resp, err := s.usecase.Register(ctx, username, password)
 
 	s.Error(err)
 	s.Nil(resp)
 	s.Contains(err.Error(), "failed to save refresh token")
-	s.userRepo.AssertNotCalled(s.T(), "GetByID")
 	s.tokenRepo.AssertExpectations(s.T())
 }
 
 func (s *AuthUsecaseSuite) TestRegister_GetUserAfterCreateError() {
 	ctx := context.Background()
 	username := "user"
-	role := "user"
-	password := "password"
+	password := "correct-horse-battery"
 	userID := int64(1)
 	expectedError := errors.New("db error on get user")
 
 	s.userRepo.On("Create", ctx, mock.AnythingOfType("*entity.User")).Return(userID, nil).Once()
-	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
 	s.userRepo.On("GetByID", ctx, userID).Return(nil, expectedError).Once()
 
-	resp, err := s.usecase.Register(ctx, username, role, password)
+	resp, err := s.usecase.Register(ctx, username, password)
 
 	s.Error(err)
 	s.Nil(resp)
 	s.Contains(err.Error(), "failed to get user")
 	s.userRepo.AssertExpectations(s.T())
+	s.tokenRepo.AssertNotCalled(s.T(), "Save")
+}
+
+func (s *AuthUsecaseSuite) TestRegister_PasswordPolicy() {
+	ctx := context.Background()
+
+	resp, err := s.usecase.Register(ctx, "johnny", "Johnny1")
+
+	s.Nil(resp)
+	var policyErr *password.PolicyError
+	s.Require().ErrorAs(err, &policyErr)
+	codes := make([]string, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		codes = append(codes, v.Code)
+	}
+	s.ElementsMatch([]string{password.ViolationTooShort, password.ViolationContainsUsername}, codes)
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestRegister_CommonPassword() {
+	resp, err := s.usecase.Register(context.Background(), "testuser", "QWERTY123")
+
+	s.Nil(resp)
+	var policyErr *password.PolicyError
+	s.Require().ErrorAs(err, &policyErr)
+	s.Equal([]password.Violation{{Code: password.ViolationCommon, Message: "password is too common"}}, policyErr.Violations)
 }
 
 // Login
@@ -143,19 +197,23 @@ func (s *AuthUsecaseSuite) TestLogin_Success() {
 	userID := int64(1)
 	role := "user"
 
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, _ := s.hasher.Hash(password)
 	expectedUser := &entity.User{
 		ID:           userID,
 		Username:     username,
 		Role:         role,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		CreatedAt:    time.Now(),
 	}
 	oldRefreshToken := "old-refresh-token"
 
 	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
 	s.tokenRepo.On("Delete", ctx, oldRefreshToken).Return(nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
 
 	resp, err := s.usecase.Login(ctx, username, password, oldRefreshToken)
 
@@ -175,17 +233,21 @@ func (s *AuthUsecaseSuite) TestLogin_Success_NoOldToken() {
 	password := "password123"
 	userID := int64(1)
 	role := "user"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, _ := s.hasher.Hash(password)
 	expectedUser := &entity.User{
 		ID:           userID,
 		Username:     username,
 		Role:         role,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		CreatedAt:    time.Now(),
 	}
 
 	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
 
 	resp, err := s.usecase.Login(ctx, username, password, "")
 
@@ -203,17 +265,31 @@ func (s *AuthUsecaseSuite) TestLogin_UserNotFound() {
 	ctx := context.Background()
 	username := "user"
 	password := "password"
+
+	s.userRepo.On("GetByUsername", ctx, username).Return(nil, fmt.Errorf("UserRepository - GetByUsername - row.Scan(): %w", pgx.ErrNoRows)).Once()
+
+	resp, err := s.usecase.Login(ctx, username, password, "")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidCredentials)
+	s.userRepo.AssertExpectations(s.T())
+	s.tokenRepo.AssertNotCalled(s.T(), "Save")
+	s.tokenRepo.AssertNotCalled(s.T(), "Delete")
+}
+
+func (s *AuthUsecaseSuite) TestLogin_GetUserError() {
+	ctx := context.Background()
+	username := "user"
+	password := "password"
 	expectedError := errors.New("db error on get user")
 
 	s.userRepo.On("GetByUsername", ctx, username).Return(nil, expectedError).Once()
 
 	resp, err := s.usecase.Login(ctx, username, password, "")
 
-	s.Error(err)
 	s.Nil(resp)
-	s.Contains(err.Error(), "invalid username or password")
 	s.ErrorIs(err, expectedError)
-	s.userRepo.AssertExpectations(s.T())
+	s.NotErrorIs(err, ErrInvalidCredentials)
 	s.tokenRepo.AssertNotCalled(s.T(), "Save")
 	s.tokenRepo.AssertNotCalled(s.T(), "Delete")
 }
@@ -226,12 +302,12 @@ func (s *AuthUsecaseSuite) TestLogin_IncorrectPassword() {
 	role := "user"
 
 	correctPassword := "password"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(correctPassword), bcrypt.DefaultCost)
+	hashedPassword, _ := s.hasher.Hash(correctPassword)
 	expectedUser := &entity.User{
 		ID:           userID,
 		Username:     username,
 		Role:         role,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		CreatedAt:    time.Now(),
 	}
 
@@ -253,8 +329,8 @@ func (s *AuthUsecaseSuite) TestLogin_DeleteOldTokenError() {
 	password := "password"
 	userID := int64(1)
 	role := "user"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	expectedUser := &entity.User{ID: userID, Username: username, Role: role, PasswordHash: string(hashedPassword)}
+	hashedPassword, _ := s.hasher.Hash(password)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: role, PasswordHash: hashedPassword}
 	oldRefreshToken := "old-refresh-token"
 	expectedError := errors.New("db error on delete token")
 
@@ -278,11 +354,14 @@ func (s *AuthUsecaseSuite) TestLogin_SaveTokenError() {
 	password := "password"
 	userID := int64(1)
 	role := "user"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	expectedUser := &entity.User{ID: userID, Username: username, Role: role, PasswordHash: string(hashedPassword)}
+	hashedPassword, _ := s.hasher.Hash(password)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: role, PasswordHash: hashedPassword}
 	expectedError := errors.New("db error on save token")
 
 	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(expectedError).Once()
 
 	resp, err := s.usecase.Login(ctx, username, password, "")
@@ -296,6 +375,307 @@ func (s *AuthUsecaseSuite) TestLogin_SaveTokenError() {
 	s.tokenRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything) // Delete не вызывался, т.к. old token пуст
 }
 
+// MFA
+func (s *AuthUsecaseSuite) TestLogin_MFARequired() {
+	ctx := context.Background()
+	username := "testuser"
+	password := "password123"
+	userID := int64(1)
+	hashedPassword, _ := s.hasher.Hash(password)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: "user", PasswordHash: hashedPassword}
+
+	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("SECRET", true, nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	var challengeID string
+	s.mfaRepo.On("SaveChallenge", ctx, mock.AnythingOfType("string"), userID, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return time.Until(expiresAt) > 4*time.Minute
+	})).Run(func(args mock.Arguments) { challengeID = args.String(1) }).Return(nil).Once()
+
+	resp, err := s.usecase.Login(ctx, username, password, "")
+
+	s.NoError(err)
+	s.True(resp.MFARequired)
+	s.NotEmpty(resp.MFAToken)
+	claims, err := s.jwt.ParseToken(resp.MFAToken)
+	s.Require().NoError(err)
+	s.Equal(challengeID, (*claims)["jti"])
+	s.Empty(resp.Tokens.AccessToken)
+	s.Empty(resp.Tokens.RefreshToken)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save")
+}
+
+func (s *AuthUsecaseSuite) TestLogin_RehashesBcryptPassword() {
+	ctx := context.Background()
+	username := "testuser"
+	password := "password123"
+	userID := int64(1)
+	legacyHash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: "user", PasswordHash: string(legacyHash)}
+
+	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.userRepo.On("UpdatePasswordHash", ctx, userID, mock.MatchedBy(func(hash string) bool {
+		ok, _ := s.hasher.Verify(hash, password)
+		return ok && !s.hasher.NeedsRehash(hash)
+	})).Return(nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, "user").Return([]string{}, []int64{}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
+
+	resp, err := s.usecase.Login(ctx, username, password, "")
+
+	s.NoError(err)
+	s.NotEmpty(resp.Tokens.AccessToken)
+	s.userRepo.AssertExpectations(s.T())
+}
+
+func (s *AuthUsecaseSuite) TestLogin_RehashFailureDoesNotBlockLogin() {
+	ctx := context.Background()
+	username := "testuser"
+	password := "password123"
+	userID := int64(1)
+	legacyHash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: "user", PasswordHash: string(legacyHash)}
+
+	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.userRepo.On("UpdatePasswordHash", ctx, userID, mock.AnythingOfType("string")).Return(errors.New("db error")).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("SECRET", true, nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.mfaRepo.On("SaveChallenge", ctx, mock.AnythingOfType("string"), userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	resp, err := s.usecase.Login(ctx, username, password, "")
+
+	s.NoError(err)
+	s.True(resp.MFARequired)
+}
+
+func (s *AuthUsecaseSuite) TestLogin_Banned() {
+	ctx := context.Background()
+	username := "testuser"
+	password := "password123"
+	userID := int64(1)
+	hashedPassword, _ := s.hasher.Hash(password)
+	expectedUser := &entity.User{ID: userID, Username: username, Role: "user", PasswordHash: hashedPassword}
+	expiresAt := time.Now().Add(time.Hour)
+	ban := &entity.Ban{ID: 3, UserID: userID, BannedBy: 2, Reason: "spam", ExpiresAt: &expiresAt}
+
+	s.userRepo.On("GetByUsername", ctx, username).Return(expectedUser, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(ban, nil).Once()
+
+	resp, err := s.usecase.Login(ctx, username, password, "")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrUserBanned)
+	var banErr *BanError
+	s.Require().ErrorAs(err, &banErr)
+	s.Equal("spam", banErr.Ban.Reason)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_Success_TOTP() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+	expectedUser := &entity.User{ID: userID, Username: "testuser", Role: "user"}
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(true, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, true, nil).Once()
+	s.mfaRepo.On("UseTOTPStep", ctx, userID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+	s.mfaRepo.On("DeleteChallenge", ctx, "challenge").Return(true, nil).Once()
+	s.userRepo.On("GetByID", ctx, userID).Return(expectedUser, nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, code)
+
+	s.NoError(err)
+	s.Equal(userID, resp.User.ID)
+	s.NotEmpty(resp.Tokens.AccessToken)
+	s.NotEmpty(resp.Tokens.RefreshToken)
+	s.mfaRepo.AssertNotCalled(s.T(), "UseRecoveryCode")
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_Success_RecoveryCode() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+	expectedUser := &entity.User{ID: userID, Username: "testuser", Role: "user"}
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(true, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, true, nil).Once()
+	s.mfaRepo.On("UseRecoveryCode", ctx, userID, hashRecoveryCode("abcde-fghij")).Return(true, nil).Once()
+	s.mfaRepo.On("DeleteChallenge", ctx, "challenge").Return(true, nil).Once()
+	s.userRepo.On("GetByID", ctx, userID).Return(expectedUser, nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, userID).Return(nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, "ABCDE FGHIJ")
+
+	s.NoError(err)
+	s.NotEmpty(resp.Tokens.AccessToken)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_InvalidCode() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(true, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, true, nil).Once()
+	s.mfaRepo.On("UseRecoveryCode", ctx, userID, mock.AnythingOfType("string")).Return(false, nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, "000000")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFACode)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save")
+	s.mfaRepo.AssertNotCalled(s.T(), "DeleteChallenge", mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_ReplayedCode() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	now := time.Now()
+	code, _ := totp.Code(secret, now)
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(true, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, true, nil).Once()
+	s.mfaRepo.On("UseTOTPStep", ctx, userID, mock.MatchedBy(func(step int64) bool {
+		return step >= now.Unix()/30-1 && step <= now.Unix()/30+1
+	})).Return(false, nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, code)
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFACode)
+	s.mfaRepo.AssertNotCalled(s.T(), "UseRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+	s.mfaRepo.AssertNotCalled(s.T(), "DeleteChallenge", mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_ChallengeUsedUp() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(false, nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, code)
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFAToken)
+	s.mfaRepo.AssertNotCalled(s.T(), "GetSecret", mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_ChallengeTakenByConcurrentLogin() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+	mfaToken, _ := s.jwt.GenerateMFAToken(userID, "challenge")
+
+	s.mfaRepo.On("UseChallengeAttempt", ctx, "challenge", userID, maxMFAAttempts).Return(true, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, true, nil).Once()
+	s.mfaRepo.On("UseTOTPStep", ctx, userID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+	s.mfaRepo.On("DeleteChallenge", ctx, "challenge").Return(false, nil).Once()
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, code)
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFAToken)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_TokenWithoutChallengeRejected() {
+	ctx := context.Background()
+	mfaToken, _ := s.jwt.GenerateMFAToken(1, "")
+
+	resp, err := s.usecase.LoginMFA(ctx, mfaToken, "123456")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFAToken)
+	s.mfaRepo.AssertNotCalled(s.T(), "UseChallengeAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AuthUsecaseSuite) TestLoginMFA_AccessTokenRejected() {
+	ctx := context.Background()
+	accessToken, _ := s.jwt.GenerateAccessToken(1, "user", nil, nil)
+
+	resp, err := s.usecase.LoginMFA(ctx, accessToken, "123456")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrInvalidMFAToken)
+	s.mfaRepo.AssertNotCalled(s.T(), "GetSecret")
+}
+
+func (s *AuthUsecaseSuite) TestEnrollTOTP_AlreadyEnabled() {
+	ctx := context.Background()
+	userID := int64(1)
+
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("SECRET", true, nil).Once()
+
+	resp, err := s.usecase.EnrollTOTP(ctx, userID)
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrMFAAlreadyEnabled)
+	s.mfaRepo.AssertNotCalled(s.T(), "SaveSecret")
+}
+
+func (s *AuthUsecaseSuite) TestConfirmTOTP_Success() {
+	ctx := context.Background()
+	userID := int64(1)
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, time.Now())
+
+	s.mfaRepo.On("GetSecret", ctx, userID).Return(secret, false, nil).Once()
+	s.mfaRepo.On("UseTOTPStep", ctx, userID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+	s.mfaRepo.On("Enable", ctx, userID, mock.MatchedBy(func(hashes []string) bool { return len(hashes) == recoveryCodesCount })).Return(nil).Once()
+
+	resp, err := s.usecase.ConfirmTOTP(ctx, userID, code)
+
+	s.NoError(err)
+	s.Len(resp.RecoveryCodes, recoveryCodesCount)
+}
+
+func (s *AuthUsecaseSuite) TestConfirmTOTP_NotEnrolled() {
+	ctx := context.Background()
+	userID := int64(1)
+
+	s.mfaRepo.On("GetSecret", ctx, userID).Return("", false, pgx.ErrNoRows).Once()
+
+	resp, err := s.usecase.ConfirmTOTP(ctx, userID, "123456")
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrMFANotEnrolled)
+}
+
+func (s *AuthUsecaseSuite) TestDisableTOTP_WrongPassword() {
+	ctx := context.Background()
+	userID := int64(1)
+	hashedPassword, _ := s.hasher.Hash("password")
+
+	s.userRepo.On("GetPasswordHash", ctx, userID).Return(hashedPassword, nil).Once()
+
+	err := s.usecase.DisableTOTP(ctx, userID, "wrong")
+
+	s.ErrorIs(err, ErrInvalidCredentials)
+	s.mfaRepo.AssertNotCalled(s.T(), "Disable")
+}
+
 // Refresh
 func (s *AuthUsecaseSuite) TestRefresh_Success() {
 	ctx := context.Background()
@@ -306,7 +686,9 @@ func (s *AuthUsecaseSuite) TestRefresh_Success() {
 
 	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
 	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
 	s.userRepo.On("GetRole", ctx, userID).Return(role, nil).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
 
 	resp, err := s.usecase.Refresh(ctx, refreshToken)
@@ -320,6 +702,49 @@ func (s *AuthUsecaseSuite) TestRefresh_Success() {
 	s.tokenRepo.AssertExpectations(s.T())
 }
 
+func (s *AuthUsecaseSuite) TestRefresh_AccessTokenCarriesPermissions() {
+	ctx := context.Background()
+	userID := int64(1)
+	role := "moderator"
+	refreshToken, _ := s.jwt.GenerateRefreshToken(userID)
+
+	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
+	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.userRepo.On("GetRole", ctx, userID).Return(role, nil).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, role).Return([]string{entity.PermTopicLock}, []int64{3}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(nil).Once()
+
+	resp, err := s.usecase.Refresh(ctx, refreshToken)
+	s.Require().NoError(err)
+
+	claims, err := s.jwt.ParseToken(resp.Tokens.AccessToken)
+	s.Require().NoError(err)
+	s.Equal(role, (*claims)["role"])
+	s.Equal([]interface{}{entity.PermTopicLock}, (*claims)["permissions"])
+	s.Equal([]interface{}{float64(3)}, (*claims)["moderated_categories"])
+}
+
+func (s *AuthUsecaseSuite) TestRefresh_GetAccessError() {
+	ctx := context.Background()
+	userID := int64(1)
+	refreshToken, _ := s.jwt.GenerateRefreshToken(userID)
+	expectedError := errors.New("db error on get access")
+
+	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
+	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
+	s.userRepo.On("GetRole", ctx, userID).Return("user", nil).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, "user").Return(nil, nil, expectedError).Once()
+
+	resp, err := s.usecase.Refresh(ctx, refreshToken)
+
+	s.Error(err)
+	s.Nil(resp)
+	s.ErrorIs(err, expectedError)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save")
+}
+
 func (s *AuthUsecaseSuite) TestRefresh_InvalidTokenSignature() {
 	ctx := context.Background()
 	invalidToken := "this.is.invalid"
@@ -406,6 +831,7 @@ func (s *AuthUsecaseSuite) TestRefresh_GetRoleError() {
 
 	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
 	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
 	s.userRepo.On("GetRole", ctx, userID).Return("", expectedError).Once()
 
 	resp, err := s.usecase.Refresh(ctx, refreshToken)
@@ -419,6 +845,24 @@ func (s *AuthUsecaseSuite) TestRefresh_GetRoleError() {
 	s.tokenRepo.AssertNotCalled(s.T(), "Save")
 }
 
+func (s *AuthUsecaseSuite) TestRefresh_Banned() {
+	ctx := context.Background()
+	userID := int64(1)
+	refreshToken, _ := s.jwt.GenerateRefreshToken(userID)
+	ban := &entity.Ban{ID: 3, UserID: userID, BannedBy: 2, Reason: "spam"}
+
+	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
+	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(ban, nil).Once()
+
+	resp, err := s.usecase.Refresh(ctx, refreshToken)
+
+	s.Nil(resp)
+	s.ErrorIs(err, ErrUserBanned)
+	s.userRepo.AssertNotCalled(s.T(), "GetRole", mock.Anything, mock.Anything)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *AuthUsecaseSuite) TestRefresh_SaveNewTokenError() {
 	ctx := context.Background()
 	userID := int64(1)
@@ -428,7 +872,9 @@ func (s *AuthUsecaseSuite) TestRefresh_SaveNewTokenError() {
 
 	s.tokenRepo.On("GetUserID", ctx, refreshToken).Return(userID, nil).Once()
 	s.tokenRepo.On("Delete", ctx, refreshToken).Return(nil).Once()
+	s.banRepo.On("GetActive", ctx, userID).Return(nil, pgx.ErrNoRows).Once()
 	s.userRepo.On("GetRole", ctx, userID).Return(role, nil).Once()
+	s.roleRepo.On("GetAccess", ctx, userID, mock.Anything).Return([]string{}, []int64{}, nil).Once()
 	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), userID).Return(expectedError).Once()
 
 	resp, err := s.usecase.Refresh(ctx, refreshToken)
@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/Van-programan/Forum_GO/internal/client"
 	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
 	mocksf "github.com/Van-programan/Forum_GO/mocks/forum"
 	mocks "github.com/Van-programan/Forum_GO/mocks/forum/repository"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/rs/zerolog"
@@ -26,9 +30,10 @@ type CategoryUsecaseSuite struct {
 
 type ChatUsecaseSuite struct {
 	suite.Suite
-	usecase      ChatUsecase
-	chatRepoMock *mocks.ChatRepository
-	log          *zerolog.Logger
+	usecase        ChatUsecase
+	chatRepoMock   *mocks.ChatRepository
+	userClientMock *mocksf.UserClient
+	log            *zerolog.Logger
 }
 
 type PostUsecaseSuite struct {
@@ -159,6 +164,31 @@ func (s *CategoryUsecaseSuite) TestGetAllCategories_RepoError() {
 	s.repoMock.AssertExpectations(s.T())
 }
 
+func (s *CategoryUsecaseSuite) TestGetAllVersionCategories_Success() {
+	ctx := context.Background()
+	expected := entity.CollectionVersion{Version: 4, UpdatedAt: time.Now()}
+
+	s.repoMock.On("GetAllVersion", ctx).Return(expected, nil).Once()
+
+	version, err := s.usecase.GetAllVersion(ctx)
+
+	s.NoError(err)
+	s.Equal(expected, version)
+	s.repoMock.AssertNotCalled(s.T(), "GetAll", mock.Anything)
+}
+
+func (s *CategoryUsecaseSuite) TestGetAllVersionCategories_RepoError() {
+	ctx := context.Background()
+	expectedError := errors.New("repository error")
+
+	s.repoMock.On("GetAllVersion", ctx).Return(entity.CollectionVersion{}, expectedError).Once()
+
+	_, err := s.usecase.GetAllVersion(ctx)
+
+	s.ErrorIs(err, expectedError)
+	s.repoMock.AssertExpectations(s.T())
+}
+
 // Update
 func (s *CategoryUsecaseSuite) TestUpdateCategory_Success() {
 	ctx := context.Background()
@@ -166,9 +196,9 @@ func (s *CategoryUsecaseSuite) TestUpdateCategory_Success() {
 	title := "Updated Title"
 	description := "Updated Description"
 
-	s.repoMock.On("Update", ctx, categoryID, title, description).Return(nil).Once()
+	s.repoMock.On("Update", ctx, categoryID, title, description, int64(2)).Return(int64(3), nil).Once()
 
-	err := s.usecase.Update(ctx, categoryID, title, description)
+	_, err := s.usecase.Update(ctx, categoryID, title, description, int64(2))
 
 	s.NoError(err)
 	s.repoMock.AssertExpectations(s.T())
@@ -181,9 +211,9 @@ func (s *CategoryUsecaseSuite) TestUpdateCategory_RepoError() {
 	description := "Updated Description"
 	expectedError := errors.New("repository error")
 
-	s.repoMock.On("Update", ctx, categoryID, title, description).Return(expectedError).Once()
+	s.repoMock.On("Update", ctx, categoryID, title, description, int64(2)).Return(int64(0), expectedError).Once()
 
-	err := s.usecase.Update(ctx, categoryID, title, description)
+	_, err := s.usecase.Update(ctx, categoryID, title, description, int64(2))
 
 	s.Error(err)
 	s.Contains(err.Error(), "ForumService - CategoryUsecase - Update - repo.Update()")
@@ -191,6 +221,76 @@ func (s *CategoryUsecaseSuite) TestUpdateCategory_RepoError() {
 	s.repoMock.AssertExpectations(s.T())
 }
 
+func (s *CategoryUsecaseSuite) TestUpdateCategory_VersionConflict() {
+	ctx := context.Background()
+	categoryID := int64(1)
+	current := &entity.Category{ID: categoryID, Title: "Changed meanwhile", Version: 5}
+
+	s.repoMock.On("Update", ctx, categoryID, "Updated Title", "", int64(2)).Return(int64(0), fmt.Errorf("row.Scan(): %w", pgx.ErrNoRows)).Once()
+	s.repoMock.On("GetByID", ctx, categoryID).Return(current, nil).Once()
+
+	_, err := s.usecase.Update(ctx, categoryID, "Updated Title", "", int64(2))
+
+	s.ErrorIs(err, ErrVersionConflict)
+	var conflict *entity.Error
+	s.Require().True(errors.As(err, &conflict))
+	s.Equal(current, conflict.Details["current"])
+	s.repoMock.AssertExpectations(s.T())
+}
+
+// The cached repository leaves invalidation to the usecase, which must drop the stale category before
+// reading the current one for the conflict.
+func (s *CategoryUsecaseSuite) TestUpdateCategory_VersionConflictInvalidatesCache() {
+	ctx := context.Background()
+	categoryID := int64(1)
+	uc := NewCategoryUsecase(repo.NewCachedCategoryRepository(s.repoMock, repo.CacheConfig{Size: 10, TTL: time.Minute}), s.log)
+
+	s.repoMock.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Version: 2}, nil).Once()
+	_, err := uc.GetByID(ctx, categoryID)
+	s.Require().NoError(err)
+
+	current := &entity.Category{ID: categoryID, Title: "Changed meanwhile", Version: 5}
+	s.repoMock.On("Update", ctx, categoryID, "Updated Title", "", int64(2)).Return(int64(0), pgx.ErrNoRows).Once()
+	s.repoMock.On("GetByID", mock.Anything, categoryID).Return(current, nil).Once()
+
+	_, err = uc.Update(ctx, categoryID, "Updated Title", "", int64(2))
+
+	var conflict *entity.Error
+	s.Require().True(errors.As(err, &conflict))
+	s.Equal(current, conflict.Details["current"])
+}
+
+func (s *CategoryUsecaseSuite) TestCreateCategory_InvalidatesCachedList() {
+	ctx := context.Background()
+	uc := NewCategoryUsecase(repo.NewCachedCategoryRepository(s.repoMock, repo.CacheConfig{Size: 10, TTL: time.Minute}), s.log)
+
+	s.repoMock.On("GetAll", mock.Anything).Return([]entity.Category{{ID: 1}}, nil).Once()
+	_, err := uc.GetAll(ctx)
+	s.Require().NoError(err)
+
+	s.repoMock.On("Create", ctx, entity.Category{Title: "Help"}).Return(int64(2), nil).Once()
+	_, err = uc.Create(ctx, entity.Category{Title: "Help"})
+	s.Require().NoError(err)
+
+	s.repoMock.On("GetAll", mock.Anything).Return([]entity.Category{{ID: 1}, {ID: 2}}, nil).Once()
+	categories, err := uc.GetAll(ctx)
+	s.NoError(err)
+	s.Len(categories, 2)
+}
+
+func (s *CategoryUsecaseSuite) TestUpdateCategory_NotFound() {
+	ctx := context.Background()
+	categoryID := int64(1)
+
+	s.repoMock.On("Update", ctx, categoryID, "Updated Title", "", int64(2)).Return(int64(0), pgx.ErrNoRows).Once()
+	s.repoMock.On("GetByID", ctx, categoryID).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.Update(ctx, categoryID, "Updated Title", "", int64(2))
+
+	s.ErrorIs(err, ErrCategoryNotFound)
+	s.repoMock.AssertExpectations(s.T())
+}
+
 // Delete
 func (s *CategoryUsecaseSuite) TestDeleteCategory_Success() {
 	ctx := context.Background()
@@ -221,9 +321,10 @@ func (s *CategoryUsecaseSuite) TestDeleteCategory_RepoError() {
 
 func (s *ChatUsecaseSuite) SetupTest() {
 	s.chatRepoMock = mocks.NewChatRepository(s.T())
+	s.userClientMock = mocksf.NewUserClient(s.T())
 	logger := zerolog.Nop()
 	s.log = &logger
-	s.usecase = NewChatUsecase(s.chatRepoMock, s.log)
+	s.usecase = NewChatUsecase(s.chatRepoMock, s.userClientMock, s.log)
 }
 
 func TestChatUsecaseSuite(t *testing.T) {
@@ -273,6 +374,8 @@ func (s *ChatUsecaseSuite) TestSaveMessage_Success() {
 	content := "This is a test message."
 	expectedMessageID := int64(123)
 
+	s.userClientMock.On("GetUserStatus", ctx, userID).Return(&entity.UserStatus{UserID: userID}, nil).Once()
+
 	var capturedMessage *entity.ChatMessage
 	s.chatRepoMock.On("SaveMessage", ctx, mock.MatchedBy(func(msg *entity.ChatMessage) bool {
 		capturedMessage = msg
@@ -304,6 +407,7 @@ func (s *ChatUsecaseSuite) TestSaveMessage_RepoError() {
 	content := "test-message"
 	expectedError := errors.New("repository error saving message")
 
+	s.userClientMock.On("GetUserStatus", ctx, userID).Return(&entity.UserStatus{UserID: userID}, nil).Once()
 	s.chatRepoMock.On("SaveMessage", ctx, mock.MatchedBy(func(msg *entity.ChatMessage) bool {
 		return msg.UserID == userID && msg.Username == username && msg.Content == content
 	})).Return(int64(0), expectedError).Once()
@@ -317,6 +421,19 @@ func (s *ChatUsecaseSuite) TestSaveMessage_RepoError() {
 	s.chatRepoMock.AssertExpectations(s.T())
 }
 
+func (s *ChatUsecaseSuite) TestSaveMessage_UserBanned() {
+	ctx := context.Background()
+	userID := int64(1)
+
+	s.userClientMock.On("GetUserStatus", ctx, userID).Return(&entity.UserStatus{UserID: userID, Banned: true}, nil).Once()
+
+	savedMessage, err := s.usecase.SaveMessage(ctx, userID, "test-user", "test-message")
+
+	s.Nil(savedMessage)
+	s.ErrorIs(err, ErrUserBanned)
+	s.chatRepoMock.AssertNotCalled(s.T(), "SaveMessage", mock.Anything, mock.Anything)
+}
+
 func (s *PostUsecaseSuite) SetupTest() {
 	s.postRepoMock = mocks.NewPostRepository(s.T())
 	s.topicRepoMock = mocks.NewTopicRepository(s.T())
@@ -338,6 +455,7 @@ func (s *PostUsecaseSuite) TestCreatePost_Success() {
 	expectedPostID := int64(1)
 	topic := &entity.Topic{ID: post.TopicID, Title: "Existing Topic"}
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(topic, nil).Once()
 	s.postRepoMock.On("Create", ctx, post).Return(expectedPostID, nil).Once()
 
@@ -354,6 +472,7 @@ func (s *PostUsecaseSuite) TestCreatePost_TopicNotFound() {
 	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
 	expectedError := ErrTopicNotFound
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(nil, pgx.ErrNoRows).Once()
 
 	id, err := s.usecase.Create(ctx, post)
@@ -365,12 +484,59 @@ func (s *PostUsecaseSuite) TestCreatePost_TopicNotFound() {
 	s.postRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
+func (s *PostUsecaseSuite) TestCreatePost_TopicLocked() {
+	ctx := context.Background()
+	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
+	topic := &entity.Topic{ID: post.TopicID, Title: "Locked Topic", Locked: true}
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
+	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(topic, nil).Once()
+
+	id, err := s.usecase.Create(ctx, post)
+
+	s.Error(err)
+	s.Equal(int64(0), id)
+	s.ErrorIs(err, ErrTopicLocked)
+	s.postRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *PostUsecaseSuite) TestCreatePost_AuthorBanned() {
+	ctx := context.Background()
+	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID, Banned: true}, nil).Once()
+
+	id, err := s.usecase.Create(ctx, post)
+
+	s.Error(err)
+	s.Equal(int64(0), id)
+	s.ErrorIs(err, ErrUserBanned)
+	s.topicRepoMock.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+	s.postRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *PostUsecaseSuite) TestCreatePost_UserServiceUnavailable() {
+	ctx := context.Background()
+	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
+	unavailable := fmt.Errorf("clients.user - GetUserStatus: %w", client.ErrUnavailable)
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(nil, unavailable).Once()
+
+	id, err := s.usecase.Create(ctx, post)
+
+	s.Equal(int64(0), id)
+	s.ErrorIs(err, ErrUserServiceUnavailable)
+	s.ErrorIs(err, client.ErrUnavailable)
+	s.postRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
 /*
 func (s *PostUsecaseSuite) TestCreatePost_TopicRepoError_OtherThanNotFound() {
 	ctx := context.Background()
 	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
 	repoError := errors.New("some topic repo error")
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(nil, repoError).Once()
 
 	id, err := s.usecase.Create(ctx, post)
@@ -388,6 +554,7 @@ func (s *PostUsecaseSuite) TestCreatePost_RepoError() {
 	expectedError := errors.New("post repository create error")
 	topic := &entity.Topic{ID: post.TopicID, Title: "Existing Topic"}
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(topic, nil).Once()
 	s.postRepoMock.On("Create", ctx, post).Return(int64(0), expectedError).Once()
 
@@ -401,6 +568,20 @@ func (s *PostUsecaseSuite) TestCreatePost_RepoError() {
 	s.postRepoMock.AssertExpectations(s.T())
 }
 
+func (s *PostUsecaseSuite) TestCreatePost_AuthorNotReplicated() {
+	ctx := context.Background()
+	post := entity.Post{TopicID: 1, AuthorID: &s.defaultAuthorID, Content: "content"}
+	topic := &entity.Topic{ID: post.TopicID, Title: "Existing Topic"}
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
+	s.topicRepoMock.On("GetByID", ctx, post.TopicID).Return(topic, nil).Once()
+	s.postRepoMock.On("Create", ctx, post).Return(int64(0), repo.ErrUnknownAuthor).Once()
+
+	_, err := s.usecase.Create(ctx, post)
+
+	s.ErrorIs(err, ErrAuthorNotReplicated)
+}
+
 // GetByTopic
 func (s *PostUsecaseSuite) TestGetByTopic_Success() {
 	ctx := context.Background()
@@ -412,24 +593,24 @@ func (s *PostUsecaseSuite) TestGetByTopic_Success() {
 		{ID: 2, TopicID: topicID, AuthorID: &authorID2, Content: "Post 2", CreatedAt: time.Now()},
 		{ID: 3, TopicID: topicID, AuthorID: nil, Content: "Post 3 - Deleted User", CreatedAt: time.Now()},
 	}
-	usernamesFromClient := map[int64]string{
-		authorID1: "UserOne",
-		authorID2: "UserTwo",
+	profilesFromClient := map[int64]entity.UserProfile{
+		authorID1: {UserID: authorID1, Username: "UserOne", DisplayName: "One", Bio: "not embedded", Role: entity.RoleUser},
+		authorID2: {UserID: authorID2, Username: "UserTwo", Signature: "-- two", Role: entity.RoleModerator},
 	}
 	expectedPosts := []entity.Post{
-		{ID: 1, TopicID: topicID, AuthorID: &authorID1, Username: "UserOne", Content: "Post 1", CreatedAt: postsFromRepo[0].CreatedAt},
-		{ID: 2, TopicID: topicID, AuthorID: &authorID2, Username: "UserTwo", Content: "Post 2", CreatedAt: postsFromRepo[1].CreatedAt},
-		{ID: 3, TopicID: topicID, AuthorID: nil, Username: "Удаленный пользователь", Content: "Post 3 - Deleted User", CreatedAt: postsFromRepo[2].CreatedAt},
+		{ID: 1, TopicID: topicID, AuthorID: &authorID1, Author: &entity.Author{ID: authorID1, Username: "UserOne", DisplayName: "One", Role: entity.RoleUser}, Content: "Post 1", CreatedAt: postsFromRepo[0].CreatedAt},
+		{ID: 2, TopicID: topicID, AuthorID: &authorID2, Author: &entity.Author{ID: authorID2, Username: "UserTwo", Signature: "-- two", Role: entity.RoleModerator}, Content: "Post 2", CreatedAt: postsFromRepo[1].CreatedAt},
+		{ID: 3, TopicID: topicID, AuthorID: nil, Author: &entity.Author{Username: "Deleted user"}, Content: "Post 3 - Deleted User", CreatedAt: postsFromRepo[2].CreatedAt},
 	}
 	topic := &entity.Topic{ID: topicID, Title: "Existing Topic"}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topic, nil).Once()
 	s.postRepoMock.On("GetByTopic", ctx, topicID).Return(postsFromRepo, nil).Once()
-	s.userClientMock.On("GetUsernames", ctx, mock.MatchedBy(func(ids []int64) bool {
+	s.userClientMock.On("GetUserProfiles", ctx, mock.MatchedBy(func(ids []int64) bool {
 		return len(ids) == 2 && ((ids[0] == authorID1 && ids[1] == authorID2) || (ids[0] == authorID2 && ids[1] == authorID1))
-	})).Return(usernamesFromClient, nil).Once()
+	})).Return(profilesFromClient, nil).Once()
 
-	posts, err := s.usecase.GetByTopic(ctx, topicID)
+	posts, _, err := s.usecase.GetByTopic(ctx, topicID)
 
 	s.NoError(err)
 	s.NotNil(posts)
@@ -448,7 +629,7 @@ func (s *PostUsecaseSuite) TestGetByTopic_RepoError() {
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topic, nil).Once()
 	s.postRepoMock.On("GetByTopic", ctx, topicID).Return(nil, expectedError).Once()
 
-	posts, err := s.usecase.GetByTopic(ctx, topicID)
+	posts, _, err := s.usecase.GetByTopic(ctx, topicID)
 
 	s.Error(err)
 	s.Nil(posts)
@@ -456,7 +637,7 @@ func (s *PostUsecaseSuite) TestGetByTopic_RepoError() {
 	s.ErrorIs(err, expectedError)
 	s.topicRepoMock.AssertExpectations(s.T())
 	s.postRepoMock.AssertExpectations(s.T())
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsernames", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfiles", mock.Anything, mock.Anything)
 }
 
 func (s *PostUsecaseSuite) TestGetByTopic_UserClientError() {
@@ -471,14 +652,14 @@ func (s *PostUsecaseSuite) TestGetByTopic_UserClientError() {
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topic, nil).Once()
 	s.postRepoMock.On("GetByTopic", ctx, topicID).Return(postsFromRepo, nil).Once()
-	s.userClientMock.On("GetUsernames", ctx, []int64{authorID1}).Return(nil, expectedError).Once()
+	s.userClientMock.On("GetUserProfiles", ctx, []int64{authorID1}).Return(nil, expectedError).Once()
 
-	posts, err := s.usecase.GetByTopic(ctx, topicID)
+	posts, degraded, err := s.usecase.GetByTopic(ctx, topicID)
 
-	s.Error(err)
-	s.Nil(posts)                                                                                        // В текущей реализации возвращается nil при ошибке клиента
-	s.Contains(err.Error(), "ForumService - TopicUsecase  - GetByCategory - userClient.GetUsernames()") // Ошибка из TopicUsecase, т.к. логика похожа
-	s.ErrorIs(err, expectedError)
+	s.NoError(err)
+	s.True(degraded)
+	s.Require().Len(posts, 1)
+	s.Equal(&entity.Author{ID: authorID1, Username: "User #10"}, posts[0].Author)
 	s.topicRepoMock.AssertExpectations(s.T())
 	s.postRepoMock.AssertExpectations(s.T())
 	s.userClientMock.AssertExpectations(s.T())
@@ -491,14 +672,41 @@ func (s *PostUsecaseSuite) TestGetByTopic_CheckTopicError() {
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, pgx.ErrNoRows).Once() // Ошибка в checkTopic
 
-	posts, err := s.usecase.GetByTopic(ctx, topicID)
+	posts, _, err := s.usecase.GetByTopic(ctx, topicID)
 
 	s.Error(err)
 	s.Nil(posts)
 	s.ErrorIs(err, expectedError)
 	s.topicRepoMock.AssertExpectations(s.T())
 	s.postRepoMock.AssertNotCalled(s.T(), "GetByTopic", mock.Anything, mock.Anything)
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsernames", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfiles", mock.Anything, mock.Anything)
+}
+
+func (s *PostUsecaseSuite) TestGetByTopicVersion_Success() {
+	ctx := context.Background()
+	topicID := int64(1)
+	expected := entity.CollectionVersion{Version: 9, UpdatedAt: time.Now()}
+
+	s.postRepoMock.On("GetByTopicVersion", ctx, topicID).Return(expected, nil).Once()
+
+	version, err := s.usecase.GetByTopicVersion(ctx, topicID)
+
+	s.NoError(err)
+	s.Equal(expected, version)
+	s.postRepoMock.AssertNotCalled(s.T(), "GetByTopic", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfiles", mock.Anything, mock.Anything)
+}
+
+func (s *PostUsecaseSuite) TestGetByTopicVersion_TopicNotFound() {
+	ctx := context.Background()
+	topicID := int64(1)
+
+	s.postRepoMock.On("GetByTopicVersion", ctx, topicID).Return(entity.CollectionVersion{}, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.GetByTopicVersion(ctx, topicID)
+
+	s.ErrorIs(err, ErrTopicNotFound)
+	s.postRepoMock.AssertExpectations(s.T())
 }
 
 // Update
@@ -506,14 +714,14 @@ func (s *PostUsecaseSuite) TestUpdatePost_Success_Author() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	content := "updated content"
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID, Content: "old content"}
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
-	s.postRepoMock.On("Update", ctx, postID, content).Return(nil).Once()
+	s.postRepoMock.On("Update", ctx, postID, content, int64(2)).Return(int64(3), nil).Once()
 
-	err := s.usecase.Update(ctx, postID, userID, role, content)
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
 
 	s.NoError(err)
 	s.postRepoMock.AssertExpectations(s.T())
@@ -524,14 +732,14 @@ func (s *PostUsecaseSuite) TestUpdatePost_Success_Admin() {
 	postID := int64(1)
 	adminID := int64(999)
 	otherUserID := s.defaultAuthorID
-	role := "admin"
+	actor := entity.Actor{UserID: adminID, Role: "admin", Permissions: []string{entity.PermPostEditAny}}
 	content := "updated content by admin"
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &otherUserID, Content: "old content"}
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
-	s.postRepoMock.On("Update", ctx, postID, content).Return(nil).Once()
+	s.postRepoMock.On("Update", ctx, postID, content, int64(2)).Return(int64(3), nil).Once()
 
-	err := s.usecase.Update(ctx, postID, adminID, role, content)
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
 
 	s.NoError(err)
 	s.postRepoMock.AssertExpectations(s.T())
@@ -542,52 +750,88 @@ func (s *PostUsecaseSuite) TestUpdatePost_AccessDenied_NotAuthorNotAdmin() {
 	postID := int64(1)
 	anotherUserID := int64(555)
 	authorID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: anotherUserID, Role: "user"}
 	content := "updated content"
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &authorID, Content: "old content"}
 	expectedError := ErrForbidden
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
 
-	err := s.usecase.Update(ctx, postID, anotherUserID, role, content)
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
 	s.postRepoMock.AssertExpectations(s.T())
-	s.postRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.postRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PostUsecaseSuite) TestUpdatePost_Success_CategoryModerator() {
+	ctx := context.Background()
+	postID := int64(1)
+	topicID := int64(7)
+	categoryID := int64(3)
+	actor := entity.Actor{UserID: 555, Role: "user", ModeratedCategories: []int64{categoryID}}
+	content := "moderated content"
+	postFromRepo := &entity.Post{ID: postID, TopicID: topicID, AuthorID: &s.defaultAuthorID, Content: "old content"}
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(&entity.Topic{ID: topicID, CategoryID: categoryID}, nil).Once()
+	s.postRepoMock.On("Update", ctx, postID, content, int64(2)).Return(int64(3), nil).Once()
+
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
+
+	s.NoError(err)
+	s.postRepoMock.AssertExpectations(s.T())
+	s.topicRepoMock.AssertExpectations(s.T())
+}
+
+func (s *PostUsecaseSuite) TestUpdatePost_AccessDenied_ModeratorOfOtherCategory() {
+	ctx := context.Background()
+	postID := int64(1)
+	topicID := int64(7)
+	actor := entity.Actor{UserID: 555, Role: "user", ModeratedCategories: []int64{4}}
+	postFromRepo := &entity.Post{ID: postID, TopicID: topicID, AuthorID: &s.defaultAuthorID, Content: "old content"}
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(&entity.Topic{ID: topicID, CategoryID: 3}, nil).Once()
+
+	_, err := s.usecase.Update(ctx, postID, actor, "content", int64(2))
+
+	s.ErrorIs(err, ErrForbidden)
+	s.postRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *PostUsecaseSuite) TestUpdatePost_PostNotFound_OnCheckAccess() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	content := "updated content"
 	expectedError := ErrPostNotFound
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(nil, pgx.ErrNoRows).Once()
 
-	err := s.usecase.Update(ctx, postID, userID, role, content)
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
 	s.postRepoMock.AssertExpectations(s.T())
-	s.postRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.postRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *PostUsecaseSuite) TestUpdatePost_RepoUpdateError() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	content := "updated content"
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID, Content: "old content"}
 	repoError := errors.New("repo update error")
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
-	s.postRepoMock.On("Update", ctx, postID, content).Return(repoError).Once()
+	s.postRepoMock.On("Update", ctx, postID, content, int64(2)).Return(int64(0), repoError).Once()
 
-	err := s.usecase.Update(ctx, postID, userID, role, content)
+	_, err := s.usecase.Update(ctx, postID, actor, content, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, repoError)
@@ -595,18 +839,84 @@ func (s *PostUsecaseSuite) TestUpdatePost_RepoUpdateError() {
 	s.postRepoMock.AssertExpectations(s.T())
 }
 
+func (s *PostUsecaseSuite) TestUpdatePost_VersionConflict() {
+	ctx := context.Background()
+	postID := int64(1)
+	actor := entity.Actor{UserID: s.defaultAuthorID, Role: "user"}
+	current := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID, Content: "edited by a moderator", Version: 3}
+	profile := &entity.UserProfile{UserID: s.defaultAuthorID, Username: "TestUser"}
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(&entity.Post{ID: postID, AuthorID: &s.defaultAuthorID, Version: 3}, nil).Once()
+	s.postRepoMock.On("Update", ctx, postID, "my edit", int64(2)).Return(int64(0), pgx.ErrNoRows).Once()
+	s.postRepoMock.On("GetByID", ctx, postID).Return(current, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, s.defaultAuthorID).Return(profile, nil).Once()
+
+	_, err := s.usecase.Update(ctx, postID, actor, "my edit", int64(2))
+
+	s.ErrorIs(err, ErrVersionConflict)
+	var conflict *entity.Error
+	s.Require().True(errors.As(err, &conflict))
+	s.Equal(current, conflict.Details["current"])
+	s.Equal("TestUser", current.Author.Username)
+	s.postRepoMock.AssertExpectations(s.T())
+}
+
+// GetByID
+func (s *PostUsecaseSuite) TestGetByIDPost_Success() {
+	ctx := context.Background()
+	postID := int64(1)
+	postFromRepo := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID, Content: "hello", Version: 2}
+	profile := &entity.UserProfile{UserID: s.defaultAuthorID, Username: "TestUser"}
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, s.defaultAuthorID).Return(profile, nil).Once()
+
+	post, degraded, err := s.usecase.GetByID(ctx, postID)
+
+	s.NoError(err)
+	s.False(degraded)
+	s.Equal(int64(2), post.Version)
+	s.Equal(profile.Author(), post.Author)
+}
+
+func (s *PostUsecaseSuite) TestGetByIDPost_NotFound() {
+	ctx := context.Background()
+	postID := int64(1)
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(nil, pgx.ErrNoRows).Once()
+
+	post, _, err := s.usecase.GetByID(ctx, postID)
+
+	s.Nil(post)
+	s.ErrorIs(err, ErrPostNotFound)
+}
+
+func (s *PostUsecaseSuite) TestGetByIDPost_UserClientError() {
+	ctx := context.Background()
+	postID := int64(1)
+
+	s.postRepoMock.On("GetByID", ctx, postID).Return(&entity.Post{ID: postID, AuthorID: &s.defaultAuthorID}, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, s.defaultAuthorID).Return(nil, client.ErrUnavailable).Once()
+
+	post, degraded, err := s.usecase.GetByID(ctx, postID)
+
+	s.NoError(err)
+	s.True(degraded)
+	s.Equal(s.defaultAuthorID, post.Author.ID)
+}
+
 // Delete
 func (s *PostUsecaseSuite) TestDeletePost_Success_Author() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID}
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
 	s.postRepoMock.On("Delete", ctx, postID).Return(nil).Once()
 
-	err := s.usecase.Delete(ctx, postID, userID, role)
+	err := s.usecase.Delete(ctx, postID, actor)
 
 	s.NoError(err)
 	s.postRepoMock.AssertExpectations(s.T())
@@ -617,13 +927,13 @@ func (s *PostUsecaseSuite) TestDeletePost_Success_Admin() {
 	postID := int64(1)
 	adminID := int64(999)
 	otherUserID := s.defaultAuthorID
-	role := "admin"
+	actor := entity.Actor{UserID: adminID, Role: "admin", Permissions: []string{entity.PermPostDeleteAny}}
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &otherUserID}
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
 	s.postRepoMock.On("Delete", ctx, postID).Return(nil).Once()
 
-	err := s.usecase.Delete(ctx, postID, adminID, role)
+	err := s.usecase.Delete(ctx, postID, actor)
 
 	s.NoError(err)
 	s.postRepoMock.AssertExpectations(s.T())
@@ -634,13 +944,13 @@ func (s *PostUsecaseSuite) TestDeletePost_AccessDenied_NotAuthorNotAdmin() {
 	postID := int64(1)
 	anotherUserID := int64(555)
 	authorID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: anotherUserID, Role: "user"}
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &authorID}
 	expectedError := ErrForbidden
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(postFromRepo, nil).Once()
 
-	err := s.usecase.Delete(ctx, postID, anotherUserID, role)
+	err := s.usecase.Delete(ctx, postID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
@@ -652,12 +962,12 @@ func (s *PostUsecaseSuite) TestDeletePost_PostNotFound_OnCheckAccess() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	expectedError := ErrPostNotFound
 
 	s.postRepoMock.On("GetByID", ctx, postID).Return(nil, pgx.ErrNoRows).Once()
 
-	err := s.usecase.Delete(ctx, postID, userID, role)
+	err := s.usecase.Delete(ctx, postID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
@@ -669,7 +979,7 @@ func (s *PostUsecaseSuite) TestDeletePost_RepoError() {
 	ctx := context.Background()
 	postID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	postFromRepo := &entity.Post{ID: postID, AuthorID: &s.defaultAuthorID}
 	repoError := errors.New("repo delete error")
 
@@ -677,7 +987,7 @@ func (s *PostUsecaseSuite) TestDeletePost_RepoError() {
 
 	s.postRepoMock.On("Delete", ctx, postID).Return(repoError).Once()
 
-	err := s.usecase.Delete(ctx, postID, userID, role)
+	err := s.usecase.Delete(ctx, postID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, repoError)
@@ -708,6 +1018,7 @@ func (s *TopicUsecaseSuite) TestCreateTopic_Success() {
 	expectedTopicID := int64(1)
 	category := &entity.Category{ID: s.defaultCategoryID, Title: "Existing category"}
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.categoryRepoMock.On("GetByID", ctx, s.defaultCategoryID).Return(category, nil).Once()
 	s.topicRepoMock.On("Create", ctx, topic).Return(expectedTopicID, nil).Once()
 
@@ -724,6 +1035,7 @@ func (s *TopicUsecaseSuite) TestCreateTopic_CategoryNotFound() {
 	topic := entity.Topic{CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID, Title: "topic title"}
 	expectedError := ErrCategoryNotFound
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.categoryRepoMock.On("GetByID", ctx, s.defaultCategoryID).Return(nil, pgx.ErrNoRows).Once()
 
 	id, err := s.usecase.Create(ctx, topic)
@@ -735,12 +1047,28 @@ func (s *TopicUsecaseSuite) TestCreateTopic_CategoryNotFound() {
 	s.topicRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 }
 
+func (s *TopicUsecaseSuite) TestCreateTopic_AuthorBanned() {
+	ctx := context.Background()
+	topic := entity.Topic{CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID, Title: "topic title"}
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID, Banned: true}, nil).Once()
+
+	id, err := s.usecase.Create(ctx, topic)
+
+	s.Error(err)
+	s.Equal(int64(0), id)
+	s.ErrorIs(err, ErrUserBanned)
+	s.categoryRepoMock.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+	s.topicRepoMock.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
 /*
 func (s *TopicUsecaseSuite) TestCreateTopic_CategoryRepoError_OtherThanNotFound() {
 	ctx := context.Background()
 	topic := entity.Topic{CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID, Title: "topic title"}
 	repoError := errors.New("some category repo error")
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.categoryRepoMock.On("GetByID", ctx, s.defaultCategoryID).Return(nil, repoError).Once()
 
 	id, err := s.usecase.Create(ctx, topic)
@@ -758,6 +1086,7 @@ func (s *TopicUsecaseSuite) TestCreateTopic_RepoError() {
 	expectedError := errors.New("topic repository create error")
 	category := &entity.Category{ID: s.defaultCategoryID, Title: "Existing category"}
 
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
 	s.categoryRepoMock.On("GetByID", ctx, s.defaultCategoryID).Return(category, nil).Once()
 	s.topicRepoMock.On("Create", ctx, topic).Return(int64(0), expectedError).Once()
 
@@ -771,19 +1100,35 @@ func (s *TopicUsecaseSuite) TestCreateTopic_RepoError() {
 	s.topicRepoMock.AssertExpectations(s.T())
 }
 
+func (s *TopicUsecaseSuite) TestCreateTopic_AuthorNotReplicated() {
+	ctx := context.Background()
+	topic := entity.Topic{CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID, Title: "topic title"}
+	category := &entity.Category{ID: s.defaultCategoryID, Title: "Existing category"}
+
+	s.userClientMock.On("GetUserStatus", ctx, s.defaultAuthorID).Return(&entity.UserStatus{UserID: s.defaultAuthorID}, nil).Once()
+	s.categoryRepoMock.On("GetByID", ctx, s.defaultCategoryID).Return(category, nil).Once()
+	s.topicRepoMock.On("Create", ctx, topic).Return(int64(0), repo.ErrUnknownAuthor).Once()
+
+	_, err := s.usecase.Create(ctx, topic)
+
+	s.ErrorIs(err, ErrAuthorNotReplicated)
+}
+
 // GetByID
 func (s *TopicUsecaseSuite) TestGetByIDTopic_Success_WithAuthor() {
 	ctx := context.Background()
 	topicID := int64(1)
 	authorID := s.defaultAuthorID
-	expectedUsername := "TestUser"
+	joinedAt := time.Now().Add(-24 * time.Hour)
+	profile := &entity.UserProfile{UserID: authorID, Username: "TestUser", DisplayName: "Tester", Bio: "not embedded", AvatarURL: "https://example.com/a.png", Role: entity.RoleUser, JoinedAt: joinedAt}
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic", CategoryID: s.defaultCategoryID, CreatedAt: time.Now()}
-	expectedTopic := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic", CategoryID: s.defaultCategoryID, Username: expectedUsername, CreatedAt: topicFromRepo.CreatedAt}
+	expectedAuthor := &entity.Author{ID: authorID, Username: "TestUser", DisplayName: "Tester", AvatarURL: "https://example.com/a.png", Role: entity.RoleUser, JoinedAt: joinedAt}
+	expectedTopic := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic", CategoryID: s.defaultCategoryID, Author: expectedAuthor, CreatedAt: topicFromRepo.CreatedAt}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
-	s.userClientMock.On("GetUsername", ctx, authorID).Return(expectedUsername, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, authorID).Return(profile, nil).Once()
 
-	topic, err := s.usecase.GetByID(ctx, topicID)
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
 
 	s.NoError(err)
 	s.NotNil(topic)
@@ -796,17 +1141,17 @@ func (s *TopicUsecaseSuite) TestGetByIDTopic_Success_AuthorNil() {
 	ctx := context.Background()
 	topicID := int64(1)
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: nil, Title: "Test Topic", CategoryID: s.defaultCategoryID, CreatedAt: time.Now()}
-	expectedTopic := &entity.Topic{ID: topicID, AuthorID: nil, Title: "Test Topic", CategoryID: s.defaultCategoryID, Username: "Удаленный пользователь", CreatedAt: topicFromRepo.CreatedAt}
+	expectedTopic := &entity.Topic{ID: topicID, AuthorID: nil, Title: "Test Topic", CategoryID: s.defaultCategoryID, Author: &entity.Author{Username: "Deleted user"}, CreatedAt: topicFromRepo.CreatedAt}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 
-	topic, err := s.usecase.GetByID(ctx, topicID)
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
 
 	s.NoError(err)
 	s.NotNil(topic)
 	s.Equal(expectedTopic, topic)
 	s.topicRepoMock.AssertExpectations(s.T())
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsername", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfile", mock.Anything, mock.Anything)
 }
 
 func (s *TopicUsecaseSuite) TestGetByIDTopic_RepoError() {
@@ -816,14 +1161,44 @@ func (s *TopicUsecaseSuite) TestGetByIDTopic_RepoError() {
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, expectedError).Once()
 
-	topic, err := s.usecase.GetByID(ctx, topicID)
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
 
 	s.Error(err)
 	s.Nil(topic)
 	s.Contains(err.Error(), "ForumService - TopicUsecase - GetByID - repo.GetByID()")
 	s.ErrorIs(err, expectedError)
 	s.topicRepoMock.AssertExpectations(s.T())
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsername", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfile", mock.Anything, mock.Anything)
+}
+
+func (s *TopicUsecaseSuite) TestGetByIDTopic_AuthorDeleted() {
+	ctx := context.Background()
+	topicID := int64(1)
+	authorID := s.defaultAuthorID
+	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic"}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, authorID).Return(nil, client.ErrUserNotFound).Once()
+
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
+
+	s.NoError(err)
+	s.Equal(&entity.Author{Username: "Deleted user"}, topic.Author)
+}
+
+func (s *TopicUsecaseSuite) TestGetByIDTopic_AuthorDeletedInRussian() {
+	ctx := i18n.NewContext(context.Background(), i18n.Russian)
+	topicID := int64(1)
+	authorID := s.defaultAuthorID
+	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic"}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+	s.userClientMock.On("GetUserProfile", ctx, authorID).Return(nil, client.ErrUserNotFound).Once()
+
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
+
+	s.NoError(err)
+	s.Equal(&entity.Author{Username: "Удаленный пользователь"}, topic.Author)
 }
 
 func (s *TopicUsecaseSuite) TestGetByIDTopic_UserClientError() {
@@ -831,17 +1206,17 @@ func (s *TopicUsecaseSuite) TestGetByIDTopic_UserClientError() {
 	topicID := int64(1)
 	authorID := s.defaultAuthorID
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Test Topic"}
-	expectedError := errors.New("user client GetUsername error")
+	expectedError := errors.New("user client GetUserProfile error")
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
-	s.userClientMock.On("GetUsername", ctx, authorID).Return("", expectedError).Once()
+	s.userClientMock.On("GetUserProfile", ctx, authorID).Return(nil, expectedError).Once()
 
-	topic, err := s.usecase.GetByID(ctx, topicID)
+	topic, degraded, err := s.usecase.GetByID(ctx, topicID)
 
-	s.Error(err)
-	s.Nil(topic)
-	s.Contains(err.Error(), "ForumService - TopicUsecase - GetById - userClient.GetUsername()")
-	s.ErrorIs(err, expectedError)
+	s.NoError(err)
+	s.True(degraded)
+	s.Require().NotNil(topic)
+	s.Equal(&entity.Author{ID: authorID, Username: fmt.Sprintf("User #%d", authorID)}, topic.Author)
 	s.topicRepoMock.AssertExpectations(s.T())
 	s.userClientMock.AssertExpectations(s.T())
 }
@@ -857,25 +1232,25 @@ func (s *TopicUsecaseSuite) TestGetByCategory_Success() {
 		{ID: 2, CategoryID: categoryID, AuthorID: &authorID2, Title: "Topic 2", CreatedAt: time.Now()},
 		{ID: 3, CategoryID: categoryID, AuthorID: nil, Title: "Topic 3 - Deleted User", CreatedAt: time.Now()},
 	}
-	usernamesFromClient := map[int64]string{
-		authorID1: "UserOne",
-		authorID2: "UserTwo",
+	profilesFromClient := map[int64]entity.UserProfile{
+		authorID1: {UserID: authorID1, Username: "UserOne"},
+		authorID2: {UserID: authorID2, Username: "UserTwo"},
 	}
 	expectedTopics := []entity.Topic{
-		{ID: 1, CategoryID: categoryID, AuthorID: &authorID1, Username: "UserOne", Title: "Topic 1", CreatedAt: topicsFromRepo[0].CreatedAt},
-		{ID: 2, CategoryID: categoryID, AuthorID: &authorID2, Username: "UserTwo", Title: "Topic 2", CreatedAt: topicsFromRepo[1].CreatedAt},
-		{ID: 3, CategoryID: categoryID, AuthorID: nil, Username: "Удаленный пользователь", Title: "Topic 3 - Deleted User", CreatedAt: topicsFromRepo[2].CreatedAt},
+		{ID: 1, CategoryID: categoryID, AuthorID: &authorID1, Author: &entity.Author{ID: authorID1, Username: "UserOne"}, Title: "Topic 1", CreatedAt: topicsFromRepo[0].CreatedAt},
+		{ID: 2, CategoryID: categoryID, AuthorID: &authorID2, Author: &entity.Author{ID: authorID2, Username: "UserTwo"}, Title: "Topic 2", CreatedAt: topicsFromRepo[1].CreatedAt},
+		{ID: 3, CategoryID: categoryID, AuthorID: nil, Author: &entity.Author{Username: "Deleted user"}, Title: "Topic 3 - Deleted User", CreatedAt: topicsFromRepo[2].CreatedAt},
 	}
 	category := &entity.Category{ID: categoryID, Title: "Existing category"}
 
 	s.categoryRepoMock.On("GetByID", ctx, categoryID).Return(category, nil).Once()
 	s.topicRepoMock.On("GetByCategory", ctx, categoryID).Return(topicsFromRepo, nil).Once()
-	s.userClientMock.On("GetUsernames", ctx, mock.MatchedBy(func(ids []int64) bool {
+	s.userClientMock.On("GetUserProfiles", ctx, mock.MatchedBy(func(ids []int64) bool {
 		s.ElementsMatch([]int64{authorID1, authorID2}, ids)
 		return true
-	})).Return(usernamesFromClient, nil).Once()
+	})).Return(profilesFromClient, nil).Once()
 
-	topics, err := s.usecase.GetByCategory(ctx, categoryID)
+	topics, _, err := s.usecase.GetByCategory(ctx, categoryID)
 
 	s.NoError(err)
 	s.NotNil(topics)
@@ -892,14 +1267,14 @@ func (s *TopicUsecaseSuite) TestGetByCategory_CheckCategoryError_NotFound() {
 
 	s.categoryRepoMock.On("GetByID", ctx, categoryID).Return(nil, pgx.ErrNoRows).Once()
 
-	topics, err := s.usecase.GetByCategory(ctx, categoryID)
+	topics, _, err := s.usecase.GetByCategory(ctx, categoryID)
 
 	s.Error(err)
 	s.Nil(topics)
 	s.ErrorIs(err, expectedError)
 	s.categoryRepoMock.AssertExpectations(s.T())
 	s.topicRepoMock.AssertNotCalled(s.T(), "GetByCategory", mock.Anything, mock.Anything)
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsernames", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfiles", mock.Anything, mock.Anything)
 }
 
 func (s *TopicUsecaseSuite) TestGetByCategory_TopicRepoError() {
@@ -911,7 +1286,7 @@ func (s *TopicUsecaseSuite) TestGetByCategory_TopicRepoError() {
 	s.categoryRepoMock.On("GetByID", ctx, categoryID).Return(category, nil).Once()
 	s.topicRepoMock.On("GetByCategory", ctx, categoryID).Return(nil, expectedError).Once()
 
-	topics, err := s.usecase.GetByCategory(ctx, categoryID)
+	topics, _, err := s.usecase.GetByCategory(ctx, categoryID)
 
 	s.Error(err)
 	s.Nil(topics)
@@ -919,7 +1294,7 @@ func (s *TopicUsecaseSuite) TestGetByCategory_TopicRepoError() {
 	s.ErrorIs(err, expectedError)
 	s.categoryRepoMock.AssertExpectations(s.T())
 	s.topicRepoMock.AssertExpectations(s.T())
-	s.userClientMock.AssertNotCalled(s.T(), "GetUsernames", mock.Anything, mock.Anything)
+	s.userClientMock.AssertNotCalled(s.T(), "GetUserProfiles", mock.Anything, mock.Anything)
 }
 
 func (s *TopicUsecaseSuite) TestGetByCategory_UserClientError() {
@@ -929,19 +1304,19 @@ func (s *TopicUsecaseSuite) TestGetByCategory_UserClientError() {
 	topicsFromRepo := []entity.Topic{
 		{ID: 1, CategoryID: categoryID, AuthorID: &authorID1, Title: "Topic 1"},
 	}
-	expectedError := errors.New("user client GetUsernames error")
+	expectedError := errors.New("user client GetUserProfiles error")
 	category := &entity.Category{ID: categoryID, Title: "Existing category"}
 
 	s.categoryRepoMock.On("GetByID", ctx, categoryID).Return(category, nil).Once()
 	s.topicRepoMock.On("GetByCategory", ctx, categoryID).Return(topicsFromRepo, nil).Once()
-	s.userClientMock.On("GetUsernames", ctx, []int64{authorID1}).Return(nil, expectedError).Once()
+	s.userClientMock.On("GetUserProfiles", ctx, []int64{authorID1}).Return(nil, expectedError).Once()
 
-	topics, err := s.usecase.GetByCategory(ctx, categoryID)
+	topics, degraded, err := s.usecase.GetByCategory(ctx, categoryID)
 
-	s.Error(err)
-	s.Nil(topics)
-	s.Contains(err.Error(), "ForumService - TopicUsecase  - GetByCategory - userClient.GetUsernames()")
-	s.ErrorIs(err, expectedError)
+	s.NoError(err)
+	s.True(degraded)
+	s.Require().Len(topics, 1)
+	s.Equal(&entity.Author{ID: authorID1, Username: "User #10"}, topics[0].Author)
 	s.categoryRepoMock.AssertExpectations(s.T())
 	s.topicRepoMock.AssertExpectations(s.T())
 	s.userClientMock.AssertExpectations(s.T())
@@ -952,14 +1327,14 @@ func (s *TopicUsecaseSuite) TestUpdateTopic_Success_Author() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	title := "updated title"
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &s.defaultAuthorID, Title: "Old title"}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
-	s.topicRepoMock.On("Update", ctx, topicID, title).Return(nil).Once()
+	s.topicRepoMock.On("Update", ctx, topicID, title, int64(2)).Return(int64(3), nil).Once()
 
-	err := s.usecase.Update(ctx, topicID, userID, role, title)
+	_, err := s.usecase.Update(ctx, topicID, actor, title, int64(2))
 
 	s.NoError(err)
 	s.topicRepoMock.AssertExpectations(s.T())
@@ -970,14 +1345,14 @@ func (s *TopicUsecaseSuite) TestUpdateTopic_Success_Admin() {
 	topicID := int64(1)
 	adminID := int64(555)
 	authorID := s.defaultAuthorID
-	role := "admin"
+	actor := entity.Actor{UserID: adminID, Role: "admin", Permissions: []string{entity.PermTopicEditAny}}
 	title := "Updated by Admin"
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Old title"}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
-	s.topicRepoMock.On("Update", ctx, topicID, title).Return(nil).Once()
+	s.topicRepoMock.On("Update", ctx, topicID, title, int64(2)).Return(int64(3), nil).Once()
 
-	err := s.usecase.Update(ctx, topicID, adminID, role, title)
+	_, err := s.usecase.Update(ctx, topicID, actor, title, int64(2))
 
 	s.NoError(err)
 	s.topicRepoMock.AssertExpectations(s.T())
@@ -988,52 +1363,52 @@ func (s *TopicUsecaseSuite) TestUpdateTopic_AccessDenied_NotAuthorNotAdmin() {
 	topicID := int64(1)
 	nonAuthorID := int64(555)
 	authorID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: nonAuthorID, Role: "user"}
 	title := "Attempted Update"
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID, Title: "Old title"}
 	expectedError := ErrForbidden
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 
-	err := s.usecase.Update(ctx, topicID, nonAuthorID, role, title)
+	_, err := s.usecase.Update(ctx, topicID, actor, title, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
 	s.topicRepoMock.AssertCalled(s.T(), "GetByID", ctx, topicID)
-	s.topicRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.topicRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *TopicUsecaseSuite) TestUpdateTopic_TopicNotFound_OnCheckAccess() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	title := "updated title"
 	expectedError := ErrTopicNotFound
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, pgx.ErrNoRows).Once()
 
-	err := s.usecase.Update(ctx, topicID, userID, role, title)
+	_, err := s.usecase.Update(ctx, topicID, actor, title, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
 	s.topicRepoMock.AssertExpectations(s.T())
-	s.topicRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.topicRepoMock.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *TopicUsecaseSuite) TestUpdateTopic_RepoUpdateError() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	title := "updated title"
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &s.defaultAuthorID, Title: "Old title"}
 	repoError := errors.New("repo update error")
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
-	s.topicRepoMock.On("Update", ctx, topicID, title).Return(repoError).Once()
+	s.topicRepoMock.On("Update", ctx, topicID, title, int64(2)).Return(int64(0), repoError).Once()
 
-	err := s.usecase.Update(ctx, topicID, userID, role, title)
+	_, err := s.usecase.Update(ctx, topicID, actor, title, int64(2))
 
 	s.Error(err)
 	s.ErrorIs(err, repoError)
@@ -1041,18 +1416,49 @@ func (s *TopicUsecaseSuite) TestUpdateTopic_RepoUpdateError() {
 	s.topicRepoMock.AssertExpectations(s.T())
 }
 
+func (s *TopicUsecaseSuite) TestUpdateTopic_VersionConflict() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: s.defaultAuthorID, Role: "user"}
+	current := &entity.Topic{ID: topicID, Title: "Renamed by a moderator", Version: 4}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(&entity.Topic{ID: topicID, AuthorID: &s.defaultAuthorID, Version: 4}, nil).Once()
+	s.topicRepoMock.On("Update", ctx, topicID, "my title", int64(2)).Return(int64(0), pgx.ErrNoRows).Once()
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(current, nil).Once()
+
+	_, err := s.usecase.Update(ctx, topicID, actor, "my title", int64(2))
+
+	s.ErrorIs(err, ErrVersionConflict)
+	var conflict *entity.Error
+	s.Require().True(errors.As(err, &conflict))
+	s.Equal(current, conflict.Details["current"])
+	s.topicRepoMock.AssertExpectations(s.T())
+}
+
+func (s *TopicUsecaseSuite) TestGetByIDTopic_NotFound() {
+	ctx := context.Background()
+	topicID := int64(1)
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, pgx.ErrNoRows).Once()
+
+	topic, _, err := s.usecase.GetByID(ctx, topicID)
+
+	s.Nil(topic)
+	s.ErrorIs(err, ErrTopicNotFound)
+}
+
 // Delete
 func (s *TopicUsecaseSuite) TestDeleteTopic_Success_Author() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &s.defaultAuthorID}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 	s.topicRepoMock.On("Delete", ctx, topicID).Return(nil).Once()
 
-	err := s.usecase.Delete(ctx, topicID, userID, role)
+	err := s.usecase.Delete(ctx, topicID, actor)
 
 	s.NoError(err)
 	s.topicRepoMock.AssertExpectations(s.T())
@@ -1063,13 +1469,13 @@ func (s *TopicUsecaseSuite) TestDeleteTopic_Success_Admin() {
 	topicID := int64(1)
 	adminID := int64(999)
 	authorID := s.defaultAuthorID
-	role := "admin"
+	actor := entity.Actor{UserID: adminID, Role: "admin", Permissions: []string{entity.PermTopicDeleteAny}}
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID}
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 	s.topicRepoMock.On("Delete", ctx, topicID).Return(nil).Once()
 
-	err := s.usecase.Delete(ctx, topicID, adminID, role)
+	err := s.usecase.Delete(ctx, topicID, actor)
 
 	s.NoError(err)
 	s.topicRepoMock.AssertExpectations(s.T())
@@ -1080,13 +1486,13 @@ func (s *TopicUsecaseSuite) TestDeleteTopic_AccessDenied_NotAuthorNotAdmin() {
 	topicID := int64(1)
 	nonAuthorID := int64(555)
 	authorID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: nonAuthorID, Role: "user"}
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &authorID}
 	expectedError := ErrForbidden
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 
-	err := s.usecase.Delete(ctx, topicID, nonAuthorID, role)
+	err := s.usecase.Delete(ctx, topicID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
@@ -1098,12 +1504,12 @@ func (s *TopicUsecaseSuite) TestDeleteTopic_TopicNotFound_OnCheckAccess() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	expectedError := ErrTopicNotFound
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, pgx.ErrNoRows).Once()
 
-	err := s.usecase.Delete(ctx, topicID, userID, role)
+	err := s.usecase.Delete(ctx, topicID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, expectedError)
@@ -1115,17 +1521,105 @@ func (s *TopicUsecaseSuite) TestDeleteTopic_RepoDeleteError() {
 	ctx := context.Background()
 	topicID := int64(1)
 	userID := s.defaultAuthorID
-	role := "user"
+	actor := entity.Actor{UserID: userID, Role: "user"}
 	topicFromRepo := &entity.Topic{ID: topicID, AuthorID: &s.defaultAuthorID}
 	repoError := errors.New("repo delete error")
 
 	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
 	s.topicRepoMock.On("Delete", ctx, topicID).Return(repoError).Once()
 
-	err := s.usecase.Delete(ctx, topicID, userID, role)
+	err := s.usecase.Delete(ctx, topicID, actor)
 
 	s.Error(err)
 	s.ErrorIs(err, repoError)
 	s.Contains(err.Error(), "ForumService - TopicUsecase - Delete - topicRepo.Delete()")
 	s.topicRepoMock.AssertExpectations(s.T())
 }
+
+func (s *TopicUsecaseSuite) TestDeleteTopic_Success_CategoryModerator() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: 555, Role: "user", ModeratedCategories: []int64{s.defaultCategoryID}}
+	topicFromRepo := &entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+	s.topicRepoMock.On("Delete", ctx, topicID).Return(nil).Once()
+
+	err := s.usecase.Delete(ctx, topicID, actor)
+
+	s.NoError(err)
+	s.topicRepoMock.AssertExpectations(s.T())
+}
+
+func (s *TopicUsecaseSuite) TestDeleteTopic_AccessDenied_ModeratorOfOtherCategory() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: 555, Role: "user", ModeratedCategories: []int64{s.defaultCategoryID + 1}}
+	topicFromRepo := &entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+
+	err := s.usecase.Delete(ctx, topicID, actor)
+
+	s.ErrorIs(err, ErrForbidden)
+	s.topicRepoMock.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+// SetLocked
+func (s *TopicUsecaseSuite) TestSetLocked_Success_CategoryModerator() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: 555, Role: "user", ModeratedCategories: []int64{s.defaultCategoryID}}
+	topicFromRepo := &entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+	s.topicRepoMock.On("SetLocked", ctx, topicID, true).Return(nil).Once()
+
+	err := s.usecase.SetLocked(ctx, topicID, actor, true)
+
+	s.NoError(err)
+	s.topicRepoMock.AssertExpectations(s.T())
+}
+
+func (s *TopicUsecaseSuite) TestSetLocked_AccessDenied_Author() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: s.defaultAuthorID, Role: "user"}
+	topicFromRepo := &entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID, AuthorID: &s.defaultAuthorID}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(topicFromRepo, nil).Once()
+
+	err := s.usecase.SetLocked(ctx, topicID, actor, true)
+
+	s.ErrorIs(err, ErrForbidden)
+	s.topicRepoMock.AssertNotCalled(s.T(), "SetLocked", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TopicUsecaseSuite) TestSetLocked_InvalidatesCache() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: 1, Role: "admin", Permissions: []string{entity.PermTopicLock}}
+	topicRepo := repo.NewCachedTopicRepository(s.topicRepoMock, repo.CacheConfig{Size: 10, TTL: time.Minute})
+	uc := NewTopicUsecase(topicRepo, s.categoryRepoMock, s.userClientMock, s.log)
+
+	s.topicRepoMock.On("GetByID", mock.Anything, topicID).Return(&entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID}, nil).Once()
+	s.topicRepoMock.On("SetLocked", ctx, topicID, true).Return(nil).Once()
+	s.Require().NoError(uc.SetLocked(ctx, topicID, actor, true))
+
+	s.topicRepoMock.On("GetByID", mock.Anything, topicID).Return(&entity.Topic{ID: topicID, CategoryID: s.defaultCategoryID, Locked: true}, nil).Once()
+	topic, err := topicRepo.GetByID(ctx, topicID)
+	s.NoError(err)
+	s.True(topic.Locked)
+}
+
+func (s *TopicUsecaseSuite) TestSetLocked_TopicNotFound() {
+	ctx := context.Background()
+	topicID := int64(1)
+	actor := entity.Actor{UserID: 1, Role: "admin", Permissions: []string{entity.PermTopicLock}}
+
+	s.topicRepoMock.On("GetByID", ctx, topicID).Return(nil, pgx.ErrNoRows).Once()
+
+	err := s.usecase.SetLocked(ctx, topicID, actor, false)
+
+	s.ErrorIs(err, ErrTopicNotFound)
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Van-programan/Forum_GO/internal/controller/response"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/oidc"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+type OIDCUsecase interface {
+	// AuthURL also returns the nonce the browser must keep, in a cookie, and send back to the callback.
+	AuthURL(ctx context.Context, provider string, linkUserID int64) (authURL, browserNonce string, err error)
+	// Callback completes a login, or a link, which leaves the session of the linking user as it is and
+	// returns only Linked set.
+	Callback(ctx context.Context, provider, code, state, browserNonce string) (*response.LoginResponse, error)
+	Identities(ctx context.Context, userID int64) ([]entity.UserIdentity, error)
+	Unlink(ctx context.Context, userID int64, provider string) error
+}
+
+type oidcUsecase struct {
+	providers    map[string]*oidc.Provider
+	identityRepo repo.IdentityRepository
+	userRepo     repo.UserRepository
+	sessions     *sessionIssuer
+	log          *zerolog.Logger
+}
+
+var (
+	ErrUnknownProvider       = entity.NewError(entity.KindNotFound, "unknown_provider", "unknown oidc provider")
+	ErrInvalidOIDCState      = entity.NewError(entity.KindInvalid, "invalid_oidc_state", "invalid or expired oidc state")
+	ErrIdentityLinked        = entity.NewError(entity.KindConflict, "identity_linked", "identity is linked to another account")
+	ErrProviderAlreadyLinked = entity.NewError(entity.KindConflict, "provider_already_linked", "provider is already linked to this account")
+	ErrIdentityNotFound      = entity.NewError(entity.KindNotFound, "identity_not_found", "identity not found")
+	ErrLastLoginMethod       = entity.NewError(entity.KindConflict, "last_login_method", "cannot unlink the only sign-in method")
+)
+
+const (
+	// OIDCStateTTL is how long an authorization request may take.
+	OIDCStateTTL        = 10 * time.Minute
+	maxUsernameAttempts = 5
+	maxUsernameLength   = 40
+)
+
+const (
+	authURLOp    = "OIDCUsecase.AuthURL"
+	callbackOp   = "OIDCUsecase.Callback"
+	identitiesOp = "OIDCUsecase.Identities"
+	unlinkOp     = "OIDCUsecase.Unlink"
+)
+
+// NewOIDCUsecase creates the OIDC login usecase. providers is keyed by the name used in the /oidc/:provider routes.
+func NewOIDCUsecase(providers map[string]*oidc.Provider, identityRepo repo.IdentityRepository, userRepo repo.UserRepository, tokenRepo repo.RefreshTokenRepository, mfaRepo repo.MFARepository, roleRepo repo.RoleRepository, banRepo repo.BanRepository, jwt *jwt.JWT, log *zerolog.Logger) OIDCUsecase {
+	return &oidcUsecase{
+		providers:    providers,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		sessions:     &sessionIssuer{jwt: jwt, userRepo: userRepo, tokenRepo: tokenRepo, mfaRepo: mfaRepo, roleRepo: roleRepo, banRepo: banRepo},
+		log:          log,
+	}
+}
+
+// AuthURL starts an authorization code flow. A non-zero linkUserID links the external account to that user instead of logging in.
+// The state is bound to the browser nonce, so a callback sent from another browser, one an attacker
+// lured into completing their flow, is rejected.
+func (u *oidcUsecase) AuthURL(ctx context.Context, provider string, linkUserID int64) (string, string, error) {
+	log := u.log.With().Str("op", authURLOp).Str("provider", provider).Logger()
+
+	p, ok := u.providers[provider]
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+
+	if linkUserID != 0 {
+		identities, err := u.identityRepo.GetByUserID(ctx, linkUserID)
+		if err != nil {
+			log.Error().Err(err).Int64("user_id", linkUserID).Msg("Failed to get identities")
+			return "", "", fmt.Errorf("failed to get identities: %w", err)
+		}
+		for _, identity := range identities {
+			if identity.Provider == provider {
+				return "", "", ErrProviderAlreadyLinked
+			}
+		}
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	browserNonce, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = u.identityRepo.SaveState(ctx, &entity.OIDCState{
+		State:        state,
+		Provider:     provider,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		BrowserHash:  hashBrowserNonce(browserNonce),
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(OIDCStateTTL),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save oidc state")
+		return "", "", fmt.Errorf("failed to save oidc state: %w", err)
+	}
+
+	return p.AuthCodeURL(state, nonce, oidc.S256Challenge(verifier)), browserNonce, nil
+}
+
+func (u *oidcUsecase) Callback(ctx context.Context, provider, code, state, browserNonce string) (*response.LoginResponse, error) {
+	log := u.log.With().Str("op", callbackOp).Str("provider", provider).Logger()
+
+	p, ok := u.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	st, err := u.identityRepo.ConsumeState(ctx, state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidOIDCState
+		}
+		log.Error().Err(err).Msg("Failed to consume oidc state")
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+	if st.Provider != provider || time.Now().After(st.ExpiresAt) {
+		return nil, ErrInvalidOIDCState
+	}
+	if subtle.ConstantTimeCompare([]byte(hashBrowserNonce(browserNonce)), []byte(st.BrowserHash)) != 1 {
+		log.Warn().Msg("OIDC state used from another browser")
+		return nil, ErrInvalidOIDCState
+	}
+
+	token, err := p.Exchange(ctx, code, st.CodeVerifier)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to exchange authorization code")
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, st.Nonce)
+	if err != nil {
+		log.Warn().Err(err).Msg("ID token rejected")
+		return nil, err
+	}
+	log = log.With().Str("subject", claims.Subject).Logger()
+
+	identity := &entity.UserIdentity{Provider: provider, Subject: claims.Subject, Email: claims.Email}
+
+	userID, err := u.identityRepo.GetUserID(ctx, provider, claims.Subject)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).Msg("Failed to look up identity")
+		return nil, fmt.Errorf("failed to look up identity: %w", err)
+	}
+	known := err == nil
+
+	if st.LinkUserID != 0 {
+		if known && userID != st.LinkUserID {
+			log.Warn().Int64("user_id", st.LinkUserID).Int64("owner_id", userID).Msg("Identity already linked to another user")
+			return nil, ErrIdentityLinked
+		}
+		if !known {
+			identity.UserID = st.LinkUserID
+			if err := u.identityRepo.Create(ctx, identity); err != nil {
+				log.Error().Err(err).Int64("user_id", st.LinkUserID).Msg("Failed to link identity")
+				return nil, fmt.Errorf("failed to link identity: %w", err)
+			}
+			log.Info().Int64("user_id", st.LinkUserID).Msg("Identity linked")
+		}
+
+		// The linking user is already signed in, so the link is only confirmed and no session is issued.
+		return &response.LoginResponse{Linked: true}, nil
+	}
+
+	if !known {
+		userID, err = u.createUser(ctx, claims, identity)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to create user for identity")
+			return nil, err
+		}
+		log.Info().Int64("user_id", userID).Msg("User registered via oidc")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", userID).Msg("Failed to get user by ID")
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	res, err := u.sessions.start(ctx, user)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to start session")
+		return nil, err
+	}
+
+	log.Info().Int64("user_id", user.ID).Bool("mfa_required", res.MFARequired).Msg("User logged in via oidc")
+	return res, nil
+}
+
+func (u *oidcUsecase) Identities(ctx context.Context, userID int64) ([]entity.UserIdentity, error) {
+	identities, err := u.identityRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", identitiesOp).Int64("user_id", userID).Msg("Failed to get identities")
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+	return identities, nil
+}
+
+// Unlink removes an external identity. It refuses to remove the last one from an account that has no password.
+func (u *oidcUsecase) Unlink(ctx context.Context, userID int64, provider string) error {
+	log := u.log.With().Str("op", unlinkOp).Int64("user_id", userID).Str("provider", provider).Logger()
+
+	identities, err := u.identityRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get identities")
+		return fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	linked := false
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return ErrIdentityNotFound
+	}
+
+	if len(identities) == 1 {
+		hash, err := u.userRepo.GetPasswordHash(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get password hash")
+			return fmt.Errorf("failed to get password hash: %w", err)
+		}
+		if hash == "" {
+			return ErrLastLoginMethod
+		}
+	}
+
+	if err := u.identityRepo.Delete(ctx, userID, provider); err != nil {
+		log.Error().Err(err).Msg("Failed to unlink identity")
+		return fmt.Errorf("failed to unlink identity: %w", err)
+	}
+
+	log.Info().Msg("Identity unlinked")
+	return nil
+}
+
+// createUser registers a passwordless user for a new identity, adding a numeric suffix when the preferred username is taken.
+func (u *oidcUsecase) createUser(ctx context.Context, claims *oidc.Claims, identity *entity.UserIdentity) (int64, error) {
+	base := usernameFromClaims(claims)
+	username := base
+
+	for i := 0; i < maxUsernameAttempts; i++ {
+		id, err := u.identityRepo.CreateWithUser(ctx, username, identity)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, repo.ErrUsernameTaken) {
+			return 0, fmt.Errorf("failed to create user: %w", err)
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate username suffix: %w", err)
+		}
+		username = fmt.Sprintf("%s_%04d", base, n.Int64())
+	}
+
+	return 0, fmt.Errorf("failed to create user: %w", repo.ErrUsernameTaken)
+}
+
+func usernameFromClaims(claims *oidc.Claims) string {
+	candidates := []string{claims.PreferredUsername, strings.Split(claims.Email, "@")[0], claims.Name}
+	for _, candidate := range candidates {
+		if username := sanitizeUsername(candidate); username != "" {
+			return username
+		}
+	}
+	return "user"
+}
+
+func sanitizeUsername(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_', r == '.', r == '-':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('_')
+		}
+	}
+
+	username := []rune(b.String())
+	if len(username) > maxUsernameLength {
+		username = username[:maxUsernameLength]
+	}
+	return string(username)
+}
+
+func hashBrowserNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
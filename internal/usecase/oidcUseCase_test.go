@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
+	"github.com/Van-programan/Forum_GO/pkg/jwt"
+	"github.com/Van-programan/Forum_GO/pkg/oidc"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testProvider = "mock"
+	testClientID = "forum-client"
+)
+
+// mockIdP is a minimal OpenID provider: discovery, JWKS and a token endpoint that checks PKCE.
+type mockIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu      sync.Mutex
+	subject string
+	email   string
+	codes   map[string]url.Values
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &mockIdP{key: key, subject: "subject-1", email: "alice@example.com", codes: map[string]url.Values{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		idp.mu.Lock()
+		authReq, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		subject, email := idp.subject, idp.email
+		idp.mu.Unlock()
+
+		if !ok || oidc.S256Challenge(r.PostForm.Get("code_verifier")) != authReq.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken := idp.sign(t, gojwt.MapClaims{
+			"iss":   idp.server.URL,
+			"aud":   testClientID,
+			"sub":   subject,
+			"email": email,
+			"nonce": authReq.Get("nonce"),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"iat":   time.Now().Unix(),
+		})
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "at", "token_type": "Bearer", "id_token": idToken})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// authorize plays the user approving the request at authURL and returns the issued code.
+func (idp *mockIdP) authorize(t *testing.T, authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code := "code-" + u.Query().Get("state")
+	idp.codes[code] = u.Query()
+	return code
+}
+
+func (idp *mockIdP) sign(t *testing.T, claims gojwt.MapClaims) string {
+	token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+type OIDCUsecaseSuite struct {
+	suite.Suite
+	usecase      OIDCUsecase
+	idp          *mockIdP
+	identityRepo *mocks.IdentityRepository
+	userRepo     *mocks.UserRepository
+	tokenRepo    *mocks.RefreshTokenRepository
+	mfaRepo      *mocks.MFARepository
+	roleRepo     *mocks.RoleRepository
+	banRepo      *mocks.BanRepository
+	states       map[string]*entity.OIDCState
+	browserNonce string
+}
+
+func (s *OIDCUsecaseSuite) SetupTest() {
+	s.idp = newMockIdP(s.T())
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Issuer:      s.idp.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:3100/oidc/mock/callback",
+		Scopes:      []string{"openid", "email"},
+	})
+	s.Require().NoError(err)
+
+	s.identityRepo = mocks.NewIdentityRepository(s.T())
+	s.userRepo = mocks.NewUserRepository(s.T())
+	s.tokenRepo = mocks.NewRefreshTokenRepository(s.T())
+	s.mfaRepo = mocks.NewMFARepository(s.T())
+	s.roleRepo = mocks.NewRoleRepository(s.T())
+	s.banRepo = mocks.NewBanRepository(s.T())
+	s.states = map[string]*entity.OIDCState{}
+	logger := zerolog.Nop()
+	s.usecase = NewOIDCUsecase(map[string]*oidc.Provider{testProvider: provider}, s.identityRepo, s.userRepo, s.tokenRepo, s.mfaRepo, s.roleRepo, s.banRepo,
+		jwt.New("secret", time.Minute, 10*time.Minute), &logger)
+}
+
+func TestOIDCUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(OIDCUsecaseSuite))
+}
+
+// startFlow runs AuthURL with an in-memory state store and returns the state and the code the IdP issued.
+// The nonce the browser keeps is left in s.browserNonce.
+func (s *OIDCUsecaseSuite) startFlow(ctx context.Context, linkUserID int64) (string, string) {
+	s.identityRepo.On("SaveState", ctx, mock.AnythingOfType("*entity.OIDCState")).Run(func(args mock.Arguments) {
+		st := args.Get(1).(*entity.OIDCState)
+		s.states[st.State] = st
+	}).Return(nil).Once()
+
+	authURL, browserNonce, err := s.usecase.AuthURL(ctx, testProvider, linkUserID)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(browserNonce)
+	s.browserNonce = browserNonce
+
+	u, _ := url.Parse(authURL)
+	state := u.Query().Get("state")
+	s.Require().Contains(s.states, state)
+	s.Equal("S256", u.Query().Get("code_challenge_method"))
+	s.Equal(testClientID, u.Query().Get("client_id"))
+
+	s.identityRepo.On("ConsumeState", ctx, state).Return(s.states[state], nil).Once()
+	return state, s.idp.authorize(s.T(), authURL)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_ExistingIdentity() {
+	ctx := context.Background()
+	user := &entity.User{ID: 7, Username: "alice", Role: "user"}
+
+	state, code := s.startFlow(ctx, 0)
+	s.identityRepo.On("GetUserID", ctx, testProvider, "subject-1").Return(user.ID, nil).Once()
+	s.userRepo.On("GetByID", ctx, user.ID).Return(user, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, user.ID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, user.ID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, user.ID, user.Role).Return([]string{}, []int64{}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), user.ID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, user.ID).Return(nil).Once()
+
+	res, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.NoError(err)
+	s.Equal(user.ID, res.User.ID)
+	s.NotEmpty(res.Tokens.AccessToken)
+	s.NotEmpty(res.Tokens.RefreshToken)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_NewUser_UsernameTaken() {
+	ctx := context.Background()
+	user := &entity.User{ID: 8, Username: "alice_0042", Role: "user"}
+
+	state, code := s.startFlow(ctx, 0)
+	s.identityRepo.On("GetUserID", ctx, testProvider, "subject-1").Return(int64(0), pgx.ErrNoRows).Once()
+	s.identityRepo.On("CreateWithUser", ctx, "alice", mock.MatchedBy(func(i *entity.UserIdentity) bool {
+		return i.Provider == testProvider && i.Subject == "subject-1" && i.Email == "alice@example.com"
+	})).Return(int64(0), repo.ErrUsernameTaken).Once()
+	s.identityRepo.On("CreateWithUser", ctx, mock.MatchedBy(func(username string) bool {
+		return len(username) == len("alice_0000") && username[:6] == "alice_"
+	}), mock.AnythingOfType("*entity.UserIdentity")).Return(user.ID, nil).Once()
+	s.userRepo.On("GetByID", ctx, user.ID).Return(user, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, user.ID).Return("", false, pgx.ErrNoRows).Once()
+	s.banRepo.On("GetActive", ctx, user.ID).Return(nil, pgx.ErrNoRows).Once()
+	s.roleRepo.On("GetAccess", ctx, user.ID, user.Role).Return([]string{}, []int64{}, nil).Once()
+	s.tokenRepo.On("Save", ctx, mock.AnythingOfType("string"), user.ID).Return(nil).Once()
+	s.userRepo.On("UpdateLastLogin", ctx, user.ID).Return(nil).Once()
+
+	res, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.NoError(err)
+	s.Equal(user.ID, res.User.ID)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_MFARequired() {
+	ctx := context.Background()
+	user := &entity.User{ID: 7, Username: "alice", Role: "user"}
+
+	state, code := s.startFlow(ctx, 0)
+	s.identityRepo.On("GetUserID", ctx, testProvider, "subject-1").Return(user.ID, nil).Once()
+	s.userRepo.On("GetByID", ctx, user.ID).Return(user, nil).Once()
+	s.mfaRepo.On("GetSecret", ctx, user.ID).Return("SECRET", true, nil).Once()
+	s.banRepo.On("GetActive", ctx, user.ID).Return(nil, pgx.ErrNoRows).Once()
+	s.mfaRepo.On("SaveChallenge", ctx, mock.AnythingOfType("string"), user.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	res, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.NoError(err)
+	s.True(res.MFARequired)
+	s.NotEmpty(res.MFAToken)
+	s.Empty(res.Tokens.AccessToken)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_Link() {
+	ctx := context.Background()
+	user := &entity.User{ID: 3, Username: "bob", Role: "user"}
+
+	s.identityRepo.On("GetByUserID", ctx, user.ID).Return([]entity.UserIdentity{}, nil).Once()
+	state, code := s.startFlow(ctx, user.ID)
+	s.identityRepo.On("GetUserID", ctx, testProvider, "subject-1").Return(int64(0), pgx.ErrNoRows).Once()
+	s.identityRepo.On("Create", ctx, mock.MatchedBy(func(i *entity.UserIdentity) bool {
+		return i.UserID == user.ID && i.Subject == "subject-1"
+	})).Return(nil).Once()
+
+	res, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.NoError(err)
+	s.True(res.Linked)
+	s.Empty(res.Tokens.AccessToken)
+	s.Empty(res.Tokens.RefreshToken)
+	s.tokenRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_Link_IdentityOwnedByAnotherUser() {
+	ctx := context.Background()
+
+	s.identityRepo.On("GetByUserID", ctx, int64(3)).Return([]entity.UserIdentity{}, nil).Once()
+	state, code := s.startFlow(ctx, 3)
+	s.identityRepo.On("GetUserID", ctx, testProvider, "subject-1").Return(int64(9), nil).Once()
+
+	_, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.ErrorIs(err, ErrIdentityLinked)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_WrongVerifier() {
+	ctx := context.Background()
+
+	state, _ := s.startFlow(ctx, 0)
+	s.states[state].CodeVerifier = "tampered"
+
+	_, err := s.usecase.Callback(ctx, testProvider, "code-"+state, state, s.browserNonce)
+
+	s.Error(err)
+	s.Contains(err.Error(), "invalid_grant")
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_NonceMismatch() {
+	ctx := context.Background()
+
+	state, code := s.startFlow(ctx, 0)
+	s.states[state].Nonce = "other-nonce"
+
+	_, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.ErrorIs(err, oidc.ErrNonceMismatch)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_OtherBrowser() {
+	ctx := context.Background()
+
+	state, code := s.startFlow(ctx, 0)
+	_, err := s.usecase.Callback(ctx, testProvider, code, state, "other-browser")
+	s.ErrorIs(err, ErrInvalidOIDCState)
+
+	// A browser without the cookie is turned away too.
+	s.identityRepo.On("ConsumeState", ctx, state).Return(s.states[state], nil).Once()
+	_, err = s.usecase.Callback(ctx, testProvider, code, state, "")
+	s.ErrorIs(err, ErrInvalidOIDCState)
+	s.identityRepo.AssertNotCalled(s.T(), "GetUserID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_UnknownState() {
+	ctx := context.Background()
+	s.identityRepo.On("ConsumeState", ctx, "nope").Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.Callback(ctx, testProvider, "code", "nope", "browser")
+
+	s.ErrorIs(err, ErrInvalidOIDCState)
+}
+
+func (s *OIDCUsecaseSuite) TestCallback_ExpiredState() {
+	ctx := context.Background()
+
+	state, code := s.startFlow(ctx, 0)
+	s.states[state].ExpiresAt = time.Now().Add(-time.Second)
+
+	_, err := s.usecase.Callback(ctx, testProvider, code, state, s.browserNonce)
+
+	s.ErrorIs(err, ErrInvalidOIDCState)
+}
+
+func (s *OIDCUsecaseSuite) TestAuthURL_UnknownProvider() {
+	_, _, err := s.usecase.AuthURL(context.Background(), "other", 0)
+
+	s.ErrorIs(err, ErrUnknownProvider)
+}
+
+func (s *OIDCUsecaseSuite) TestAuthURL_ProviderAlreadyLinked() {
+	ctx := context.Background()
+	s.identityRepo.On("GetByUserID", ctx, int64(3)).Return([]entity.UserIdentity{{UserID: 3, Provider: testProvider}}, nil).Once()
+
+	_, _, err := s.usecase.AuthURL(ctx, testProvider, 3)
+
+	s.ErrorIs(err, ErrProviderAlreadyLinked)
+}
+
+func (s *OIDCUsecaseSuite) TestUnlink_LastLoginMethod() {
+	ctx := context.Background()
+	s.identityRepo.On("GetByUserID", ctx, int64(3)).Return([]entity.UserIdentity{{UserID: 3, Provider: testProvider}}, nil).Once()
+	s.userRepo.On("GetPasswordHash", ctx, int64(3)).Return("", nil).Once()
+
+	err := s.usecase.Unlink(ctx, 3, testProvider)
+
+	s.ErrorIs(err, ErrLastLoginMethod)
+}
+
+func (s *OIDCUsecaseSuite) TestUnlink_Success() {
+	ctx := context.Background()
+	s.identityRepo.On("GetByUserID", ctx, int64(3)).Return([]entity.UserIdentity{{UserID: 3, Provider: testProvider}}, nil).Once()
+	s.userRepo.On("GetPasswordHash", ctx, int64(3)).Return("$2a$10$hash", nil).Once()
+	s.identityRepo.On("Delete", ctx, int64(3), testProvider).Return(nil).Once()
+
+	err := s.usecase.Unlink(ctx, 3, testProvider)
+
+	s.NoError(err)
+}
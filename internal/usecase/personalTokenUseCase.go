@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+type PersonalTokenUsecase interface {
+	Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*entity.PersonalToken, string, error)
+	List(ctx context.Context, userID int64) ([]entity.PersonalToken, error)
+	Revoke(ctx context.Context, userID, id int64) error
+}
+
+type personalTokenUsecase struct {
+	tokenRepo repo.PersonalTokenRepository
+	log       *zerolog.Logger
+}
+
+var (
+	ErrUnknownScope         = entity.NewError(entity.KindInvalid, "unknown_scope", "unknown token scope")
+	ErrTokenExpiry          = entity.NewError(entity.KindInvalid, "invalid_token_expiry", "token expiry must be in the future")
+	ErrPersonalTokenMissing = entity.NewError(entity.KindNotFound, "personal_token_not_found", "personal token not found")
+	ErrInvalidPersonalToken = entity.NewError(entity.KindUnauthorized, "invalid_token", "invalid personal access token")
+)
+
+const (
+	personalTokenPrefix     = "fgo_"
+	personalTokenBytes      = 20
+	personalTokenShownChars = 8
+)
+
+const (
+	createPersonalTokenOp = "PersonalTokenUsecase.Create"
+	listPersonalTokensOp  = "PersonalTokenUsecase.List"
+	revokePersonalTokenOp = "PersonalTokenUsecase.Revoke"
+)
+
+func NewPersonalTokenUsecase(tokenRepo repo.PersonalTokenRepository, log *zerolog.Logger) PersonalTokenUsecase {
+	return &personalTokenUsecase{tokenRepo: tokenRepo, log: log}
+}
+
+// Create issues a token limited to scopes. The plain token is returned only here; the database keeps its SHA-256 hash.
+func (u *personalTokenUsecase) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*entity.PersonalToken, string, error) {
+	log := u.log.With().Str("op", createPersonalTokenOp).Int64("user_id", userID).Logger()
+
+	for _, scope := range scopes {
+		if !slices.Contains(entity.TokenScopes, scope) {
+			return nil, "", ErrUnknownScope.WithMessage(fmt.Sprintf("%s: %s", ErrUnknownScope, scope))
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrTokenExpiry
+	}
+
+	plain, err := generatePersonalToken()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate personal token")
+		return nil, "", err
+	}
+
+	token := &entity.PersonalToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(personalTokenPrefix)+personalTokenShownChars],
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		ExpiresAt: expiresAt,
+	}
+	created, err := u.tokenRepo.Create(ctx, token, hashPersonalToken(plain))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to store personal token")
+		return nil, "", fmt.Errorf("failed to create personal token: %w", err)
+	}
+
+	log.Info().Int64("token_id", created.ID).Strs("scopes", created.Scopes).Msg("Personal token created")
+	return created, plain, nil
+}
+
+func (u *personalTokenUsecase) List(ctx context.Context, userID int64) ([]entity.PersonalToken, error) {
+	tokens, err := u.tokenRepo.List(ctx, userID)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", listPersonalTokensOp).Int64("user_id", userID).Msg("Failed to list personal tokens")
+		return nil, fmt.Errorf("failed to list personal tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// Revoke deletes one of the user's tokens. The forum may keep accepting it until its validation cache expires.
+func (u *personalTokenUsecase) Revoke(ctx context.Context, userID, id int64) error {
+	log := u.log.With().Str("op", revokePersonalTokenOp).Int64("user_id", userID).Int64("token_id", id).Logger()
+
+	deleted, err := u.tokenRepo.Delete(ctx, userID, id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to revoke personal token")
+		return fmt.Errorf("failed to revoke personal token: %w", err)
+	}
+	if !deleted {
+		return ErrPersonalTokenMissing
+	}
+
+	log.Info().Msg("Personal token revoked")
+	return nil
+}
+
+func generatePersonalToken() (string, error) {
+	buf := make([]byte, personalTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate personal token: %w", err)
+	}
+	return personalTokenPrefix + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)), nil
+}
+
+func hashPersonalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
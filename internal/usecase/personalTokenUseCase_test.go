@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonalTokenUsecaseSuite struct {
+	suite.Suite
+	usecase   PersonalTokenUsecase
+	tokenRepo *mocks.PersonalTokenRepository
+}
+
+func (s *PersonalTokenUsecaseSuite) SetupTest() {
+	s.tokenRepo = mocks.NewPersonalTokenRepository(s.T())
+	logger := zerolog.Nop()
+	s.usecase = NewPersonalTokenUsecase(s.tokenRepo, &logger)
+}
+
+func TestPersonalTokenUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenUsecaseSuite))
+}
+
+func (s *PersonalTokenUsecaseSuite) TestCreate_Success() {
+	ctx := context.Background()
+	var storedHash string
+
+	s.tokenRepo.On("Create", ctx, mock.MatchedBy(func(t *entity.PersonalToken) bool {
+		return t.UserID == 7 && t.Name == "bot" && strings.HasPrefix(t.Prefix, personalTokenPrefix) &&
+			len(t.Scopes) == 2 && t.Scopes[0] == entity.ScopePostsWrite && t.Scopes[1] == entity.ScopeTopicsWrite
+	}), mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		storedHash = args.String(2)
+	}).Return(&entity.PersonalToken{ID: 1, UserID: 7, Name: "bot"}, nil).Once()
+
+	token, plain, err := s.usecase.Create(ctx, 7, "bot", []string{entity.ScopeTopicsWrite, entity.ScopePostsWrite, entity.ScopePostsWrite}, nil)
+
+	s.NoError(err)
+	s.Equal(int64(1), token.ID)
+	s.True(strings.HasPrefix(plain, personalTokenPrefix))
+	s.Equal(hashPersonalToken(plain), storedHash)
+}
+
+func (s *PersonalTokenUsecaseSuite) TestCreate_UnknownScope() {
+	_, _, err := s.usecase.Create(context.Background(), 7, "bot", []string{"admin"}, nil)
+
+	s.ErrorIs(err, ErrUnknownScope)
+	s.tokenRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PersonalTokenUsecaseSuite) TestCreate_ExpiryInPast() {
+	past := time.Now().Add(-time.Hour)
+
+	_, _, err := s.usecase.Create(context.Background(), 7, "bot", []string{entity.ScopeChatWrite}, &past)
+
+	s.ErrorIs(err, ErrTokenExpiry)
+}
+
+func (s *PersonalTokenUsecaseSuite) TestRevoke_Success() {
+	ctx := context.Background()
+
+	s.tokenRepo.On("Delete", ctx, int64(7), int64(3)).Return(true, nil).Once()
+
+	s.NoError(s.usecase.Revoke(ctx, 7, 3))
+}
+
+func (s *PersonalTokenUsecaseSuite) TestRevoke_NotFound() {
+	ctx := context.Background()
+
+	s.tokenRepo.On("Delete", ctx, int64(7), int64(3)).Return(false, nil).Once()
+
+	s.ErrorIs(s.usecase.Revoke(ctx, 7, 3), ErrPersonalTokenMissing)
+}
+
+func (s *PersonalTokenUsecaseSuite) TestRevoke_RepoError() {
+	ctx := context.Background()
+
+	s.tokenRepo.On("Delete", ctx, int64(7), int64(3)).Return(false, errors.New("db down")).Once()
+
+	err := s.usecase.Revoke(ctx, 7, 3)
+
+	s.Error(err)
+	s.NotErrorIs(err, ErrPersonalTokenMissing)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+type ProfileUsecase interface {
+	Get(ctx context.Context, userID int64) (*entity.UserProfile, error)
+	Update(ctx context.Context, userID int64, update entity.ProfileUpdate) (*entity.UserProfile, error)
+}
+
+type profileUsecase struct {
+	profileRepo repo.ProfileRepository
+	log         *zerolog.Logger
+}
+
+var ErrInvalidProfile = entity.NewError(entity.KindInvalid, "invalid_profile", "invalid profile")
+
+const (
+	maxDisplayNameLength = 64
+	maxBioLength         = 1000
+	maxSignatureLength   = 300
+	maxAvatarURLLength   = 2048
+)
+
+const (
+	getProfileOp    = "ProfileUsecase.Get"
+	updateProfileOp = "ProfileUsecase.Update"
+)
+
+func NewProfileUsecase(profileRepo repo.ProfileRepository, log *zerolog.Logger) ProfileUsecase {
+	return &profileUsecase{profileRepo: profileRepo, log: log}
+}
+
+func (u *profileUsecase) Get(ctx context.Context, userID int64) (*entity.UserProfile, error) {
+	profile, err := u.profileRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		u.log.Error().Err(err).Str("op", getProfileOp).Int64("user_id", userID).Msg("Failed to get profile")
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return profile, nil
+}
+
+// Update validates and applies the changed profile fields. Display name and signature are trimmed,
+// and an empty avatar URL removes the avatar.
+func (u *profileUsecase) Update(ctx context.Context, userID int64, update entity.ProfileUpdate) (*entity.UserProfile, error) {
+	log := u.log.With().Str("op", updateProfileOp).Int64("user_id", userID).Logger()
+
+	if err := normalizeProfileUpdate(&update); err != nil {
+		return nil, err
+	}
+
+	profile, err := u.profileRepo.Update(ctx, userID, update)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("Failed to update profile")
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	log.Info().Msg("Profile updated")
+	return profile, nil
+}
+
+func normalizeProfileUpdate(update *entity.ProfileUpdate) error {
+	if update.DisplayName != nil {
+		name := strings.TrimSpace(*update.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return ErrInvalidProfile.WithMessage(fmt.Sprintf("invalid profile: display name must be at most %d characters", maxDisplayNameLength))
+		}
+		update.DisplayName = &name
+	}
+
+	if update.Bio != nil && utf8.RuneCountInString(*update.Bio) > maxBioLength {
+		return ErrInvalidProfile.WithMessage(fmt.Sprintf("invalid profile: bio must be at most %d characters", maxBioLength))
+	}
+
+	if update.Signature != nil {
+		signature := strings.TrimSpace(*update.Signature)
+		if utf8.RuneCountInString(signature) > maxSignatureLength {
+			return ErrInvalidProfile.WithMessage(fmt.Sprintf("invalid profile: signature must be at most %d characters", maxSignatureLength))
+		}
+		update.Signature = &signature
+	}
+
+	if update.AvatarURL != nil {
+		avatar := strings.TrimSpace(*update.AvatarURL)
+		if avatar != "" && !validAvatarURL(avatar) {
+			return ErrInvalidProfile.WithMessage(fmt.Sprintf("invalid profile: avatar URL must be an absolute http or https URL of at most %d characters", maxAvatarURLLength))
+		}
+		update.AvatarURL = &avatar
+	}
+
+	return nil
+}
+
+func validAvatarURL(raw string) bool {
+	if len(raw) > maxAvatarURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
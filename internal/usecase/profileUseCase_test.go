@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileUsecaseSuite struct {
+	suite.Suite
+	usecase     ProfileUsecase
+	profileRepo *mocks.ProfileRepository
+}
+
+func (s *ProfileUsecaseSuite) SetupTest() {
+	s.profileRepo = mocks.NewProfileRepository(s.T())
+	logger := zerolog.Nop()
+	s.usecase = NewProfileUsecase(s.profileRepo, &logger)
+}
+
+func TestProfileUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ProfileUsecaseSuite))
+}
+
+func (s *ProfileUsecaseSuite) TestGet_NotFound() {
+	ctx := context.Background()
+
+	s.profileRepo.On("GetByID", ctx, int64(7)).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.Get(ctx, 7)
+
+	s.ErrorIs(err, ErrUserNotFound)
+}
+
+func (s *ProfileUsecaseSuite) TestUpdate_TrimsFields() {
+	ctx := context.Background()
+	name, avatar := "  Bob  ", " https://example.com/b.png "
+	profile := &entity.UserProfile{UserID: 7, Username: "bob", DisplayName: "Bob", AvatarURL: "https://example.com/b.png"}
+
+	s.profileRepo.On("Update", ctx, int64(7), mock.MatchedBy(func(u entity.ProfileUpdate) bool {
+		return *u.DisplayName == "Bob" && *u.AvatarURL == "https://example.com/b.png" && u.Bio == nil && u.Signature == nil
+	})).Return(profile, nil).Once()
+
+	got, err := s.usecase.Update(ctx, 7, entity.ProfileUpdate{DisplayName: &name, AvatarURL: &avatar})
+
+	s.NoError(err)
+	s.Equal(profile, got)
+}
+
+func (s *ProfileUsecaseSuite) TestUpdate_ClearAvatar() {
+	ctx := context.Background()
+	empty := ""
+
+	s.profileRepo.On("Update", ctx, int64(7), entity.ProfileUpdate{AvatarURL: &empty}).Return(&entity.UserProfile{UserID: 7}, nil).Once()
+
+	_, err := s.usecase.Update(ctx, 7, entity.ProfileUpdate{AvatarURL: &empty})
+
+	s.NoError(err)
+}
+
+func (s *ProfileUsecaseSuite) TestUpdate_Invalid() {
+	long := strings.Repeat("я", maxDisplayNameLength+1)
+	bio := strings.Repeat("a", maxBioLength+1)
+	signature := strings.Repeat("a", maxSignatureLength+1)
+	avatars := []string{"javascript:alert(1)", "/relative.png", "ftp://example.com/a.png", "https://" + strings.Repeat("a", maxAvatarURLLength)}
+
+	updates := []entity.ProfileUpdate{
+		{DisplayName: &long},
+		{Bio: &bio},
+		{Signature: &signature},
+	}
+	for i := range avatars {
+		updates = append(updates, entity.ProfileUpdate{AvatarURL: &avatars[i]})
+	}
+
+	for _, update := range updates {
+		_, err := s.usecase.Update(context.Background(), 7, update)
+		s.ErrorIs(err, ErrInvalidProfile)
+	}
+	s.profileRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ProfileUsecaseSuite) TestUpdate_NotFound() {
+	ctx := context.Background()
+	bio := "hi"
+
+	s.profileRepo.On("Update", ctx, int64(7), entity.ProfileUpdate{Bio: &bio}).Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := s.usecase.Update(ctx, 7, entity.ProfileUpdate{Bio: &bio})
+
+	s.ErrorIs(err, ErrUserNotFound)
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+type ModeratorUsecase interface {
+	GetModerators(ctx context.Context, categoryID int64) ([]entity.User, error)
+	AddModerator(ctx context.Context, categoryID int64, userID int64) error
+	RemoveModerator(ctx context.Context, categoryID int64, userID int64) error
+}
+
+type moderatorUsecase struct {
+	roleRepo repo.RoleRepository
+	userRepo repo.UserRepository
+	log      *zerolog.Logger
+}
+
+var ErrUserNotFound = entity.NewError(entity.KindNotFound, "user_not_found", "user not found")
+
+const (
+	getModeratorsOp   = "ModeratorUsecase.GetModerators"
+	addModeratorOp    = "ModeratorUsecase.AddModerator"
+	removeModeratorOp = "ModeratorUsecase.RemoveModerator"
+)
+
+func NewModeratorUsecase(roleRepo repo.RoleRepository, userRepo repo.UserRepository, log *zerolog.Logger) ModeratorUsecase {
+	return &moderatorUsecase{roleRepo: roleRepo, userRepo: userRepo, log: log}
+}
+
+func (u *moderatorUsecase) GetModerators(ctx context.Context, categoryID int64) ([]entity.User, error) {
+	users, err := u.roleRepo.GetModerators(ctx, categoryID)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", getModeratorsOp).Int64("category_id", categoryID).Msg("Failed to get moderators")
+		return nil, fmt.Errorf("failed to get moderators: %w", err)
+	}
+	return users, nil
+}
+
+// AddModerator grants userID moderator rights in categoryID. Categories live in the forum database, so categoryID is not checked here.
+// The new rights reach the user's access token on the next refresh.
+func (u *moderatorUsecase) AddModerator(ctx context.Context, categoryID int64, userID int64) error {
+	log := u.log.With().Str("op", addModeratorOp).Int64("category_id", categoryID).Int64("user_id", userID).Logger()
+
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		log.Error().Err(err).Msg("Failed to get user by ID")
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := u.roleRepo.AddModerator(ctx, categoryID, userID); err != nil {
+		log.Error().Err(err).Msg("Failed to add moderator")
+		return fmt.Errorf("failed to add moderator: %w", err)
+	}
+
+	log.Info().Msg("Moderator added")
+	return nil
+}
+
+func (u *moderatorUsecase) RemoveModerator(ctx context.Context, categoryID int64, userID int64) error {
+	log := u.log.With().Str("op", removeModeratorOp).Int64("category_id", categoryID).Int64("user_id", userID).Logger()
+
+	if err := u.roleRepo.RemoveModerator(ctx, categoryID, userID); err != nil {
+		log.Error().Err(err).Msg("Failed to remove moderator")
+		return fmt.Errorf("failed to remove moderator: %w", err)
+	}
+
+	log.Info().Msg("Moderator removed")
+	return nil
+}
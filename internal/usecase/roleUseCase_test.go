@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocks "github.com/Van-programan/Forum_GO/mocks/auth/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModeratorUsecaseSuite struct {
+	suite.Suite
+	usecase  ModeratorUsecase
+	roleRepo *mocks.RoleRepository
+	userRepo *mocks.UserRepository
+}
+
+func (s *ModeratorUsecaseSuite) SetupTest() {
+	s.roleRepo = mocks.NewRoleRepository(s.T())
+	s.userRepo = mocks.NewUserRepository(s.T())
+	logger := zerolog.Nop()
+	s.usecase = NewModeratorUsecase(s.roleRepo, s.userRepo, &logger)
+}
+
+func TestModeratorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ModeratorUsecaseSuite))
+}
+
+func (s *ModeratorUsecaseSuite) TestGetModerators_Success() {
+	ctx := context.Background()
+	moderators := []entity.User{{ID: 1, Username: "alice"}}
+
+	s.roleRepo.On("GetModerators", ctx, int64(3)).Return(moderators, nil).Once()
+
+	users, err := s.usecase.GetModerators(ctx, 3)
+
+	s.NoError(err)
+	s.Equal(moderators, users)
+}
+
+func (s *ModeratorUsecaseSuite) TestAddModerator_Success() {
+	ctx := context.Background()
+
+	s.userRepo.On("GetByID", ctx, int64(7)).Return(&entity.User{ID: 7, Username: "bob"}, nil).Once()
+	s.roleRepo.On("AddModerator", ctx, int64(3), int64(7)).Return(nil).Once()
+
+	err := s.usecase.AddModerator(ctx, 3, 7)
+
+	s.NoError(err)
+}
+
+func (s *ModeratorUsecaseSuite) TestAddModerator_UserNotFound() {
+	ctx := context.Background()
+
+	s.userRepo.On("GetByID", ctx, int64(7)).Return(nil, pgx.ErrNoRows).Once()
+
+	err := s.usecase.AddModerator(ctx, 3, 7)
+
+	s.ErrorIs(err, ErrUserNotFound)
+	s.roleRepo.AssertNotCalled(s.T(), "AddModerator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ModeratorUsecaseSuite) TestRemoveModerator_RepoError() {
+	ctx := context.Background()
+	repoErr := errors.New("db error")
+
+	s.roleRepo.On("RemoveModerator", ctx, int64(3), int64(7)).Return(repoErr).Once()
+
+	err := s.usecase.RemoveModerator(ctx, 3, 7)
+
+	s.ErrorIs(err, repoErr)
+}
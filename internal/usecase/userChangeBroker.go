@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"sync"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/rs/zerolog"
+)
+
+// userChangeBuffer is how many changes a subscriber may lag behind before it is dropped.
+const userChangeBuffer = 64
+
+// UserChangeBroker fans account changes out to the services watching them. A subscriber that falls
+// behind has its channel closed instead of silently losing events, so it knows to drop its cache.
+type UserChangeBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan entity.UserChange]struct{}
+	log         *zerolog.Logger
+}
+
+func NewUserChangeBroker(log *zerolog.Logger) *UserChangeBroker {
+	return &UserChangeBroker{subscribers: make(map[chan entity.UserChange]struct{}), log: log}
+}
+
+// Subscribe returns a channel of changes and a function that ends the subscription.
+func (b *UserChangeBroker) Subscribe() (<-chan entity.UserChange, func()) {
+	ch := make(chan entity.UserChange, userChangeBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *UserChangeBroker) Publish(change entity.UserChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- change:
+		default:
+			b.log.Warn().Str("op", "UserChangeBroker.Publish").Msg("Subscriber fell behind, dropping it")
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserChangeBroker_FanOut(t *testing.T) {
+	logger := zerolog.Nop()
+	broker := NewUserChangeBroker(&logger)
+
+	first, unsubscribeFirst := broker.Subscribe()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	change := entity.UserChange{Type: entity.UserChangeRenamed, UserID: 7}
+	broker.Publish(change)
+
+	assert.Equal(t, change, <-first)
+	assert.Equal(t, change, <-second)
+
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+	unsubscribeFirst()
+
+	broker.Publish(change)
+	assert.Equal(t, change, <-second)
+}
+
+func TestUserChangeBroker_DropsSlowSubscriber(t *testing.T) {
+	logger := zerolog.Nop()
+	broker := NewUserChangeBroker(&logger)
+
+	changes, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= userChangeBuffer; i++ {
+		broker.Publish(entity.UserChange{Type: entity.UserChangeUpdated, UserID: int64(i)})
+	}
+
+	received := 0
+	for range changes {
+		received++
+	}
+	assert.Equal(t, userChangeBuffer, received)
+}
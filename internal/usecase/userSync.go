@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/client"
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+const (
+	userSyncBackoffMin = time.Second
+	userSyncBackoffMax = 30 * time.Second
+)
+
+// UserSync keeps the forum's users table, which posts and topics reference, a copy of the user service's
+// accounts. It catches up from the stored checkpoint on start, whenever the user service announces a
+// change and every interval, so a missed event is made up for on the next tick.
+type UserSync struct {
+	mirror   repo.UserMirrorRepository
+	users    client.UserClient
+	interval time.Duration
+	log      *zerolog.Logger
+}
+
+func NewUserSync(mirror repo.UserMirrorRepository, users client.UserClient, interval time.Duration, log *zerolog.Logger) *UserSync {
+	return &UserSync{mirror, users, interval, log}
+}
+
+// Run syncs until ctx is done. Failed syncs are logged and retried on the next event or tick.
+func (s *UserSync) Run(ctx context.Context) {
+	log := s.log.With().Str("op", "UserSync.Run").Logger()
+
+	// Events arriving during a sync collapse into one more sync, which picks up all of them.
+	trigger := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+	notify()
+	go s.watch(ctx, notify)
+
+	var tick <-chan time.Time
+	if s.interval > 0 {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-trigger:
+		case <-tick:
+		}
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("failed to sync users")
+		}
+	}
+}
+
+// watch calls notify for every user change, resubscribing with backoff whenever the stream ends.
+// The stream opens with a reset event, so changes made while it was down are synced too.
+func (s *UserSync) watch(ctx context.Context, notify func()) {
+	log := s.log.With().Str("op", "UserSync.watch").Logger()
+	backoff := userSyncBackoffMin
+
+	for {
+		changes, err := s.users.WatchUserChanges(ctx)
+		if err == nil {
+			for range changes {
+				backoff = userSyncBackoffMin
+				notify()
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn().Err(err).Dur("retry_in", backoff).Msg("User change stream ended")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, userSyncBackoffMax)
+	}
+}
+
+// Sync applies every change since the stored checkpoint and then moves the checkpoint forward. If it fails
+// part way, the next sync applies the same changes again, which is harmless.
+func (s *UserSync) Sync(ctx context.Context) error {
+	since, err := s.mirror.Checkpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("ForumService - UserSync - Sync - mirror.Checkpoint: %w", err)
+	}
+
+	applied := 0
+	checkpoint, err := s.users.StreamUsers(ctx, since, func(records []entity.UserRecord) error {
+		if err := s.mirror.Apply(ctx, records); err != nil {
+			return fmt.Errorf("ForumService - UserSync - Sync - mirror.Apply: %w", err)
+		}
+		applied += len(records)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ForumService - UserSync - Sync - users.StreamUsers: %w", err)
+	}
+
+	if checkpoint != since {
+		if err := s.mirror.SaveCheckpoint(ctx, checkpoint); err != nil {
+			return fmt.Errorf("ForumService - UserSync - Sync - mirror.SaveCheckpoint: %w", err)
+		}
+	}
+
+	if applied > 0 {
+		s.log.Info().Str("op", "UserSync.Sync").Int("users", applied).Int64("checkpoint", checkpoint).Msg("users synced")
+	}
+	return nil
+}
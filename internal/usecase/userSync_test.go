@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Van-programan/Forum_GO/internal/entity"
+	mocksf "github.com/Van-programan/Forum_GO/mocks/forum"
+	mocks "github.com/Van-programan/Forum_GO/mocks/forum/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// streamPages makes a StreamUsers mock hand each page to the apply callback.
+func streamPages(pages ...[]entity.UserRecord) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		apply := args.Get(2).(func([]entity.UserRecord) error)
+		for _, page := range pages {
+			if err := apply(page); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestUserSync_Sync(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	first := []entity.UserRecord{{ID: 1, Username: "alice", Role: entity.RoleUser}}
+	second := []entity.UserRecord{{ID: 2, Deleted: true}}
+
+	t.Run("Applies pages then saves checkpoint", func(t *testing.T) {
+		mirror := mocks.NewUserMirrorRepository(t)
+		users := mocksf.NewUserClient(t)
+		mirror.On("Checkpoint", ctx).Return(int64(100), nil).Once()
+		users.On("StreamUsers", ctx, int64(100), mock.Anything).Run(streamPages(first, second)).Return(int64(200), nil).Once()
+		applied := mirror.On("Apply", ctx, first).Return(nil).Once()
+		mirror.On("Apply", ctx, second).Return(nil).Once().NotBefore(applied)
+		mirror.On("SaveCheckpoint", ctx, int64(200)).Return(nil).Once()
+
+		assert.NoError(t, NewUserSync(mirror, users, 0, &logger).Sync(ctx))
+	})
+
+	t.Run("Keeps checkpoint when a page fails", func(t *testing.T) {
+		mirror := mocks.NewUserMirrorRepository(t)
+		users := mocksf.NewUserClient(t)
+		dbErr := errors.New("db down")
+		mirror.On("Checkpoint", ctx).Return(int64(100), nil).Once()
+		users.On("StreamUsers", ctx, int64(100), mock.Anything).Run(streamPages(first, second)).Return(int64(0), dbErr).Once()
+		mirror.On("Apply", ctx, first).Return(dbErr).Once()
+
+		err := NewUserSync(mirror, users, 0, &logger).Sync(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		mirror.AssertNotCalled(t, "SaveCheckpoint", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Nothing new", func(t *testing.T) {
+		mirror := mocks.NewUserMirrorRepository(t)
+		users := mocksf.NewUserClient(t)
+		mirror.On("Checkpoint", ctx).Return(int64(100), nil).Once()
+		users.On("StreamUsers", ctx, int64(100), mock.Anything).Run(streamPages(nil)).Return(int64(100), nil).Once()
+		mirror.On("Apply", ctx, []entity.UserRecord(nil)).Return(nil).Once()
+
+		assert.NoError(t, NewUserSync(mirror, users, 0, &logger).Sync(ctx))
+	})
+}
+
+func TestUserSync_RunSyncsOnChange(t *testing.T) {
+	logger := zerolog.Nop()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mirror := mocks.NewUserMirrorRepository(t)
+	users := mocksf.NewUserClient(t)
+
+	changes := make(chan entity.UserChange)
+	users.On("WatchUserChanges", mock.Anything).Return((<-chan entity.UserChange)(changes), nil).Once()
+
+	synced := make(chan int64, 2)
+	mirror.On("Checkpoint", mock.Anything).Return(int64(100), nil)
+	users.On("StreamUsers", mock.Anything, int64(100), mock.Anything).
+		Run(func(mock.Arguments) { synced <- 100 }).Return(int64(100), nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewUserSync(mirror, users, time.Hour, &logger).Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	assert.Equal(t, int64(100), <-synced, "syncs on start")
+	changes <- entity.UserChange{Type: entity.UserChangeCreated, UserID: 3}
+	select {
+	case <-synced:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no sync after a user change")
+	}
+}
@@ -2,30 +2,49 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/repo"
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
 )
 
+// maxUserIDs bounds a batch lookup so one request cannot ask for the whole users table.
+const maxUserIDs = 1000
+
+var ErrInvalidUserIDs = errors.New("invalid user ids")
+
 type UserUsecase interface {
 	GetUsernamesByIds(ctx context.Context, ids []int64) (map[int64]string, error)
 	GetUsernameById(ctx context.Context, id int64) (string, error)
+	GetUserStatus(ctx context.Context, id int64) (*entity.UserStatus, error)
+	GetUserStatuses(ctx context.Context, ids []int64) (map[int64]entity.UserStatus, error)
+	ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error)
+	GetUserProfiles(ctx context.Context, ids []int64) (map[int64]entity.UserProfile, error)
+	GetUserProfile(ctx context.Context, id int64) (*entity.UserProfile, error)
+	WatchUserChanges() (<-chan entity.UserChange, func())
+	StreamUsers(ctx context.Context, since int64, send func(records []entity.UserRecord, checkpoint int64) error) error
 }
 
 type userUsecase struct {
-	repo repo.UserGprcRepository
-	log  *zerolog.Logger
+	repo        repo.UserGprcRepository
+	banRepo     repo.BanRepository
+	tokenRepo   repo.PersonalTokenRepository
+	profileRepo repo.ProfileRepository
+	replication repo.UserReplicationRepository
+	changes     *UserChangeBroker
+	log         *zerolog.Logger
 }
 
-func New(repo repo.UserGprcRepository, log *zerolog.Logger) UserUsecase {
-	return &userUsecase{repo, log}
+func New(repo repo.UserGprcRepository, banRepo repo.BanRepository, tokenRepo repo.PersonalTokenRepository, profileRepo repo.ProfileRepository, replication repo.UserReplicationRepository, changes *UserChangeBroker, log *zerolog.Logger) UserUsecase {
+	return &userUsecase{repo, banRepo, tokenRepo, profileRepo, replication, changes, log}
 }
 
 func (u *userUsecase) GetUsernamesByIds(ctx context.Context, ids []int64) (map[int64]string, error) {
-	if len(ids) == 0 {
-		u.log.Warn().Str("op", "UserUsecase.GetUsernamesByIds").Msg("empty ids")
-		return make(map[int64]string), nil
+	if err := validateUserIDs(ids); err != nil {
+		return nil, err
 	}
 
 	usernames, err := u.repo.GetUsernamesByIds(ctx, ids)
@@ -39,8 +58,15 @@ func (u *userUsecase) GetUsernamesByIds(ctx context.Context, ids []int64) (map[i
 }
 
 func (u *userUsecase) GetUsernameById(ctx context.Context, id int64) (string, error) {
+	if err := validateUserIDs([]int64{id}); err != nil {
+		return "", err
+	}
+
 	username, err := u.repo.GetUsernameById(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
 		u.log.Error().Err(err).Str("op", "UserUsecase.GetUsernameById").Msg("failed to get username by id")
 		return "", fmt.Errorf("UserService - UserUsecase - GetUsernameById - repo.GetUsernameById: %w", err)
 	}
@@ -48,3 +74,150 @@ func (u *userUsecase) GetUsernameById(ctx context.Context, id int64) (string, er
 	u.log.Info().Str("op", "UserUsecase.GetUsernameById").Msg("success")
 	return username, nil
 }
+
+func (u *userUsecase) GetUserStatus(ctx context.Context, id int64) (*entity.UserStatus, error) {
+	status := &entity.UserStatus{UserID: id}
+
+	ban, err := u.banRepo.GetActive(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status, nil
+		}
+		u.log.Error().Err(err).Str("op", "UserUsecase.GetUserStatus").Int64("user_id", id).Msg("failed to get active ban")
+		return nil, fmt.Errorf("UserService - UserUsecase - GetUserStatus - banRepo.GetActive: %w", err)
+	}
+
+	status.Banned = true
+	status.Reason = ban.Reason
+	status.BannedUntil = ban.ExpiresAt
+	return status, nil
+}
+
+// GetUserStatuses returns the status of every requested user, so callers watching many users need one call.
+func (u *userUsecase) GetUserStatuses(ctx context.Context, ids []int64) (map[int64]entity.UserStatus, error) {
+	if err := validateUserIDs(ids); err != nil {
+		return nil, err
+	}
+
+	bans, err := u.banRepo.GetActiveByUsers(ctx, ids)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", "UserUsecase.GetUserStatuses").Msg("failed to get active bans")
+		return nil, fmt.Errorf("UserService - UserUsecase - GetUserStatuses - banRepo.GetActiveByUsers: %w", err)
+	}
+
+	statuses := make(map[int64]entity.UserStatus, len(ids))
+	for _, id := range ids {
+		status := entity.UserStatus{UserID: id}
+		if ban, ok := bans[id]; ok {
+			status.Banned = true
+			status.Reason = ban.Reason
+			status.BannedUntil = ban.ExpiresAt
+		}
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
+// ValidateToken resolves a personal access token. Unknown and expired tokens, and tokens of banned users,
+// give ErrInvalidPersonalToken.
+func (u *userUsecase) ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error) {
+	log := u.log.With().Str("op", "UserUsecase.ValidateToken").Logger()
+
+	identity, err := u.tokenRepo.Authenticate(ctx, hashPersonalToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidPersonalToken
+		}
+		log.Error().Err(err).Msg("failed to authenticate personal token")
+		return nil, fmt.Errorf("UserService - UserUsecase - ValidateToken - tokenRepo.Authenticate: %w", err)
+	}
+
+	if _, err := u.banRepo.GetActive(ctx, identity.UserID); err == nil {
+		log.Info().Int64("user_id", identity.UserID).Msg("personal token of banned user rejected")
+		return nil, ErrInvalidPersonalToken
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).Int64("user_id", identity.UserID).Msg("failed to get active ban")
+		return nil, fmt.Errorf("UserService - UserUsecase - ValidateToken - banRepo.GetActive: %w", err)
+	}
+
+	return identity, nil
+}
+
+// GetUserProfiles returns the profiles of the users that exist; unknown IDs are left out.
+func (u *userUsecase) GetUserProfiles(ctx context.Context, ids []int64) (map[int64]entity.UserProfile, error) {
+	if err := validateUserIDs(ids); err != nil {
+		return nil, err
+	}
+
+	profiles, err := u.profileRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", "UserUsecase.GetUserProfiles").Msg("failed to get profiles")
+		return nil, fmt.Errorf("UserService - UserUsecase - GetUserProfiles - profileRepo.GetByIDs: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (u *userUsecase) GetUserProfile(ctx context.Context, id int64) (*entity.UserProfile, error) {
+	if err := validateUserIDs([]int64{id}); err != nil {
+		return nil, err
+	}
+
+	profile, err := u.profileRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		u.log.Error().Err(err).Str("op", "UserUsecase.GetUserProfile").Int64("user_id", id).Msg("failed to get profile")
+		return nil, fmt.Errorf("UserService - UserUsecase - GetUserProfile - profileRepo.GetByID: %w", err)
+	}
+
+	return profile, nil
+}
+
+// WatchUserChanges subscribes to renames, profile edits and deletions. The channel is closed when the
+// subscriber falls behind; call the returned function to unsubscribe.
+func (u *userUsecase) WatchUserChanges() (<-chan entity.UserChange, func()) {
+	return u.changes.Subscribe()
+}
+
+// userRecordsPage is how many user records StreamUsers reads and sends at a time.
+const userRecordsPage = 500
+
+// StreamUsers hands send every account created, renamed, re-roled or deleted since the checkpoint since,
+// a page at a time, with the checkpoint to resume from once all pages are applied. since 0 streams every
+// account. At least one page is sent, possibly empty, so the caller always learns the new checkpoint.
+func (u *userUsecase) StreamUsers(ctx context.Context, since int64, send func(records []entity.UserRecord, checkpoint int64) error) error {
+	checkpoint, err := u.replication.Horizon(ctx)
+	if err != nil {
+		u.log.Error().Err(err).Str("op", "UserUsecase.StreamUsers").Msg("failed to get replication horizon")
+		return fmt.Errorf("UserService - UserUsecase - StreamUsers - replication.Horizon: %w", err)
+	}
+
+	var after entity.UserRecord
+	for {
+		records, err := u.replication.ListChanges(ctx, since, after, userRecordsPage)
+		if err != nil {
+			return fmt.Errorf("UserService - UserUsecase - StreamUsers - replication.ListChanges: %w", err)
+		}
+		if err := send(records, checkpoint); err != nil {
+			return err
+		}
+		if len(records) < userRecordsPage {
+			return nil
+		}
+		after = records[len(records)-1]
+	}
+}
+
+func validateUserIDs(ids []int64) error {
+	if len(ids) == 0 || len(ids) > maxUserIDs {
+		return fmt.Errorf("%w: want 1 to %d ids, got %d", ErrInvalidUserIDs, maxUserIDs, len(ids))
+	}
+	for _, id := range ids {
+		if id <= 0 {
+			return fmt.Errorf("%w: id %d is not positive", ErrInvalidUserIDs, id)
+		}
+	}
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/Van-programan/Forum_GO/internal/entity"
 	"github.com/Van-programan/Forum_GO/internal/usecase"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
 	"github.com/gorilla/websocket"
 )
 
@@ -18,27 +20,39 @@ type Client struct {
 	UserID       int64
 	Username     string
 	IsAuthorized bool
-	chatUsecase  usecase.ChatUsecase
+	// locale is the language of the error frames sent to the client, negotiated when it connected.
+	locale      string
+	chatUsecase usecase.ChatUsecase
+
+	// disconnect is closed by the hub to make WritePump flush send and close the connection; send itself is
+	// only closed on unregister, because ReadPump may still queue error frames. disconnecting is owned by
+	// the hub goroutine.
+	disconnect    chan struct{}
+	disconnecting bool
 }
 
-func NewAuthorizedClient(hub *Hub, conn *websocket.Conn, userID int64, username string, chatUsecase usecase.ChatUsecase) *Client {
+func NewAuthorizedClient(hub *Hub, conn *websocket.Conn, userID int64, username, locale string, chatUsecase usecase.ChatUsecase) *Client {
 	return &Client{
 		hub:          hub,
 		conn:         conn,
 		send:         make(chan []byte, 64),
+		disconnect:   make(chan struct{}),
 		UserID:       userID,
 		Username:     username,
 		IsAuthorized: true,
+		locale:       locale,
 		chatUsecase:  chatUsecase,
 	}
 }
 
-func NewUnauthorizedClient(hub *Hub, conn *websocket.Conn, chatUsecase usecase.ChatUsecase) *Client {
+func NewUnauthorizedClient(hub *Hub, conn *websocket.Conn, locale string, chatUsecase usecase.ChatUsecase) *Client {
 	return &Client{
 		hub:          hub,
 		conn:         conn,
 		send:         make(chan []byte, 64),
+		disconnect:   make(chan struct{}),
 		IsAuthorized: false,
+		locale:       locale,
 		chatUsecase:  chatUsecase,
 	}
 }
@@ -77,19 +91,24 @@ func (c *Client) ReadPump() {
 		message = bytes.TrimSpace(bytes.Replace(message, newline, space, -1))
 		var incomingMessage entity.IncomingWsMessage
 		if err := json.Unmarshal(message, &incomingMessage); err != nil {
-			c.sendErrorToClient("Invalid message format")
+			c.sendErrorToClient(i18n.ChatInvalidMessage)
 			c.hub.log.Error().Err(err).Int64("user_id", c.UserID).Str("username", c.Username).Msg("Failed to unmarshal message")
 			continue
 		}
 
 		if c.IsAuthorized {
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			ctx, cancel := context.WithTimeout(i18n.NewContext(context.Background(), c.locale), 10*time.Second)
 			savedMessage, err := c.chatUsecase.SaveMessage(ctx, c.UserID, c.Username, incomingMessage.Content)
 			cancel()
 
+			if errors.Is(err, usecase.ErrUserBanned) {
+				c.hub.log.Info().Int64("user_id", c.UserID).Str("username", c.Username).Msg("Banned user sent a message, closing connection")
+				c.sendErrorToClient(i18n.ChatBanned)
+				break
+			}
 			if err != nil {
 				c.hub.log.Error().Err(err).Int64("user_id", c.UserID).Str("username", c.Username).Msg("Failed to save message")
-				c.sendErrorToClient("Failed to save message")
+				c.sendErrorToClient(i18n.ChatSaveFailed)
 				continue
 			}
 
@@ -104,7 +123,7 @@ func (c *Client) ReadPump() {
 				c.hub.log.Warn().Int64("user_id", c.UserID).Str("username", c.Username).Msg("Failed to send message to broadcast")
 			}
 		} else {
-			c.sendErrorToClient("Отправка сообщений доступна только авторизованным пользователям")
+			c.sendErrorToClient(i18n.ChatAuthRequired)
 		}
 	}
 }
@@ -144,6 +163,16 @@ func (c *Client) WritePump() {
 				c.hub.log.Error().Err(err).Int64("user_id", c.UserID).Str("username", c.Username).Msg("Failed to close writer")
 				return
 			}
+		case <-c.disconnect:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			n := len(c.send)
+			for i := 0; i < n; i++ {
+				if err := c.conn.WriteMessage(websocket.TextMessage, <-c.send); err != nil {
+					return
+				}
+			}
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, ""))
+			return
 		case <-ticker.C:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -155,8 +184,9 @@ func (c *Client) WritePump() {
 
 }
 
-func (c *Client) sendErrorToClient(errorMsg string) {
-	errMsg := entity.WsMessage{Type: "error", Payload: errorMsg}
+// sendErrorToClient sends an error frame with the message for key in the client's locale.
+func (c *Client) sendErrorToClient(key string) {
+	errMsg := entity.WsMessage{Type: "error", Payload: i18n.Translate(c.locale, key)}
 
 	errorBytes, err := json.Marshal(errMsg)
 	if err != nil {
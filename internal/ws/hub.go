@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/Van-programan/Forum_GO/internal/client"
 	"github.com/Van-programan/Forum_GO/internal/entity"
+	"github.com/Van-programan/Forum_GO/pkg/i18n"
 	"github.com/rs/zerolog"
 )
 
@@ -13,6 +15,9 @@ const (
 	broadcastBufferSize  = 32
 	registerBufferSize   = 8
 	unregisterBufferSize = 8
+	banCheckInterval     = 30 * time.Second
+	// banCheckBatchSize matches the largest batch the user service accepts.
+	banCheckBatchSize = 1000
 )
 
 type Hub struct {
@@ -20,15 +25,20 @@ type Hub struct {
 	broadcast  chan entity.WsMessage
 	Register   chan *Client
 	unregister chan *Client
+	banned     chan int64
+	userClient client.UserClient
 	log        *zerolog.Logger
 }
 
-func NewHub(log *zerolog.Logger) *Hub {
+// NewHub creates the chat hub. With a non-nil userClient the hub periodically disconnects users that got banned.
+func NewHub(log *zerolog.Logger, userClient client.UserClient) *Hub {
 	return &Hub{
 		broadcast:  make(chan entity.WsMessage, broadcastBufferSize),
 		Register:   make(chan *Client, registerBufferSize),
 		unregister: make(chan *Client, unregisterBufferSize),
+		banned:     make(chan int64, unregisterBufferSize),
 		clients:    make(map[*Client]bool),
+		userClient: userClient,
 		log:        log,
 	}
 }
@@ -37,6 +47,9 @@ func (h *Hub) Run() {
 	log := h.log.With().Str("component", "chat.Hub").Logger()
 	log.Info().Msg("Starting chat hub")
 
+	banTicker := time.NewTicker(banCheckInterval)
+	defer banTicker.Stop()
+
 	for {
 		select {
 		case client := <-h.Register:
@@ -70,6 +83,31 @@ func (h *Hub) Run() {
 				close(client.send)
 				log.Info().Int64("user_id", client.UserID).Str("username", client.Username).Bool("is_authenticated", client.IsAuthorized).Int64("total_clients", int64(len(h.clients))).Msg("Client unregistered")
 			}
+		case <-banTicker.C:
+			if h.userClient == nil {
+				continue
+			}
+			seen := make(map[int64]struct{})
+			var userIDs []int64
+			for client := range h.clients {
+				if _, ok := seen[client.UserID]; ok || !client.IsAuthorized || client.disconnecting {
+					continue
+				}
+				seen[client.UserID] = struct{}{}
+				userIDs = append(userIDs, client.UserID)
+			}
+			if len(userIDs) > 0 {
+				go h.checkBans(userIDs)
+			}
+		case userID := <-h.banned:
+			for client := range h.clients {
+				if client.UserID != userID || !client.IsAuthorized || client.disconnecting {
+					continue
+				}
+				client.sendErrorToClient(i18n.ChatBanned)
+				h.disconnect(client)
+				log.Info().Int64("user_id", client.UserID).Str("username", client.Username).Msg("Banned client disconnected")
+			}
 		case message := <-h.broadcast:
 			messageBytes, err := json.Marshal(message)
 			log.Info().Msg(string(messageBytes))
@@ -78,13 +116,46 @@ func (h *Hub) Run() {
 				continue
 			}
 			for client := range h.clients {
+				if client.disconnecting {
+					continue
+				}
 				select {
 				case client.send <- messageBytes:
 				default:
-					close(client.send)
-					delete(h.clients, client)
+					h.disconnect(client)
 				}
 			}
 		}
 	}
 }
+
+// disconnect makes the client's WritePump send what is queued and close the connection. The client stays
+// registered until ReadPump notices the closed connection and unregisters it, which closes send.
+func (h *Hub) disconnect(client *Client) {
+	if client.disconnecting {
+		return
+	}
+	client.disconnecting = true
+	close(client.disconnect)
+}
+
+// checkBans asks the user service about the connected users, one call per batch, and hands the banned ones
+// back to Run. It runs outside Run so a slow user service does not stall the chat.
+func (h *Hub) checkBans(userIDs []int64) {
+	for start := 0; start < len(userIDs); start += banCheckBatchSize {
+		batch := userIDs[start:min(start+banCheckBatchSize, len(userIDs))]
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		statuses, err := h.userClient.GetUserStatuses(ctx, batch)
+		cancel()
+		if err != nil {
+			h.log.Error().Err(err).Int("users", len(batch)).Msg("Failed to check ban status")
+			continue
+		}
+		for userID, status := range statuses {
+			if status.Banned {
+				h.banned <- userID
+			}
+		}
+	}
+}
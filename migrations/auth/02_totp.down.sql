@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_recovery_codes_user_id;
+
+DROP TABLE IF EXISTS recovery_codes;
+
+DROP TABLE IF EXISTS user_totp;
\ No newline at end of file
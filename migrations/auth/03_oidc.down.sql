@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+
+DROP TABLE IF EXISTS oidc_states;
+
+DROP TABLE IF EXISTS user_identities;
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+
+DROP INDEX IF EXISTS idx_category_moderators_category_id;
+
+DROP TABLE IF EXISTS category_moderators;
+
+DROP TABLE IF EXISTS role_permissions;
+
+DROP TABLE IF EXISTS permissions;
+
+DROP TABLE IF EXISTS roles;
\ No newline at end of file
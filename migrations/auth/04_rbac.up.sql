@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS roles (
+	name TEXT PRIMARY KEY,
+	description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+	name TEXT PRIMARY KEY,
+	description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+	role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+	permission TEXT NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+	PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE IF NOT EXISTS category_moderators (
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	category_id INT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_moderators_category_id ON public.category_moderators(category_id);
+
+INSERT INTO roles (name, description) VALUES
+	('user', 'Regular member'),
+	('moderator', 'Moderates every category'),
+	('admin', 'Full access')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+	('post.edit.any', 'Edit posts of other users'),
+	('post.delete.any', 'Delete posts of other users'),
+	('topic.edit.any', 'Edit topics of other users'),
+	('topic.delete.any', 'Delete topics of other users'),
+	('topic.lock', 'Lock and unlock topics'),
+	('category.manage', 'Create, edit and delete categories and assign category moderators')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+	('moderator', 'post.edit.any'),
+	('moderator', 'post.delete.any'),
+	('moderator', 'topic.edit.any'),
+	('moderator', 'topic.delete.any'),
+	('moderator', 'topic.lock'),
+	('admin', 'post.edit.any'),
+	('admin', 'post.delete.any'),
+	('admin', 'topic.edit.any'),
+	('admin', 'topic.delete.any'),
+	('admin', 'topic.lock'),
+	('admin', 'category.manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles (name) SELECT DISTINCT role FROM users ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
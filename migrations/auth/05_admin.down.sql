@@ -0,0 +1,11 @@
+DELETE FROM role_permissions WHERE permission = 'user.manage';
+
+DELETE FROM permissions WHERE name = 'user.manage';
+
+DROP INDEX IF EXISTS idx_admin_audit_log_actor_id;
+
+DROP INDEX IF EXISTS idx_admin_audit_log_target_id;
+
+DROP TABLE IF EXISTS admin_audit_log;
+
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
@@ -0,0 +1,22 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+	target_id INT REFERENCES users(id) ON DELETE SET NULL,
+	action TEXT NOT NULL,
+	before JSONB NOT NULL DEFAULT '{}',
+	after JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target_id ON public.admin_audit_log(target_id);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_actor_id ON public.admin_audit_log(actor_id);
+
+INSERT INTO permissions (name, description) VALUES
+	('user.manage', 'List users, change roles and end sessions')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+	('admin', 'user.manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_bans_user_id;
+
+DROP TABLE IF EXISTS user_bans;
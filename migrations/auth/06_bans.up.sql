@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_bans (
+	id BIGSERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	banned_by INT REFERENCES users(id) ON DELETE SET NULL,
+	reason TEXT NOT NULL,
+	expires_at TIMESTAMPTZ,
+	lifted_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_bans_user_id ON public.user_bans(user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_personal_access_tokens_user_id;
+
+DROP TABLE IF EXISTS personal_access_tokens;
@@ -0,0 +1,34 @@
+DROP TRIGGER IF EXISTS users_notify_change ON users;
+
+CREATE OR REPLACE FUNCTION notify_user_change() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'deleted', 'user_id', OLD.id)::text);
+		RETURN OLD;
+	END IF;
+
+	IF NEW.username IS DISTINCT FROM OLD.username THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'renamed', 'user_id', NEW.id)::text);
+	ELSIF (NEW.display_name, NEW.avatar_url, NEW.role, NEW.signature, NEW.bio)
+		IS DISTINCT FROM (OLD.display_name, OLD.avatar_url, OLD.role, OLD.signature, OLD.bio) THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'updated', 'user_id', NEW.id)::text);
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_notify_change
+	AFTER UPDATE OR DELETE ON users
+	FOR EACH ROW EXECUTE FUNCTION notify_user_change();
+
+DROP TRIGGER IF EXISTS users_track_deletion ON users;
+
+DROP TRIGGER IF EXISTS users_track_replication ON users;
+
+DROP FUNCTION IF EXISTS track_user_replication();
+
+DROP TABLE IF EXISTS user_tombstones;
+
+DROP INDEX IF EXISTS idx_users_change_xid;
+
+ALTER TABLE users DROP COLUMN IF EXISTS change_xid;
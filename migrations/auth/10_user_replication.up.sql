@@ -0,0 +1,70 @@
+-- change_xid is the transaction that last changed what other services mirror (username, role).
+-- Readers resume from the xmin of their previous snapshot, so transactions that were still running
+-- then are read again rather than missed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS idx_users_change_xid ON users(change_xid, id);
+
+CREATE TABLE IF NOT EXISTS user_tombstones (
+	user_id BIGINT PRIMARY KEY,
+	change_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+	deleted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tombstones_change_xid ON user_tombstones(change_xid, user_id);
+
+CREATE OR REPLACE FUNCTION track_user_replication() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		INSERT INTO user_tombstones (user_id) VALUES (OLD.id)
+		ON CONFLICT (user_id) DO UPDATE SET change_xid = pg_current_xact_id(), deleted_at = now();
+		RETURN OLD;
+	END IF;
+
+	IF TG_OP = 'INSERT' OR (NEW.username, NEW.role) IS DISTINCT FROM (OLD.username, OLD.role) THEN
+		NEW.change_xid := pg_current_xact_id();
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS users_track_replication ON users;
+
+CREATE TRIGGER users_track_replication
+	BEFORE INSERT OR UPDATE ON users
+	FOR EACH ROW EXECUTE FUNCTION track_user_replication();
+
+DROP TRIGGER IF EXISTS users_track_deletion ON users;
+
+CREATE TRIGGER users_track_deletion
+	AFTER DELETE ON users
+	FOR EACH ROW EXECUTE FUNCTION track_user_replication();
+
+-- New accounts are announced too, so mirrors pick them up before their first post.
+CREATE OR REPLACE FUNCTION notify_user_change() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'INSERT' THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'created', 'user_id', NEW.id)::text);
+		RETURN NEW;
+	END IF;
+
+	IF TG_OP = 'DELETE' THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'deleted', 'user_id', OLD.id)::text);
+		RETURN OLD;
+	END IF;
+
+	IF NEW.username IS DISTINCT FROM OLD.username THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'renamed', 'user_id', NEW.id)::text);
+	ELSIF (NEW.display_name, NEW.avatar_url, NEW.role, NEW.signature, NEW.bio)
+		IS DISTINCT FROM (OLD.display_name, OLD.avatar_url, OLD.role, OLD.signature, OLD.bio) THEN
+		PERFORM pg_notify('user_changes', json_build_object('type', 'updated', 'user_id', NEW.id)::text);
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS users_notify_change ON users;
+
+CREATE TRIGGER users_notify_change
+	AFTER INSERT OR UPDATE OR DELETE ON users
+	FOR EACH ROW EXECUTE FUNCTION notify_user_change();
@@ -0,0 +1 @@
+ALTER TABLE oidc_states ADD COLUMN IF NOT EXISTS browser_hash TEXT NOT NULL DEFAULT '';
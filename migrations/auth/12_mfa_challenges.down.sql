@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_mfa_challenges_expires_at;
+
+DROP TABLE IF EXISTS mfa_challenges;
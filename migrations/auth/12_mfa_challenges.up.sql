@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+	id TEXT PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	attempts INT NOT NULL DEFAULT 0,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires_at ON public.mfa_challenges(expires_at);
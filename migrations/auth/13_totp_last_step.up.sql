@@ -0,0 +1 @@
+ALTER TABLE user_totp ADD COLUMN IF NOT EXISTS last_used_step BIGINT NOT NULL DEFAULT 0;
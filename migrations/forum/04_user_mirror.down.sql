@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS replication_checkpoints;
+
+ALTER TABLE users DROP COLUMN IF EXISTS synced_at;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
@@ -0,0 +1,13 @@
+-- users is a read-only mirror of the auth service's accounts, kept by the user sync worker.
+-- Uniqueness is the auth service's job; enforcing it here would reject renames applied mid-swap.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+
+ALTER TABLE users DROP COLUMN IF EXISTS password_hash;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS synced_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE TABLE IF NOT EXISTS replication_checkpoints (
+	name TEXT PRIMARY KEY,
+	position BIGINT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS version;
+
+ALTER TABLE topics DROP COLUMN IF EXISTS version;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
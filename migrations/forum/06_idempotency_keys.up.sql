@@ -0,0 +1,16 @@
+-- idempotency_keys keeps the replies to POST requests sent with an Idempotency-Key, so a retried request
+-- gets the first reply instead of writing again. A row without status_code is the lock held by the request
+-- still running; its short expires_at lets a retry take over the key when that request never finishes.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	user_id BIGINT NOT NULL,
+	idempotency_key TEXT NOT NULL,
+	request_hash TEXT NOT NULL,
+	status_code INT,
+	content_type TEXT NOT NULL DEFAULT '',
+	body BYTEA,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	expires_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS posts_bump_topic_version ON posts;
+
+DROP FUNCTION IF EXISTS bump_topic_posts_version();
+
+DROP TRIGGER IF EXISTS categories_bump_version ON categories;
+
+DROP FUNCTION IF EXISTS bump_categories_version();
+
+DROP FUNCTION IF EXISTS bump_collection_version(TEXT);
+
+DROP TABLE IF EXISTS collection_versions;
@@ -0,0 +1,51 @@
+-- collection_versions counts the changes to lists the API serves, so their ETag and Last-Modified can be
+-- checked without reading the list. Triggers bump "categories" for every category change and
+-- "topic_posts:<topic id>" for every change to a post of the topic, including cascades.
+-- Author profiles come from the user service and are not counted.
+CREATE TABLE IF NOT EXISTS collection_versions (
+	name TEXT PRIMARY KEY,
+	version BIGINT NOT NULL DEFAULT 1,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO collection_versions (name) VALUES ('categories') ON CONFLICT DO NOTHING;
+
+INSERT INTO collection_versions (name)
+SELECT 'topic_posts:' || id FROM topics
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION bump_collection_version(collection TEXT) RETURNS void AS $$
+	INSERT INTO collection_versions (name) VALUES (collection)
+	ON CONFLICT (name) DO UPDATE SET version = collection_versions.version + 1, updated_at = now();
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION bump_categories_version() RETURNS trigger AS $$
+BEGIN
+	PERFORM bump_collection_version('categories');
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS categories_bump_version ON categories;
+
+CREATE TRIGGER categories_bump_version
+	AFTER INSERT OR UPDATE OR DELETE ON categories
+	FOR EACH ROW EXECUTE FUNCTION bump_categories_version();
+
+CREATE OR REPLACE FUNCTION bump_topic_posts_version() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP <> 'INSERT' THEN
+		PERFORM bump_collection_version('topic_posts:' || OLD.topic_id);
+	END IF;
+	IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.topic_id <> OLD.topic_id) THEN
+		PERFORM bump_collection_version('topic_posts:' || NEW.topic_id);
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS posts_bump_topic_version ON posts;
+
+CREATE TRIGGER posts_bump_topic_version
+	AFTER INSERT OR UPDATE OR DELETE ON posts
+	FOR EACH ROW EXECUTE FUNCTION bump_topic_posts_version();
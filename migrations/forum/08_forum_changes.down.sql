@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS topics_notify_change ON topics;
+
+DROP TRIGGER IF EXISTS categories_notify_change ON categories;
+
+DROP FUNCTION IF EXISTS notify_forum_change();
@@ -0,0 +1,24 @@
+-- forum_changes tells every forum service node which categories and topics changed, so they can drop
+-- them from their read caches. Cascaded deletes are reported as well.
+CREATE OR REPLACE FUNCTION notify_forum_change() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		PERFORM pg_notify('forum_changes', json_build_object('table', TG_TABLE_NAME, 'id', OLD.id)::text);
+	ELSE
+		PERFORM pg_notify('forum_changes', json_build_object('table', TG_TABLE_NAME, 'id', NEW.id)::text);
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS categories_notify_change ON categories;
+
+CREATE TRIGGER categories_notify_change
+	AFTER INSERT OR UPDATE OR DELETE ON categories
+	FOR EACH ROW EXECUTE FUNCTION notify_forum_change();
+
+DROP TRIGGER IF EXISTS topics_notify_change ON topics;
+
+CREATE TRIGGER topics_notify_change
+	AFTER UPDATE OR DELETE ON topics
+	FOR EACH ROW EXECUTE FUNCTION notify_forum_change();
@@ -0,0 +1,3 @@
+-- lock_token names the request holding an unanswered key, so a request whose lock expired and was taken
+-- over cannot complete or release the new holder's lock.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS lock_token TEXT NOT NULL DEFAULT '';
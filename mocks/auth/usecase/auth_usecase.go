@@ -6,7 +6,6 @@ import (
 	context "context"
 
 	response "github.com/Van-programan/Forum_GO/internal/controller/response"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,6 +14,84 @@ type AuthUsecase struct {
 	mock.Mock
 }
 
+// ConfirmTOTP provides a mock function with given fields: ctx, userID, code
+func (_m *AuthUsecase) ConfirmTOTP(ctx context.Context, userID int64, code string) (*response.TOTPConfirmResponse, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 *response.TOTPConfirmResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*response.TOTPConfirmResponse, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *response.TOTPConfirmResponse); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TOTPConfirmResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, userID, password
+func (_m *AuthUsecase) DisableTOTP(ctx context.Context, userID int64, password string) error {
+	ret := _m.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, userID
+func (_m *AuthUsecase) EnrollTOTP(ctx context.Context, userID int64) (*response.TOTPEnrollResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 *response.TOTPEnrollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*response.TOTPEnrollResponse, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *response.TOTPEnrollResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TOTPEnrollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsSessionActive provides a mock function with given fields: ctx, refreshToken
 func (_m *AuthUsecase) IsSessionActive(ctx context.Context, refreshToken string) (*response.IsSessionActiveResponse, error) {
 	ret := _m.Called(ctx, refreshToken)
@@ -75,6 +152,36 @@ func (_m *AuthUsecase) Login(ctx context.Context, username string, password stri
 	return r0, r1
 }
 
+// LoginMFA provides a mock function with given fields: ctx, mfaToken, code
+func (_m *AuthUsecase) LoginMFA(ctx context.Context, mfaToken string, code string) (*response.LoginResponse, error) {
+	ret := _m.Called(ctx, mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginMFA")
+	}
+
+	var r0 *response.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*response.LoginResponse, error)); ok {
+		return rf(ctx, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *response.LoginResponse); ok {
+		r0 = rf(ctx, mfaToken, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Logout provides a mock function with given fields: ctx, refreshToken
 func (_m *AuthUsecase) Logout(ctx context.Context, refreshToken string) error {
 	ret := _m.Called(ctx, refreshToken)
@@ -123,9 +230,9 @@ func (_m *AuthUsecase) Refresh(ctx context.Context, refreshToken string) (*respo
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: ctx, username, role, password
-func (_m *AuthUsecase) Register(ctx context.Context, username string, role string, password string) (*response.RegisterResponse, error) {
-	ret := _m.Called(ctx, username, role, password)
+// Register provides a mock function with given fields: ctx, username, password
+func (_m *AuthUsecase) Register(ctx context.Context, username string, password string) (*response.RegisterResponse, error) {
+	ret := _m.Called(ctx, username, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -133,19 +240,19 @@ func (_m *AuthUsecase) Register(ctx context.Context, username string, role strin
 
 	var r0 *response.RegisterResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*response.RegisterResponse, error)); ok {
-		return rf(ctx, username, role, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*response.RegisterResponse, error)); ok {
+		return rf(ctx, username, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *response.RegisterResponse); ok {
-		r0 = rf(ctx, username, role, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *response.RegisterResponse); ok {
+		r0 = rf(ctx, username, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.RegisterResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, username, role, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/Van-programan/Forum_GO/internal/entity"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +32,126 @@ func (_m *UserClient) Close() error {
 	return r0
 }
 
+// GetUserProfile provides a mock function with given fields: ctx, userID
+func (_m *UserClient) GetUserProfile(ctx context.Context, userID int64) (*entity.UserProfile, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserProfile")
+	}
+
+	var r0 *entity.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.UserProfile, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.UserProfile); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProfiles provides a mock function with given fields: ctx, userIDs
+func (_m *UserClient) GetUserProfiles(ctx context.Context, userIDs []int64) (map[int64]entity.UserProfile, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserProfiles")
+	}
+
+	var r0 map[int64]entity.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]entity.UserProfile, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]entity.UserProfile); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]entity.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserStatus provides a mock function with given fields: ctx, userID
+func (_m *UserClient) GetUserStatus(ctx context.Context, userID int64) (*entity.UserStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStatus")
+	}
+
+	var r0 *entity.UserStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.UserStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.UserStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserStatuses provides a mock function with given fields: ctx, userIDs
+func (_m *UserClient) GetUserStatuses(ctx context.Context, userIDs []int64) (map[int64]entity.UserStatus, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStatuses")
+	}
+
+	var r0 map[int64]entity.UserStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]entity.UserStatus, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]entity.UserStatus); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]entity.UserStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsername provides a mock function with given fields: ctx, userID
 func (_m *UserClient) GetUsername(ctx context.Context, userID int64) (string, error) {
 	ret := _m.Called(ctx, userID)
@@ -89,6 +210,94 @@ func (_m *UserClient) GetUsernames(ctx context.Context, userIDs []int64) (map[in
 	return r0, r1
 }
 
+// StreamUsers provides a mock function with given fields: ctx, since, apply
+func (_m *UserClient) StreamUsers(ctx context.Context, since int64, apply func([]entity.UserRecord) error) (int64, error) {
+	ret := _m.Called(ctx, since, apply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func([]entity.UserRecord) error) (int64, error)); ok {
+		return rf(ctx, since, apply)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func([]entity.UserRecord) error) int64); ok {
+		r0 = rf(ctx, since, apply)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, func([]entity.UserRecord) error) error); ok {
+		r1 = rf(ctx, since, apply)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateToken provides a mock function with given fields: ctx, token
+func (_m *UserClient) ValidateToken(ctx context.Context, token string) (*entity.TokenIdentity, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateToken")
+	}
+
+	var r0 *entity.TokenIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.TokenIdentity, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.TokenIdentity); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TokenIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchUserChanges provides a mock function with given fields: ctx
+func (_m *UserClient) WatchUserChanges(ctx context.Context) (<-chan entity.UserChange, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserChanges")
+	}
+
+	var r0 <-chan entity.UserChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan entity.UserChange, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan entity.UserChange); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan entity.UserChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserClient creates a new instance of UserClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserClient(t interface {
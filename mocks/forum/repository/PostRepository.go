@@ -120,22 +120,60 @@ func (_m *PostRepository) GetByTopic(ctx context.Context, topicID int64) ([]enti
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, id, content
-func (_m *PostRepository) Update(ctx context.Context, id int64, content string) error {
-	ret := _m.Called(ctx, id, content)
+// GetByTopicVersion provides a mock function with given fields: ctx, topicID
+func (_m *PostRepository) GetByTopicVersion(ctx context.Context, topicID int64) (entity.CollectionVersion, error) {
+	ret := _m.Called(ctx, topicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTopicVersion")
+	}
+
+	var r0 entity.CollectionVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (entity.CollectionVersion, error)); ok {
+		return rf(ctx, topicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.CollectionVersion); ok {
+		r0 = rf(ctx, topicID)
+	} else {
+		r0 = ret.Get(0).(entity.CollectionVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, topicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, content, version
+func (_m *PostRepository) Update(ctx context.Context, id int64, content string, version int64) (int64, error) {
+	ret := _m.Called(ctx, id, content, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
-		r0 = rf(ctx, id, content)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) (int64, error)); ok {
+		return rf(ctx, id, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) int64); ok {
+		r0 = rf(ctx, id, content, version)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int64) error); ok {
+		r1 = rf(ctx, id, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewPostRepository creates a new instance of PostRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
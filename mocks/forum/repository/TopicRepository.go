@@ -120,17 +120,17 @@ func (_m *TopicRepository) GetByID(_a0 context.Context, _a1 int64) (*entity.Topi
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, id, title
-func (_m *TopicRepository) Update(ctx context.Context, id int64, title string) error {
-	ret := _m.Called(ctx, id, title)
+// SetLocked provides a mock function with given fields: ctx, id, locked
+func (_m *TopicRepository) SetLocked(ctx context.Context, id int64, locked bool) error {
+	ret := _m.Called(ctx, id, locked)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for SetLocked")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
-		r0 = rf(ctx, id, title)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, locked)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -138,6 +138,34 @@ func (_m *TopicRepository) Update(ctx context.Context, id int64, title string) e
 	return r0
 }
 
+// Update provides a mock function with given fields: ctx, id, title, version
+func (_m *TopicRepository) Update(ctx context.Context, id int64, title string, version int64) (int64, error) {
+	ret := _m.Called(ctx, id, title, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) (int64, error)); ok {
+		return rf(ctx, id, title, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int64) int64); ok {
+		r0 = rf(ctx, id, title, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int64) error); ok {
+		r1 = rf(ctx, id, title, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTopicRepository creates a new instance of TopicRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTopicRepository(t interface {
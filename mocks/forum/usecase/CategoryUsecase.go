@@ -90,6 +90,34 @@ func (_m *CategoryUsecase) GetAll(_a0 context.Context) ([]entity.Category, error
 	return r0, r1
 }
 
+// GetAllVersion provides a mock function with given fields: ctx
+func (_m *CategoryUsecase) GetAllVersion(ctx context.Context) (entity.CollectionVersion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllVersion")
+	}
+
+	var r0 entity.CollectionVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (entity.CollectionVersion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) entity.CollectionVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.CollectionVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *CategoryUsecase) GetByID(ctx context.Context, id int64) (*entity.Category, error) {
 	ret := _m.Called(ctx, id)
@@ -120,22 +148,32 @@ func (_m *CategoryUsecase) GetByID(ctx context.Context, id int64) (*entity.Categ
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, id, title, description
-func (_m *CategoryUsecase) Update(ctx context.Context, id int64, title string, description string) error {
-	ret := _m.Called(ctx, id, title, description)
+// Update provides a mock function with given fields: ctx, id, title, description, version
+func (_m *CategoryUsecase) Update(ctx context.Context, id int64, title string, description string, version int64) (int64, error) {
+	ret := _m.Called(ctx, id, title, description, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
-		r0 = rf(ctx, id, title, description)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int64) (int64, error)); ok {
+		return rf(ctx, id, title, description, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int64) int64); ok {
+		r0 = rf(ctx, id, title, description, version)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string, int64) error); ok {
+		r1 = rf(ctx, id, title, description, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewCategoryUsecase creates a new instance of CategoryUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -42,17 +42,17 @@ func (_m *PostUsecase) Create(_a0 context.Context, _a1 entity.Post) (int64, erro
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, postID, userID, role
-func (_m *PostUsecase) Delete(ctx context.Context, postID int64, userID int64, role string) error {
-	ret := _m.Called(ctx, postID, userID, role)
+// Delete provides a mock function with given fields: ctx, postID, actor
+func (_m *PostUsecase) Delete(ctx context.Context, postID int64, actor entity.Actor) error {
+	ret := _m.Called(ctx, postID, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
-		r0 = rf(ctx, postID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor) error); ok {
+		r0 = rf(ctx, postID, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,8 +60,45 @@ func (_m *PostUsecase) Delete(ctx context.Context, postID int64, userID int64, r
 	return r0
 }
 
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PostUsecase) GetByID(ctx context.Context, id int64) (*entity.Post, bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Post
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.Post, bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetByTopic provides a mock function with given fields: ctx, topicID
-func (_m *PostUsecase) GetByTopic(ctx context.Context, topicID int64) ([]entity.Post, error) {
+func (_m *PostUsecase) GetByTopic(ctx context.Context, topicID int64) ([]entity.Post, bool, error) {
 	ret := _m.Called(ctx, topicID)
 
 	if len(ret) == 0 {
@@ -69,8 +106,9 @@ func (_m *PostUsecase) GetByTopic(ctx context.Context, topicID int64) ([]entity.
 	}
 
 	var r0 []entity.Post
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.Post, error)); ok {
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.Post, bool, error)); ok {
 		return rf(ctx, topicID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Post); ok {
@@ -81,6 +119,40 @@ func (_m *PostUsecase) GetByTopic(ctx context.Context, topicID int64) ([]entity.
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = rf(ctx, topicID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, topicID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByTopicVersion provides a mock function with given fields: ctx, topicID
+func (_m *PostUsecase) GetByTopicVersion(ctx context.Context, topicID int64) (entity.CollectionVersion, error) {
+	ret := _m.Called(ctx, topicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTopicVersion")
+	}
+
+	var r0 entity.CollectionVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (entity.CollectionVersion, error)); ok {
+		return rf(ctx, topicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.CollectionVersion); ok {
+		r0 = rf(ctx, topicID)
+	} else {
+		r0 = ret.Get(0).(entity.CollectionVersion)
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
 		r1 = rf(ctx, topicID)
 	} else {
@@ -90,22 +162,32 @@ func (_m *PostUsecase) GetByTopic(ctx context.Context, topicID int64) ([]entity.
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, postID, userID, role, content
-func (_m *PostUsecase) Update(ctx context.Context, postID int64, userID int64, role string, content string) error {
-	ret := _m.Called(ctx, postID, userID, role, content)
+// Update provides a mock function with given fields: ctx, postID, actor, content, version
+func (_m *PostUsecase) Update(ctx context.Context, postID int64, actor entity.Actor, content string, version int64) (int64, error) {
+	ret := _m.Called(ctx, postID, actor, content, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string) error); ok {
-		r0 = rf(ctx, postID, userID, role, content)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor, string, int64) (int64, error)); ok {
+		return rf(ctx, postID, actor, content, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor, string, int64) int64); ok {
+		r0 = rf(ctx, postID, actor, content, version)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, entity.Actor, string, int64) error); ok {
+		r1 = rf(ctx, postID, actor, content, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewPostUsecase creates a new instance of PostUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -42,17 +42,17 @@ func (_m *TopicUsecase) Create(_a0 context.Context, _a1 entity.Topic) (int64, er
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, topicID, userID, role
-func (_m *TopicUsecase) Delete(ctx context.Context, topicID int64, userID int64, role string) error {
-	ret := _m.Called(ctx, topicID, userID, role)
+// Delete provides a mock function with given fields: ctx, topicID, actor
+func (_m *TopicUsecase) Delete(ctx context.Context, topicID int64, actor entity.Actor) error {
+	ret := _m.Called(ctx, topicID, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
-		r0 = rf(ctx, topicID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor) error); ok {
+		r0 = rf(ctx, topicID, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,38 +60,45 @@ func (_m *TopicUsecase) Delete(ctx context.Context, topicID int64, userID int64,
 	return r0
 }
 
-// GetByCategory provides a mock function with given fields: ct, categoryID
-func (_m *TopicUsecase) GetByCategory(ct context.Context, categoryID int64) ([]entity.Topic, error) {
-	ret := _m.Called(ct, categoryID)
+// GetByCategory provides a mock function with given fields: ctx, categoryID
+func (_m *TopicUsecase) GetByCategory(ctx context.Context, categoryID int64) ([]entity.Topic, bool, error) {
+	ret := _m.Called(ctx, categoryID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByCategory")
 	}
 
 	var r0 []entity.Topic
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.Topic, error)); ok {
-		return rf(ct, categoryID)
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.Topic, bool, error)); ok {
+		return rf(ctx, categoryID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Topic); ok {
-		r0 = rf(ct, categoryID)
+		r0 = rf(ctx, categoryID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Topic)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ct, categoryID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = rf(ctx, categoryID)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, categoryID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetByID provides a mock function with given fields: ctx, id
-func (_m *TopicUsecase) GetByID(ctx context.Context, id int64) (*entity.Topic, error) {
+func (_m *TopicUsecase) GetByID(ctx context.Context, id int64) (*entity.Topic, bool, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
@@ -99,8 +106,9 @@ func (_m *TopicUsecase) GetByID(ctx context.Context, id int64) (*entity.Topic, e
 	}
 
 	var r0 *entity.Topic
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.Topic, error)); ok {
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.Topic, bool, error)); ok {
 		return rf(ctx, id)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Topic); ok {
@@ -111,26 +119,32 @@ func (_m *TopicUsecase) GetByID(ctx context.Context, id int64) (*entity.Topic, e
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
 		r1 = rf(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Update provides a mock function with given fields: ctx, topicID, userID, role, title
-func (_m *TopicUsecase) Update(ctx context.Context, topicID int64, userID int64, role string, title string) error {
-	ret := _m.Called(ctx, topicID, userID, role, title)
+// SetLocked provides a mock function with given fields: ctx, topicID, actor, locked
+func (_m *TopicUsecase) SetLocked(ctx context.Context, topicID int64, actor entity.Actor, locked bool) error {
+	ret := _m.Called(ctx, topicID, actor, locked)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for SetLocked")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string) error); ok {
-		r0 = rf(ctx, topicID, userID, role, title)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor, bool) error); ok {
+		r0 = rf(ctx, topicID, actor, locked)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -138,6 +152,34 @@ func (_m *TopicUsecase) Update(ctx context.Context, topicID int64, userID int64,
 	return r0
 }
 
+// Update provides a mock function with given fields: ctx, topicID, actor, title, version
+func (_m *TopicUsecase) Update(ctx context.Context, topicID int64, actor entity.Actor, title string, version int64) (int64, error) {
+	ret := _m.Called(ctx, topicID, actor, title, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor, string, int64) (int64, error)); ok {
+		return rf(ctx, topicID, actor, title, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.Actor, string, int64) int64); ok {
+		r0 = rf(ctx, topicID, actor, title, version)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, entity.Actor, string, int64) error); ok {
+		r1 = rf(ctx, topicID, actor, title, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTopicUsecase creates a new instance of TopicUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTopicUsecase(t interface {
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader is an LRU that fills itself: a miss calls the load function given to Get and keeps what it
+// returns for ttl. Concurrent misses for a key share one load, and a load that started before an
+// invalidation is not stored, so a read racing with a write cannot put the old value back.
+type Loader[K comparable, V any] struct {
+	lru *LRU[K, V]
+	ttl time.Duration
+
+	loads  singleflight.Group
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// Stats counts the lookups a Loader answered itself and the ones it loaded.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+func NewLoader[K comparable, V any](size int, ttl time.Duration) *Loader[K, V] {
+	return &Loader[K, V]{lru: NewLRU[K, V](size), ttl: ttl}
+}
+
+// Get returns the cached value of key or the one load returns, which is cached unless it fails.
+func (c *Loader[K, V]) Get(ctx context.Context, key K, load func(context.Context) (V, error)) (V, error) {
+	if value, ok := c.lru.Get(key); ok {
+		c.hits.Add(1)
+		return value, nil
+	}
+	c.misses.Add(1)
+
+	epoch := c.lru.Epoch()
+	// Requests that miss after an invalidation start their own load instead of joining an older one.
+	loaded := c.loads.DoChan(fmt.Sprintf("%v@%d", key, epoch), func() (any, error) {
+		// Other requests may be waiting for the load, so it goes on when the one that started it gives up.
+		value, err := load(context.WithoutCancel(ctx))
+		if err == nil {
+			c.lru.PutAt(epoch, key, value, c.ttl)
+		}
+		return value, err
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	case res := <-loaded:
+		if res.Err != nil {
+			var zero V
+			return zero, res.Err
+		}
+		return res.Val.(V), nil
+	}
+}
+
+// Invalidate drops key; loads already running for it are not stored.
+func (c *Loader[K, V]) Invalidate(key K) {
+	c.lru.Invalidate(key)
+}
+
+// Purge drops every value; loads already running are not stored.
+func (c *Loader[K, V]) Purge() {
+	c.lru.Purge()
+}
+
+func (c *Loader[K, V]) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Entries: c.lru.Len()}
+}
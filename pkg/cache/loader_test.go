@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_SharesConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	c := NewLoader[int64, string](10, time.Minute)
+
+	release := make(chan struct{})
+	var loads atomic.Int32
+	load := func(context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.Get(ctx, 1, load)
+			assert.NoError(t, err)
+			assert.Equal(t, "value", value)
+		}()
+	}
+	// Every request has missed once all five misses are counted; the pause lets the last one join the load.
+	require.Eventually(t, func() bool { return c.misses.Load() == 5 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestLoader_CallerGivesUp(t *testing.T) {
+	c := NewLoader[int64, string](10, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.Get(ctx, 1, func(ctx context.Context) (string, error) {
+			<-release
+			return "value", ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+	require.Eventually(t, func() bool { return c.misses.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	// The load finishes without the caller and is cached for the next request.
+	close(release)
+	require.Eventually(t, func() bool {
+		_, ok := c.lru.Get(1)
+		return ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestLoader_LoadRacingInvalidationIsNotStored(t *testing.T) {
+	ctx := context.Background()
+	c := NewLoader[int64, string](10, time.Minute)
+
+	value, err := c.Get(ctx, 1, func(context.Context) (string, error) {
+		c.Invalidate(1)
+		return "old", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "old", value)
+
+	_, ok := c.lru.Get(1)
+	assert.False(t, ok)
+}
+
+func TestLoader_EvictsLeastRecentlyUsedAndExpires(t *testing.T) {
+	ctx := context.Background()
+	c := NewLoader[int64, int64](2, time.Minute)
+	load := func(id int64) func(context.Context) (int64, error) {
+		return func(context.Context) (int64, error) { return id, nil }
+	}
+
+	for _, id := range []int64{1, 2, 1, 3} {
+		_, err := c.Get(ctx, id, load(id))
+		require.NoError(t, err)
+	}
+	_, ok := c.lru.Get(2)
+	assert.False(t, ok)
+	_, ok = c.lru.Get(1)
+	assert.True(t, ok)
+
+	c.lru.items[1].Value.(*entry[int64, int64]).expiresAt = time.Now().Add(-time.Second)
+	_, ok = c.lru.Get(1)
+	assert.False(t, ok)
+}
+
+func TestLoader_LoadError(t *testing.T) {
+	ctx := context.Background()
+	c := NewLoader[int64, string](10, time.Minute)
+	loadErr := errors.New("db error")
+
+	_, err := c.Get(ctx, 1, func(context.Context) (string, error) { return "", loadErr })
+	assert.ErrorIs(t, err, loadErr)
+	_, ok := c.lru.Get(1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,125 @@
+// Package cache keeps values in memory for a limited time, evicting the least recently used ones
+// when it is full.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU holds at most size values, each until its own expiry. Every invalidation bumps the epoch, and
+// PutAt ignores values fetched in an earlier epoch, so a lookup racing with a change cannot store the
+// old value.
+type LRU[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[K]*list.Element
+	epoch uint64
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func NewLRU[K comparable, V any](size int) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:  size,
+		order: list.New(),
+		items: make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value of key unless it is missing or expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if time.Now().After(e.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// Put stores value for ttl.
+func (c *LRU[K, V]) Put(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(key, value, ttl)
+}
+
+// PutAt stores value for ttl unless the cache was invalidated since epoch was taken.
+func (c *LRU[K, V]) PutAt(epoch uint64, key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+	c.put(key, value, ttl)
+}
+
+func (c *LRU[K, V]) put(key K, value V, ttl time.Duration) {
+	e := &entry[K, V]{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = e
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(e)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+	}
+}
+
+// Invalidate drops key and bumps the epoch.
+func (c *LRU[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	if elem, ok := c.items[key]; ok {
+		c.order.Remove(elem)
+		delete(c.items, key)
+	}
+}
+
+// Purge drops every value and bumps the epoch.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	c.order.Init()
+	c.items = make(map[K]*list.Element)
+}
+
+// Epoch returns the current epoch, to be passed to PutAt with a value fetched after the call.
+func (c *LRU[K, V]) Epoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// Len returns the number of values held, including expired ones not yet dropped.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int, string](2)
+	c.Put(1, "one", time.Minute)
+	c.Put(2, "two", time.Minute)
+	c.Get(1)
+	c.Put(3, "three", time.Minute)
+
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+	value, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRU_Expires(t *testing.T) {
+	c := NewLRU[int, string](2)
+	c.Put(1, "one", -time.Second)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_StaleEpochIgnored(t *testing.T) {
+	c := NewLRU[int, string](10)
+
+	epoch := c.Epoch()
+	c.Invalidate(1)
+	c.PutAt(epoch, 1, "old", time.Minute)
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	c.PutAt(c.Epoch(), 1, "new", time.Minute)
+	value, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "new", value)
+}
+
+func TestLRU_Purge(t *testing.T) {
+	c := NewLRU[int, string](10)
+	c.Put(1, "one", time.Minute)
+	epoch := c.Epoch()
+
+	c.Purge()
+	c.PutAt(epoch, 2, "two", time.Minute)
+	assert.Equal(t, 0, c.Len())
+}
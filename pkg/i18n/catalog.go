@@ -0,0 +1,155 @@
+package i18n
+
+// Message keys used by the services.
+const (
+	DeletedUser     = "user.deleted"
+	UnavailableUser = "user.unavailable"
+
+	ChatInvalidMessage = "chat.invalid_message"
+	ChatSaveFailed     = "chat.save_failed"
+	ChatBanned         = "chat.banned"
+	ChatAuthRequired   = "chat.auth_required"
+
+	ValidationFailed = "validation.failed"
+	// ValidationInvalid is the message for validation rules that have no message of their own.
+	ValidationInvalid = "validation.invalid"
+)
+
+// ValidationRule returns the key of the message for a failed validation rule. Length rules have a
+// variant per kind of value ("string", "list" or "number"); other rules pass an empty kind.
+func ValidationRule(rule, kind string) string {
+	if kind == "" {
+		return "validation." + rule
+	}
+	return "validation." + rule + "." + kind
+}
+
+// ErrorCode returns the key of the translated message for an API error code. English messages come
+// from the errors themselves, so only other locales define these keys.
+func ErrorCode(code string) string {
+	return "error." + code
+}
+
+// Validation messages take the field name and the rule parameter. Messages that do not mention the
+// parameter use explicit argument indexes. Length messages with a ".one" suffix are used when the
+// parameter is 1.
+var catalogs = map[string]map[string]string{
+	English: {
+		DeletedUser:     "Deleted user",
+		UnavailableUser: "User #%d",
+
+		ChatInvalidMessage: "Invalid message format",
+		ChatSaveFailed:     "Failed to save message",
+		ChatBanned:         "You are banned",
+		ChatAuthRequired:   "Only signed-in users can send messages",
+
+		ValidationFailed:  "request validation failed",
+		ValidationInvalid: "%[1]s is invalid",
+
+		"validation.required":   "%[1]s is required",
+		"validation.numeric":    "%[1]s must contain only digits",
+		"validation.username":   "%[1]s may contain only letters, digits and the characters . _ -",
+		"validation.singleline": "%[1]s must not contain line breaks or control characters",
+		"validation.oneof":      "%s must be one of: %s",
+		"validation.gt":         "%s must be greater than %s",
+		"validation.http_url":   "%[1]s must be an absolute http or https URL",
+
+		"validation.min.string":     "%s must be at least %s characters long",
+		"validation.min.string.one": "%s must be at least %s character long",
+		"validation.min.list":       "%s must contain at least %s items",
+		"validation.min.list.one":   "%s must contain at least %s item",
+		"validation.min.number":     "%s must be at least %s",
+		"validation.max.string":     "%s must be at most %s characters long",
+		"validation.max.string.one": "%s must be at most %s character long",
+		"validation.max.list":       "%s must contain at most %s items",
+		"validation.max.list.one":   "%s must contain at most %s item",
+		"validation.max.number":     "%s must be at most %s",
+		"validation.len.string":     "%s must be exactly %s characters long",
+		"validation.len.string.one": "%s must be exactly %s character long",
+		"validation.len.list":       "%s must contain exactly %s items",
+		"validation.len.list.one":   "%s must contain exactly %s item",
+		"validation.len.number":     "%s must be %s",
+	},
+	// The Russian length messages are phrased so that the number needs no agreeing noun.
+	Russian: {
+		DeletedUser:     "Удаленный пользователь",
+		UnavailableUser: "Пользователь #%d",
+
+		ChatInvalidMessage: "Неверный формат сообщения",
+		ChatSaveFailed:     "Не удалось сохранить сообщение",
+		ChatBanned:         "Вы заблокированы",
+		ChatAuthRequired:   "Отправка сообщений доступна только авторизованным пользователям",
+
+		ValidationFailed:  "запрос не прошёл проверку",
+		ValidationInvalid: "поле %[1]s заполнено неверно",
+
+		"validation.required":   "поле %[1]s обязательно",
+		"validation.numeric":    "поле %[1]s должно содержать только цифры",
+		"validation.username":   "поле %[1]s может содержать только буквы, цифры и символы . _ -",
+		"validation.singleline": "поле %[1]s не должно содержать переводов строки и управляющих символов",
+		"validation.oneof":      "поле %s должно быть одним из: %s",
+		"validation.gt":         "поле %s должно быть больше %s",
+		"validation.http_url":   "поле %[1]s должно быть абсолютным адресом http или https",
+
+		"validation.min.string": "длина поля %s должна быть не меньше %s",
+		"validation.min.list":   "количество элементов в поле %s должно быть не меньше %s",
+		"validation.min.number": "поле %s должно быть не меньше %s",
+		"validation.max.string": "длина поля %s должна быть не больше %s",
+		"validation.max.list":   "количество элементов в поле %s должно быть не больше %s",
+		"validation.max.number": "поле %s должно быть не больше %s",
+		"validation.len.string": "длина поля %s должна быть равна %s",
+		"validation.len.list":   "количество элементов в поле %s должно быть равно %s",
+		"validation.len.number": "поле %s должно быть равно %s",
+
+		"error.internal_error":               "внутренняя ошибка сервера",
+		"error.unauthorized":                 "требуется авторизация",
+		"error.invalid_token":                "недействительный токен",
+		"error.insufficient_permissions":     "недостаточно прав",
+		"error.insufficient_scope":           "у токена нет нужного разрешения",
+		"error.token_validation_unavailable": "проверка токена временно недоступна",
+		"error.invalid_credentials":          "неверное имя пользователя или пароль",
+		"error.invalid_mfa_login":            "неверный токен или код второго фактора",
+		"error.invalid_mfa_code":             "неверный код второго фактора",
+		"error.refresh_token_required":       "требуется refresh-токен",
+		"error.invalid_refresh_token":        "refresh-токен недействителен или истёк",
+		"error.invalid_password":             "неверный пароль",
+		"error.password_policy":              "пароль не соответствует требованиям",
+		"error.user_banned":                  "пользователь заблокирован",
+		"error.user_not_found":               "пользователь не найден",
+		"error.category_not_found":           "категория не найдена",
+		"error.topic_not_found":              "тема не найдена",
+		"error.post_not_found":               "сообщение не найдено",
+		"error.topic_locked":                 "тема закрыта",
+		"error.user_service_unavailable":     "сервис пользователей недоступен, попробуйте позже",
+		"error.author_not_replicated":        "учётная запись автора ещё недоступна, попробуйте позже",
+		"error.version_conflict":             "ресурс изменился после того, как был прочитан",
+		"error.precondition_required":        "требуется заголовок If-Match",
+		"error.idempotency_key_reused":       "Idempotency-Key уже использован для другого запроса",
+		"error.idempotency_key_in_progress":  "запрос с этим Idempotency-Key ещё выполняется",
+		"error.request_too_large":            "тело запроса слишком большое",
+		"error.ban_expired":                  "срок блокировки должен быть в будущем",
+		"error.identity_linked":              "учётная запись провайдера привязана к другому пользователю",
+		"error.identity_not_found":           "привязанная учётная запись не найдена",
+		"error.invalid_mfa_token":            "токен второго фактора недействителен или истёк",
+		"error.invalid_oidc_state":           "параметр state OIDC недействителен или истёк",
+		"error.invalid_parameter":            "неверный параметр",
+		"error.invalid_profile":              "неверные данные профиля",
+		"error.invalid_request":              "неверный запрос",
+		"error.invalid_token_expiry":         "срок действия токена должен быть в будущем",
+		"error.last_login_method":            "нельзя отвязать единственный способ входа",
+		"error.mfa_already_enabled":          "TOTP уже включён",
+		"error.mfa_not_enrolled":             "TOTP не подключён",
+		"error.not_banned":                   "пользователь не заблокирован",
+		"error.oidc_authentication_failed":   "не удалось войти через OIDC",
+		"error.oidc_provider_error":          "провайдер учётных записей вернул ошибку",
+		"error.personal_token_not_found":     "персональный токен не найден",
+		"error.provider_already_linked":      "этот провайдер уже привязан к учётной записи",
+		"error.self_ban":                     "нельзя заблокировать самого себя",
+		"error.self_role_change":             "нельзя изменить собственную роль",
+		"error.unknown_provider":             "неизвестный провайдер OIDC",
+		"error.unknown_role":                 "неизвестная роль",
+		"error.unknown_scope":                "неизвестное разрешение токена",
+		"error.username_taken":               "имя пользователя уже занято",
+		"error.validation_failed":            "запрос не прошёл проверку",
+	},
+}
@@ -0,0 +1,113 @@
+// Package i18n translates server-generated strings. The locale of a request travels in its
+// context.Context; messages are looked up by key in per-locale catalogs.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+
+	// Default is used when neither the user nor the client asks for a supported locale.
+	Default = English
+)
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying locale.
+func NewContext(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, locale)
+}
+
+// FromContext returns the locale carried by ctx, or Default.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(ctxKey{}).(string); ok {
+		return locale
+	}
+	return Default
+}
+
+// Supported reports whether there is a catalog for locale.
+func Supported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Locales returns the supported locales, sorted.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Has reports whether the catalog for locale has key, without falling back to Default.
+func Has(locale, key string) bool {
+	_, ok := catalogs[locale][key]
+	return ok
+}
+
+// Negotiate picks the supported locale the client prefers most from an Accept-Language header,
+// falling back to Default. Region subtags are ignored, so "ru-RU" selects Russian.
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale := Normalize(tag); locale != "" && q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// Normalize maps a language tag such as "ru-RU" to a supported locale, or returns "" if there is none.
+func Normalize(tag string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	if !Supported(base) {
+		return ""
+	}
+	return base
+}
+
+// T translates key into the locale carried by ctx.
+func T(ctx context.Context, key string, args ...any) string {
+	return Translate(FromContext(ctx), key, args...)
+}
+
+// Translate formats the message for key in locale with args. Keys missing from the locale's catalog
+// fall back to Default, and keys missing there are returned as they are.
+func Translate(locale, key string, args ...any) string {
+	format, ok := Lookup(locale, key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Lookup returns the unformatted message for key in locale, falling back to Default.
+func Lookup(locale, key string) (string, bool) {
+	if format, ok := catalogs[locale][key]; ok {
+		return format, true
+	}
+	format, ok := catalogs[Default][key]
+	return format, ok
+}
@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", English},
+		{"ru", Russian},
+		{"ru-RU,ru;q=0.9", Russian},
+		{"de-DE,de;q=0.9", English},
+		{"en;q=0.5,ru;q=0.8", Russian},
+		{"fr,en;q=0.4,ru;q=0.3", English},
+		{"ru;q=abc,en", English},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Negotiate(tt.header), tt.header)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, Russian, Normalize(" RU-ru "))
+	assert.Equal(t, English, Normalize("en"))
+	assert.Equal(t, "", Normalize("de"))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Deleted user", T(context.Background(), DeletedUser))
+	assert.Equal(t, "Пользователь #7", T(NewContext(context.Background(), Russian), UnavailableUser, 7))
+}
+
+func TestTranslate_FallsBack(t *testing.T) {
+	assert.Equal(t, "request validation failed", Translate("de", ValidationFailed))
+	assert.Equal(t, "no.such.key", Translate(Russian, "no.such.key"))
+
+	_, ok := Lookup(English, ErrorCode("topic_not_found"))
+	assert.False(t, ok)
+	format, ok := Lookup(Russian, ErrorCode("topic_not_found"))
+	assert.True(t, ok)
+	assert.Equal(t, "тема не найдена", format)
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for key := range catalogs[English] {
+		_, ok := catalogs[Russian][key]
+		if !ok && !isEnglishOnly(key) {
+			t.Errorf("key %q has no Russian message", key)
+		}
+	}
+}
+
+// isEnglishOnly reports keys that other locales may leave out: singular length messages.
+func isEnglishOnly(key string) bool {
+	return strings.HasSuffix(key, ".one")
+}
+
+func TestHas(t *testing.T) {
+	assert.True(t, Has(Russian, ErrorCode("topic_not_found")))
+	assert.False(t, Has(Russian, "missing.key"))
+	assert.False(t, Has("de", ErrorCode("topic_not_found")))
+}
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []string{English, Russian}, Locales())
+}
@@ -8,6 +8,13 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	TokenTypeMFA = "mfa"
+
+	// MFATTL is how long an mfa challenge token is valid.
+	MFATTL = 5 * time.Minute
+)
+
 type JWT struct {
 	secretKey  string
 	accessTTL  time.Duration
@@ -18,12 +25,16 @@ func New(secretKey string, accessTTL time.Duration, refreshTTL time.Duration) *J
 	return &JWT{secretKey: secretKey, accessTTL: accessTTL, refreshTTL: refreshTTL}
 }
 
-func (j *JWT) GenerateAccessToken(userID int64, role string) (string, error) {
+// GenerateAccessToken issues an access token carrying the user's role, its permissions and the
+// categories the user moderates, so the forum service can authorize requests without a lookup.
+func (j *JWT) GenerateAccessToken(userID int64, role string, permissions []string, moderatedCategories []int64) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": userID,
-		"role":    role,
-		"exp":     time.Now().Add(j.accessTTL).Unix(),
-		"iat":     time.Now().Unix(),
+		"user_id":              userID,
+		"role":                 role,
+		"permissions":          permissions,
+		"moderated_categories": moderatedCategories,
+		"exp":                  time.Now().Add(j.accessTTL).Unix(),
+		"iat":                  time.Now().Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -41,6 +52,22 @@ func (j *JWT) GenerateRefreshToken(userID int64) (string, error) {
 	return token.SignedString([]byte(j.secretKey))
 }
 
+// GenerateMFAToken issues the short-lived challenge token returned by Login when the user has 2FA enabled.
+// It carries no role and is rejected by the access token middleware. challengeID ends up in the jti
+// claim, so the attempts made with the token can be counted.
+func (j *JWT) GenerateMFAToken(userID int64, challengeID string) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"type":    TokenTypeMFA,
+		"jti":     challengeID,
+		"exp":     time.Now().Add(MFATTL).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.secretKey))
+}
+
 func (j *JWT) ParseToken(tokenStr string) (*jwt.MapClaims, error) {
 	if tokenStr == "" {
 		return nil, errors.New("empty token string")
@@ -0,0 +1,166 @@
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type Config struct {
+	CertFile       string
+	KeyFile        string
+	CAFile         string
+	ReloadInterval time.Duration
+}
+
+// Reloader holds a certificate and CA pool read from files and swaps them for new ones when the files
+// change, so rotated certificates are picked up without a restart. Handshakes in flight keep the old ones.
+type Reloader struct {
+	cfg      Config
+	cert     atomic.Pointer[tls.Certificate]
+	roots    atomic.Pointer[x509.CertPool]
+	modTimes [3]time.Time
+	log      *zerolog.Logger
+}
+
+func NewReloader(cfg Config, log *zerolog.Logger) (*Reloader, error) {
+	r := &Reloader{cfg: cfg, log: log}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate, key and CA files. On error the previous ones stay in use.
+func (r *Reloader) Reload() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("mtls - Reload - tls.LoadX509KeyPair: %w", err)
+	}
+
+	caPEM, err := os.ReadFile(r.cfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("mtls - Reload - os.ReadFile: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return errors.New("mtls - Reload: no certificates in CA file " + r.cfg.CAFile)
+	}
+
+	r.cert.Store(&cert)
+	r.roots.Store(roots)
+	r.modTimes = modTimes
+	return nil
+}
+
+func (r *Reloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, fmt.Errorf("mtls - stat: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// Watch checks the files every ReloadInterval and reloads them when one has changed, until ctx is done.
+func (r *Reloader) Watch(ctx context.Context) {
+	if r.cfg.ReloadInterval <= 0 {
+		return
+	}
+	log := r.log.With().Str("op", "mtls.Reloader.Watch").Logger()
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		modTimes, err := r.stat()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to stat certificate files")
+			continue
+		}
+		if modTimes == r.modTimes {
+			continue
+		}
+		if err := r.Reload(); err != nil {
+			log.Error().Err(err).Msg("failed to reload certificates, keeping the old ones")
+			continue
+		}
+		log.Info().Msg("certificates reloaded")
+	}
+}
+
+// ServerTLSConfig requires clients to present a certificate signed by the CA.
+func (r *Reloader) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert.Load()},
+				ClientCAs:    r.roots.Load(),
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// ClientTLSConfig presents the client certificate and checks that the server's certificate is signed by
+// the CA and issued for serverName. The standard verification is replaced so that it uses the current CA pool.
+func (r *Reloader) ClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.cert.Load(), nil
+		},
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("mtls: server sent no certificate")
+			}
+			name := serverName
+			if name == "" {
+				name = cs.ServerName
+			}
+			opts := x509.VerifyOptions{
+				DNSName:       name,
+				Roots:         r.roots.Load(),
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// Identities lists the names a certificate was issued for: its URI SANs, such as SPIFFE IDs, and DNS SANs.
+func Identities(cert *x509.Certificate) []string {
+	ids := make([]string, 0, len(cert.URIs)+len(cert.DNSNames))
+	for _, uri := range cert.URIs {
+		ids = append(ids, uri.String())
+	}
+	return append(ids, cert.DNSNames...)
+}
@@ -0,0 +1,126 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams keep the tests fast; the encoding does not depend on the cost.
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2id_HashAndVerify(t *testing.T) {
+	h := NewArgon2id(testParams)
+
+	encoded, err := h.Hash("correct-horse-battery")
+	require.NoError(t, err)
+
+	parts := strings.Split(encoded, "$")
+	require.Len(t, parts, 6)
+	assert.Equal(t, "argon2id", parts[1])
+	assert.Equal(t, fmt.Sprintf("v=%d", argon2.Version), parts[2])
+	assert.Equal(t, "m=1024,t=1,p=1", parts[3])
+
+	ok, err := h.Verify(encoded, "correct-horse-battery")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(encoded, "wrong-horse-battery")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := h.Hash("correct-horse-battery")
+	require.NoError(t, err)
+	assert.NotEqual(t, encoded, other, "salts must differ")
+}
+
+func TestArgon2id_DecodesPHC(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("password"), salt, 2, 2048, 1, 24)
+	encoded := fmt.Sprintf("$argon2id$v=19$m=2048,t=2,p=1$%s$%s", b64.EncodeToString(salt), b64.EncodeToString(key))
+
+	params, gotSalt, gotKey, err := decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, Params{Memory: 2048, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 24}, params)
+	assert.Equal(t, salt, gotSalt)
+	assert.Equal(t, key, gotKey)
+
+	// A hash made with other parameters still verifies with the parameters it carries.
+	ok, err := NewArgon2id(testParams).Verify(encoded, "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestArgon2id_VerifiesBcrypt(t *testing.T) {
+	h := NewArgon2id(testParams)
+	legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ok, err := h.Verify(string(legacy), "password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(string(legacy), "password124")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.True(t, h.NeedsRehash(string(legacy)))
+}
+
+func TestArgon2id_NeedsRehash(t *testing.T) {
+	h := NewArgon2id(testParams)
+	encoded, err := h.Hash("password")
+	require.NoError(t, err)
+
+	assert.False(t, h.NeedsRehash(encoded))
+
+	tests := []struct {
+		name   string
+		change func(p *Params)
+	}{
+		{"memory", func(p *Params) { p.Memory *= 2 }},
+		{"iterations", func(p *Params) { p.Iterations++ }},
+		{"parallelism", func(p *Params) { p.Parallelism++ }},
+		{"salt length", func(p *Params) { p.SaltLength = 32 }},
+		{"key length", func(p *Params) { p.KeyLength = 64 }},
+	}
+	for _, tt := range tests {
+		params := testParams
+		tt.change(&params)
+		assert.True(t, NewArgon2id(params).NeedsRehash(encoded), tt.name)
+	}
+}
+
+func TestArgon2id_MalformedHash(t *testing.T) {
+	h := NewArgon2id(testParams)
+	valid, err := h.Hash("password")
+	require.NoError(t, err)
+	parts := strings.Split(valid, "$")
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		// Accounts created through OIDC have no local password and store an empty hash.
+		{"empty", ""},
+		{"not a hash", "password"},
+		{"other algorithm", strings.Replace(valid, "$argon2id$", "$argon2i$", 1)},
+		{"other version", strings.Replace(valid, "$v=19$", "$v=16$", 1)},
+		{"bad parameters", strings.Replace(valid, parts[3], "m=x,t=1,p=1", 1)},
+		{"bad salt", strings.Replace(valid, parts[4], "!!!", 1)},
+		{"bad key", strings.Replace(valid, parts[5], "!!!", 1)},
+		{"missing key", strings.Join(parts[:5], "$")},
+		{"truncated bcrypt", "$2a$10$short"},
+	}
+	for _, tt := range tests {
+		ok, err := h.Verify(tt.encoded, "password")
+		assert.ErrorIs(t, err, ErrInvalidHash, tt.name)
+		assert.False(t, ok, tt.name)
+		assert.True(t, h.NeedsRehash(tt.encoded), tt.name)
+	}
+}
@@ -0,0 +1,92 @@
+package password
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+const (
+	ViolationTooShort         = "too_short"
+	ViolationTooLong          = "too_long"
+	ViolationCommon           = "common"
+	ViolationContainsUsername = "contains_username"
+)
+
+// Violation is one policy rule a password breaks.
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PolicyError lists every rule a rejected password breaks.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return "password does not meet policy: " + strings.Join(messages, "; ")
+}
+
+type Policy struct {
+	MinLength int
+	MaxLength int
+	common    map[string]struct{}
+}
+
+// NewPolicy creates a policy. Passwords from common are rejected case-insensitively.
+func NewPolicy(minLength, maxLength int, common []string) *Policy {
+	set := make(map[string]struct{}, len(common))
+	for _, pw := range common {
+		set[strings.ToLower(pw)] = struct{}{}
+	}
+	return &Policy{MinLength: minLength, MaxLength: maxLength, common: set}
+}
+
+// LoadCommonPasswords reads a newline separated password list. An empty path returns the built-in list.
+func LoadCommonPasswords(path string) ([]string, error) {
+	if path == "" {
+		return strings.Fields(commonPasswords), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("password - LoadCommonPasswords - os.ReadFile(): %w", err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// Validate returns a *PolicyError when password breaks any rule.
+func (p *Policy) Validate(username, password string) error {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, Violation{ViolationTooShort, fmt.Sprintf("password must be at least %d characters long", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, Violation{ViolationTooLong, fmt.Sprintf("password must be at most %d characters long", p.MaxLength)})
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.common[lower]; ok {
+		violations = append(violations, Violation{ViolationCommon, "password is too common"})
+	}
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		violations = append(violations, Violation{ViolationContainsUsername, "password must not contain the username"})
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package password
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violationCodes(t *testing.T, err error) []string {
+	t.Helper()
+	var policyErr *PolicyError
+	require.True(t, errors.As(err, &policyErr), "want a *PolicyError, got %v", err)
+
+	codes := make([]string, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		codes[i] = v.Code
+	}
+	return codes
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	p := NewPolicy(8, 16, []string{"Password123", "qwertyuiop"})
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     []string
+	}{
+		{"too short", "alice", "k9#mQ2", []string{ViolationTooShort}},
+		{"too long", "alice", strings.Repeat("k9#mQ2", 3), []string{ViolationTooLong}},
+		{"common", "alice", "password123", []string{ViolationCommon}},
+		{"contains username", "Alice", "xx-alice-xx", []string{ViolationContainsUsername}},
+		{"several rules", "qwerty", "QWERTYUIOP", []string{ViolationCommon, ViolationContainsUsername}},
+	}
+	for _, tt := range tests {
+		err := p.Validate(tt.username, tt.password)
+		assert.Equal(t, tt.want, violationCodes(t, err), tt.name)
+	}
+}
+
+func TestPolicy_ValidateAccepts(t *testing.T) {
+	p := NewPolicy(8, 16, []string{"password123"})
+
+	assert.NoError(t, p.Validate("alice", "correct-horse"))
+	// Length counts characters, not bytes.
+	assert.NoError(t, p.Validate("alice", "пароль-длинный"))
+	// Without a username the username rule does not apply.
+	assert.NoError(t, p.Validate("", "correct-horse"))
+	// Without a maximum any length is allowed.
+	assert.NoError(t, NewPolicy(8, 0, nil).Validate("alice", strings.Repeat("x", 1000)))
+}
+
+func TestPolicyError_Error(t *testing.T) {
+	err := NewPolicy(8, 16, nil).Validate("alice", "alice")
+	assert.EqualError(t, err, "password does not meet policy: password must be at least 8 characters long; password must not contain the username")
+}
+
+func TestLoadCommonPasswords(t *testing.T) {
+	builtIn, err := LoadCommonPasswords("")
+	require.NoError(t, err)
+	assert.NotEmpty(t, builtIn)
+
+	path := filepath.Join(t.TempDir(), "common.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hunter2\nletmein\n\n"), 0o600))
+	list, err := LoadCommonPasswords(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hunter2", "letmein"}, list)
+
+	_, err = LoadCommonPasswords(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the RFC 6238 SHA-1 test key "12345678901234567890" in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode_RFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, tt.unix)
+		assert.True(t, Validate(rfcSecret, tt.want, time.Unix(tt.unix, 0)), tt.unix)
+	}
+}
+
+func TestCode_InvalidSecret(t *testing.T) {
+	_, err := Code("not base32!", time.Unix(59, 0))
+	assert.Error(t, err)
+}
+
+func TestValidateStep_Window(t *testing.T) {
+	// The code belongs to step 37037036, which covers 1111111080-1111111109, and is accepted from the
+	// first second of the previous step to the last second of the next one.
+	code := "081804"
+
+	tests := []struct {
+		name string
+		unix int64
+		ok   bool
+	}{
+		{"same step", 1111111109, true},
+		{"first second of previous step", 1111111050, true},
+		{"last second of next step", 1111111139, true},
+		{"two steps later", 1111111140, false},
+		{"two steps earlier", 1111111049, false},
+	}
+
+	for _, tt := range tests {
+		step, ok := ValidateStep(rfcSecret, code, time.Unix(tt.unix, 0))
+		assert.Equal(t, tt.ok, ok, tt.name)
+		if tt.ok {
+			assert.Equal(t, int64(37037036), step, tt.name)
+		}
+	}
+}
+
+func TestValidate_Malformed(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"empty code", rfcSecret, ""},
+		{"short code", rfcSecret, "81804"},
+		{"long code", rfcSecret, "0818040"},
+		{"padded code", rfcSecret, " 081804"},
+		{"letters", rfcSecret, "08l8o4"},
+		{"invalid secret", "not base32!", "081804"},
+		{"empty secret", "", "081804"},
+	}
+
+	for _, tt := range tests {
+		assert.False(t, Validate(tt.secret, tt.code, now), tt.name)
+	}
+}
+
+func TestValidate_LowercaseSecret(t *testing.T) {
+	assert.True(t, Validate(strings.ToLower(rfcSecret), "081804", time.Unix(1111111109, 0)))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	key, err := b32.DecodeString(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, secretLen)
+
+	code, err := Code(secret, time.Now())
+	require.NoError(t, err)
+	assert.True(t, Validate(secret, code, time.Now()))
+}